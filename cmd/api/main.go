@@ -3,7 +3,10 @@ package main
 import (
 	"github.com/curserio/chrono-api/config"
 	"github.com/curserio/chrono-api/internal/app"
+	"github.com/curserio/chrono-api/internal/auth"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/logger"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -31,10 +34,11 @@ func main() {
 			logger.AdaptZap,
 
 			// Server
-			func(l logger.Logger, cfg *config.Config) []func(*server.Server) {
+			func(l logger.Logger, cfg *config.Config, tokens *auth.TokenManager, keys *usecase.APIKeyUseCase) []func(*server.Server) {
 				return []func(*server.Server){
 					server.WithLogger(l),
 					server.WithDefaultLanguage(cfg.App.DefaultLanguage),
+					server.WithMiddleware(middleware.Authenticate(tokens, keys)),
 				}
 			},
 
@@ -44,9 +48,16 @@ func main() {
 			},
 		),
 
+		app.AuthModule,
 		app.RepositoryModule,
 		app.UsecaseModule,
 		app.HandlerModule,
+		app.EventsModule,
+		app.WebhookModule,
+		app.ReminderModule,
+		app.NotificationModule,
+		app.CalendarSyncModule,
+		app.RealtimeModule,
 		app.TelemetryModule,
 	).Run()
 }
@@ -2,14 +2,23 @@ package config
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	App      AppConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	App          AppConfig
+	Auth         AuthConfig
+	Outbox       OutboxConfig
+	Webhook      WebhookConfig
+	Reminder     ReminderConfig
+	Telegram     TelegramConfig
+	SMTP         SMTPConfig
+	CalendarSync CalendarSyncConfig
 }
 
 type ServerConfig struct {
@@ -25,10 +34,85 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+type AuthConfig struct {
+	JWTSecret       string
+	Issuer          string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// TelegramBotToken signs Telegram Login Widget and Mini App payloads
+	TelegramBotToken   string
+	TelegramAuthMaxAge time.Duration
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// RetryBackoff is the delay after the first failed delivery, doubled on each retry
+	RetryBackoff time.Duration
+	// MaxAttempts failed deliveries are dead-lettered
+	MaxAttempts int
+}
+
+type WebhookConfig struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts failed deliveries are dead-lettered
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	// AllowInsecureTargets lets webhooks go to http URLs and private
+	// addresses; for local development only
+	AllowInsecureTargets bool
+}
+
+type ReminderConfig struct {
+	// Offsets before the booking start at which reminders are sent
+	Offsets      []time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts failed reminders are given up
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// TelegramConfig configures notifications sent by the bot of
+// Auth.TelegramBotToken. They are disabled without a token.
+type TelegramConfig struct {
+	APIURL  string
+	Timeout time.Duration
+}
+
+// SMTPConfig configures email notifications. They are disabled without a host.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender, e.g. "Chrono <noreply@example.com>"
+	From    string
+	Timeout time.Duration
+}
+
+// CalendarSyncConfig configures the import of external ICS calendars.
+type CalendarSyncConfig struct {
+	PollInterval time.Duration
+	// Interval between two syncs of a source
+	Interval  time.Duration
+	BatchSize int
+	Timeout   time.Duration
+	// MaxSize is the largest calendar accepted, in bytes
+	MaxSize int64
+	// AllowInsecureSources lets calendars come from http URLs and private
+	// addresses; for local development only
+	AllowInsecureSources bool
+}
+
 type AppConfig struct {
 	Name            string
 	DevMode         bool
 	DefaultLanguage string
+	LocalesPath     string
 }
 
 func NewConfig() *Config {
@@ -40,6 +124,37 @@ func NewConfig() *Config {
 
 	v.AutomaticEnv()
 
+	v.SetDefault("auth.issuer", "chrono-api")
+	v.SetDefault("auth.access_token_ttl", 15*time.Minute)
+	v.SetDefault("auth.refresh_token_ttl", 30*24*time.Hour)
+	v.SetDefault("auth.telegram_auth_max_age", 24*time.Hour)
+	v.SetDefault("outbox.poll_interval", time.Second)
+	v.SetDefault("outbox.batch_size", 100)
+	v.SetDefault("outbox.retry_backoff", 10*time.Second)
+	v.SetDefault("outbox.max_attempts", 20)
+	v.SetDefault("webhook.timeout", 10*time.Second)
+	v.SetDefault("webhook.poll_interval", time.Second)
+	v.SetDefault("webhook.batch_size", 50)
+	v.SetDefault("webhook.max_attempts", 10)
+	v.SetDefault("webhook.allow_insecure_targets", false)
+	v.SetDefault("webhook.retry_backoff", 30*time.Second)
+	v.SetDefault("reminder.offsets", []string{"24h", "2h"})
+	v.SetDefault("reminder.poll_interval", 30*time.Second)
+	v.SetDefault("reminder.batch_size", 100)
+	v.SetDefault("reminder.max_attempts", 3)
+	v.SetDefault("reminder.retry_backoff", time.Minute)
+	v.SetDefault("telegram.api_url", "https://api.telegram.org")
+	v.SetDefault("telegram.timeout", 10*time.Second)
+	v.SetDefault("app.locales_path", "locales")
+	v.SetDefault("smtp.port", 587)
+	v.SetDefault("smtp.timeout", 10*time.Second)
+	v.SetDefault("calendar_sync.poll_interval", time.Minute)
+	v.SetDefault("calendar_sync.interval", 15*time.Minute)
+	v.SetDefault("calendar_sync.batch_size", 10)
+	v.SetDefault("calendar_sync.timeout", 30*time.Second)
+	v.SetDefault("calendar_sync.max_size", 5<<20)
+	v.SetDefault("calendar_sync.allow_insecure_sources", false)
+
 	if err := v.ReadInConfig(); err != nil {
 		log.Printf("Config file not found: %v, using env only", err)
 	}
@@ -60,7 +175,75 @@ func NewConfig() *Config {
 			Name:            v.GetString("app.name"),
 			DevMode:         v.GetBool("app.dev_mode"),
 			DefaultLanguage: v.GetString("app.default_language"),
+			LocalesPath:     v.GetString("app.locales_path"),
+		},
+		Auth: AuthConfig{
+			JWTSecret:       v.GetString("auth.jwt_secret"),
+			Issuer:          v.GetString("auth.issuer"),
+			AccessTokenTTL:  v.GetDuration("auth.access_token_ttl"),
+			RefreshTokenTTL: v.GetDuration("auth.refresh_token_ttl"),
+
+			TelegramBotToken:   v.GetString("auth.telegram_bot_token"),
+			TelegramAuthMaxAge: v.GetDuration("auth.telegram_auth_max_age"),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: v.GetDuration("outbox.poll_interval"),
+			BatchSize:    v.GetInt("outbox.batch_size"),
+			RetryBackoff: v.GetDuration("outbox.retry_backoff"),
+			MaxAttempts:  v.GetInt("outbox.max_attempts"),
+		},
+		Webhook: WebhookConfig{
+			Timeout:              v.GetDuration("webhook.timeout"),
+			PollInterval:         v.GetDuration("webhook.poll_interval"),
+			BatchSize:            v.GetInt("webhook.batch_size"),
+			MaxAttempts:          v.GetInt("webhook.max_attempts"),
+			RetryBackoff:         v.GetDuration("webhook.retry_backoff"),
+			AllowInsecureTargets: v.GetBool("webhook.allow_insecure_targets"),
+		},
+		Reminder: ReminderConfig{
+			Offsets:      parseDurations(v.GetStringSlice("reminder.offsets")),
+			PollInterval: v.GetDuration("reminder.poll_interval"),
+			BatchSize:    v.GetInt("reminder.batch_size"),
+			MaxAttempts:  v.GetInt("reminder.max_attempts"),
+			RetryBackoff: v.GetDuration("reminder.retry_backoff"),
+		},
+		Telegram: TelegramConfig{
+			APIURL:  v.GetString("telegram.api_url"),
+			Timeout: v.GetDuration("telegram.timeout"),
+		},
+		SMTP: SMTPConfig{
+			Host:     v.GetString("smtp.host"),
+			Port:     v.GetInt("smtp.port"),
+			Username: v.GetString("smtp.username"),
+			Password: v.GetString("smtp.password"),
+			From:     v.GetString("smtp.from"),
+			Timeout:  v.GetDuration("smtp.timeout"),
+		},
+		CalendarSync: CalendarSyncConfig{
+			PollInterval:         v.GetDuration("calendar_sync.poll_interval"),
+			Interval:             v.GetDuration("calendar_sync.interval"),
+			BatchSize:            v.GetInt("calendar_sync.batch_size"),
+			Timeout:              v.GetDuration("calendar_sync.timeout"),
+			MaxSize:              v.GetInt64("calendar_sync.max_size"),
+			AllowInsecureSources: v.GetBool("calendar_sync.allow_insecure_sources"),
 		},
 	}
 	return cfg
 }
+
+// parseDurations parses values such as "24h" and "90m", failing fast on a
+// misconfiguration.
+func parseDurations(values []string) []time.Duration {
+	durations := make([]time.Duration, 0, len(values))
+	for _, s := range values {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			log.Fatalf("invalid duration %q: %v", s, err)
+		}
+		if d <= 0 {
+			log.Fatalf("invalid duration %q: must be positive", s)
+		}
+		durations = append(durations, d)
+	}
+	return durations
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/auth"
+	"go.uber.org/fx"
+)
+
+var AuthModule = fx.Options(
+	fx.Provide(
+		func(cfg *config.Config) (*auth.TokenManager, error) {
+			if cfg.Auth.JWTSecret == "" {
+				return nil, errors.New("auth.jwt_secret is not configured")
+			}
+			return auth.NewTokenManager(cfg.Auth), nil
+		},
+		func(cfg *config.Config) *auth.TelegramVerifier {
+			return auth.NewTelegramVerifier(cfg.Auth.TelegramBotToken, cfg.Auth.TelegramAuthMaxAge)
+		},
+	),
+)
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/calendarsync"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// CalendarSyncModule fetches the registered ICS calendars of masters and
+// keeps their busy blocks up to date.
+var CalendarSyncModule = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			func(cfg *config.Config) *calendarsync.Fetcher {
+				return calendarsync.NewFetcher(cfg.CalendarSync)
+			},
+			fx.As(new(usecase.CalendarFetcher)),
+		),
+		func(repo repository.CalendarSourceRepository, uc *usecase.BusyBlockUseCase, l logger.Logger, cfg *config.Config) *calendarsync.Syncer {
+			return calendarsync.NewSyncer(repo, uc, l, cfg.CalendarSync)
+		},
+	),
+	fx.Invoke(func(lc fx.Lifecycle, s *calendarsync.Syncer) {
+		runInBackground(lc, s.Run)
+	}),
+)
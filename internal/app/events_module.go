@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/events"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// EventsModule runs the outbox dispatcher. Sinks are contributed to the
+// "event_sinks" group, e.g.:
+//
+//	fx.Provide(fx.Annotate(NewMySink, fx.As(new(events.Sink)), fx.ResultTags(`group:"event_sinks"`)))
+var EventsModule = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			func(cfg *config.Config, l logger.Logger) []events.Sink {
+				if !cfg.App.DevMode {
+					return nil
+				}
+				return []events.Sink{events.NewLogSink(l)}
+			},
+			fx.ResultTags(`group:"event_sinks,flatten"`),
+		),
+		fx.Annotate(
+			func(repo repository.OutboxRepository, sinks []events.Sink, l logger.Logger, cfg *config.Config) *events.Dispatcher {
+				return events.NewDispatcher(repo, sinks, l, cfg.Outbox)
+			},
+			fx.ParamTags(``, `group:"event_sinks"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, d *events.Dispatcher) {
+		runInBackground(lc, d.Run)
+	}),
+)
+
+// runInBackground starts run with the application and cancels it on stop,
+// waiting for it to return.
+func runInBackground(lc fx.Lifecycle, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
@@ -8,10 +8,21 @@ import (
 
 var HandlerModule = fx.Options(
 	fx.Invoke(
+		handler.NewOrganizationHandler,
+		handler.NewAuthHandler,
+		handler.NewAPIKeyHandler,
 		handler.NewMasterHandler,
+		handler.NewMasterEventsHandler,
 		handler.NewServiceHandler,
 		handler.NewBookingHandler,
 		handler.NewClientHandler,
 		handler.NewScheduleHandler,
+		handler.NewAvailabilityHandler,
+		handler.NewWebhookHandler,
+		handler.NewCalendarFeedHandler,
+		handler.NewBusyBlockHandler,
+		handler.NewCalDAVHandler,
+		handler.NewTimeOffHandler,
+		handler.NewReportHandler,
 	),
 )
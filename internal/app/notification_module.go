@@ -0,0 +1,82 @@
+package app
+
+import (
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/events"
+	"github.com/curserio/chrono-api/internal/i18n"
+	"github.com/curserio/chrono-api/internal/notification"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+	"golang.org/x/text/language"
+)
+
+// notifierOut contributes a notification backend to the outbox and the
+// reminder scheduler. Both lists are empty if the backend is disabled.
+type notifierOut struct {
+	fx.Out
+
+	Sinks     []events.Sink       `group:"event_sinks,flatten"`
+	Notifiers []reminder.Notifier `group:"reminder_notifiers,flatten"`
+}
+
+// NotificationModule contributes the Telegram and email backends, each
+// enabled by its configuration.
+var NotificationModule = fx.Options(
+	fx.Provide(
+		func(cfg *config.Config) (*i18n.Translator, error) {
+			return i18n.NewTranslator(cfg.App.LocalesPath)
+		},
+		func(
+			cfg *config.Config,
+			translator *i18n.Translator,
+			masters repository.MasterRepository,
+			clients repository.ClientRepository,
+			services repository.ServiceRepository,
+			l logger.Logger,
+		) notifierOut {
+			if cfg.Auth.TelegramBotToken == "" {
+				return notifierOut{}
+			}
+			client := notification.NewTelegramClient(cfg.Telegram.APIURL, cfg.Auth.TelegramBotToken, cfg.Telegram.Timeout)
+			n := notification.NewTelegramNotifier(client, translator, masters, clients, services, defaultLanguage(cfg), l)
+			return notifierOut{
+				Sinks:     []events.Sink{n},
+				Notifiers: []reminder.Notifier{n},
+			}
+		},
+		func(
+			cfg *config.Config,
+			translator *i18n.Translator,
+			masters repository.MasterRepository,
+			clients repository.ClientRepository,
+			services repository.ServiceRepository,
+			l logger.Logger,
+		) (notifierOut, error) {
+			if cfg.SMTP.Host == "" {
+				return notifierOut{}, nil
+			}
+			sender, err := notification.NewSMTPSender(cfg.SMTP)
+			if err != nil {
+				return notifierOut{}, err
+			}
+			n, err := notification.NewEmailNotifier(sender, translator, masters, clients, services, defaultLanguage(cfg), l)
+			if err != nil {
+				return notifierOut{}, err
+			}
+			return notifierOut{
+				Sinks:     []events.Sink{n},
+				Notifiers: []reminder.Notifier{n},
+			}, nil
+		},
+	),
+)
+
+func defaultLanguage(cfg *config.Config) language.Tag {
+	tag, err := language.Parse(cfg.App.DefaultLanguage)
+	if err != nil {
+		return language.English
+	}
+	return tag
+}
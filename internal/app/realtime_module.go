@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+
+	"github.com/curserio/chrono-api/internal/events"
+	"github.com/curserio/chrono-api/internal/realtime"
+	"github.com/curserio/chrono-api/internal/repository/postgres"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// RealtimeModule broadcasts the outbox events of masters to every API
+// instance over Postgres LISTEN/NOTIFY and hands them to the local streams.
+var RealtimeModule = fx.Options(
+	fx.Provide(
+		postgres.NewPubSub,
+		realtime.NewBroker,
+		fx.Annotate(
+			func(ps *postgres.PubSub) *realtime.Publisher {
+				return realtime.NewPublisher(ps)
+			},
+			fx.As(new(events.Sink)),
+			fx.ResultTags(`group:"event_sinks"`),
+		),
+		func(ps *postgres.PubSub, b *realtime.Broker, l logger.Logger) *realtime.Listener {
+			return realtime.NewListener(ps, b, l)
+		},
+	),
+	fx.Invoke(func(lc fx.Lifecycle, l *realtime.Listener, b *realtime.Broker) {
+		runInBackground(lc, l.Run)
+		// end open streams before the server waits for them to finish
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				b.Close()
+				return nil
+			},
+		})
+	}),
+)
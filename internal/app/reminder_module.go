@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/events"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// ReminderModule plans reminders from booking events and sends them.
+// Notifiers are contributed to the "reminder_notifiers" group.
+var ReminderModule = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			func(b repository.BookingRepository, r repository.ReminderRepository, tx repository.TxManager, cfg *config.Config) *reminder.Planner {
+				return reminder.NewPlanner(b, r, tx, cfg.Reminder.Offsets)
+			},
+			fx.As(new(events.Sink)),
+			fx.ResultTags(`group:"event_sinks"`),
+		),
+		fx.Annotate(
+			func(cfg *config.Config, l logger.Logger) []reminder.Notifier {
+				if !cfg.App.DevMode {
+					return nil
+				}
+				return []reminder.Notifier{reminder.NewLogNotifier(l)}
+			},
+			fx.ResultTags(`group:"reminder_notifiers,flatten"`),
+		),
+		fx.Annotate(
+			func(repo repository.ReminderRepository, notifiers []reminder.Notifier, l logger.Logger, cfg *config.Config) *reminder.Scheduler {
+				return reminder.NewScheduler(repo, notifiers, l, cfg.Reminder)
+			},
+			fx.ParamTags(``, `group:"reminder_notifiers"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, s *reminder.Scheduler) {
+		runInBackground(lc, s.Run)
+	}),
+)
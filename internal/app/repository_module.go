@@ -30,12 +30,42 @@ var RepositoryModule = fx.Options(
 	),
 
 	fx.Provide(
+		postgres.NewTxManager,
+		postgres.NewOrganizationRepository,
+		postgres.NewAdminRepository,
+		postgres.NewRefreshTokenRepository,
+		postgres.NewAPIKeyRepository,
 		postgres.NewMasterRepository,
 		postgres.NewServiceRepository,
 		postgres.NewBookingRepository,
 		postgres.NewClientRepository,
 		postgres.NewScheduleRepository,
+		postgres.NewOutboxRepository,
+		postgres.NewWebhookRepository,
+		postgres.NewWebhookDeliveryRepository,
+		postgres.NewReminderRepository,
+		postgres.NewCalendarFeedRepository,
+		postgres.NewBusyBlockRepository,
+		postgres.NewCalendarSourceRepository,
+		postgres.NewCalDAVAccountRepository,
+		postgres.NewTimeOffRepository,
 
+		func(m *postgres.TxManager) repository.TxManager {
+			return m
+		},
+
+		func(repo *postgres.OrganizationRepository) repository.OrganizationRepository {
+			return repo
+		},
+		func(repo *postgres.AdminRepository) repository.AdminRepository {
+			return repo
+		},
+		func(repo *postgres.RefreshTokenRepository) repository.RefreshTokenRepository {
+			return repo
+		},
+		func(repo *postgres.APIKeyRepository) repository.APIKeyRepository {
+			return repo
+		},
 		func(repo *postgres.MasterRepository) repository.MasterRepository {
 			return repo
 		},
@@ -51,5 +81,32 @@ var RepositoryModule = fx.Options(
 		func(repo *postgres.ScheduleRepository) repository.ScheduleRepository {
 			return repo
 		},
+		func(repo *postgres.OutboxRepository) repository.OutboxRepository {
+			return repo
+		},
+		func(repo *postgres.WebhookRepository) repository.WebhookRepository {
+			return repo
+		},
+		func(repo *postgres.WebhookDeliveryRepository) repository.WebhookDeliveryRepository {
+			return repo
+		},
+		func(repo *postgres.ReminderRepository) repository.ReminderRepository {
+			return repo
+		},
+		func(repo *postgres.CalendarFeedRepository) repository.CalendarFeedRepository {
+			return repo
+		},
+		func(repo *postgres.BusyBlockRepository) repository.BusyBlockRepository {
+			return repo
+		},
+		func(repo *postgres.CalendarSourceRepository) repository.CalendarSourceRepository {
+			return repo
+		},
+		func(repo *postgres.CalDAVAccountRepository) repository.CalDAVAccountRepository {
+			return repo
+		},
+		func(repo *postgres.TimeOffRepository) repository.TimeOffRepository {
+			return repo
+		},
 	),
 )
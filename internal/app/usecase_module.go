@@ -7,10 +7,20 @@ import (
 
 var UsecaseModule = fx.Options(
 	fx.Provide(
+		usecase.NewOrganizationUseCase,
+		usecase.NewAuthUseCase,
+		usecase.NewAPIKeyUseCase,
 		usecase.NewMasterUseCase,
 		usecase.NewServiceUseCase,
 		usecase.NewBookingUseCase,
 		usecase.NewClientUseCase,
 		usecase.NewScheduleUseCase,
+		usecase.NewAvailabilityUseCase,
+		usecase.NewWebhookUseCase,
+		usecase.NewCalendarFeedUseCase,
+		usecase.NewBusyBlockUseCase,
+		usecase.NewCalDAVUseCase,
+		usecase.NewTimeOffUseCase,
+		usecase.NewReportUseCase,
 	),
 )
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/events"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/webhook"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"go.uber.org/fx"
+)
+
+var WebhookModule = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			webhook.NewSink,
+			fx.As(new(events.Sink)),
+			fx.ResultTags(`group:"event_sinks"`),
+		),
+		func(repo repository.WebhookDeliveryRepository, l logger.Logger, cfg *config.Config) *webhook.Worker {
+			return webhook.NewWorker(repo, l, cfg.Webhook)
+		},
+	),
+	fx.Invoke(func(lc fx.Lifecycle, w *webhook.Worker) {
+		runInBackground(lc, w.Run)
+	}),
+)
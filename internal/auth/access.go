@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+)
+
+// The checks below are used by usecases once the entity is loaded. A context
+// without a principal belongs to internal callers (background jobs), which
+// are trusted; HTTP routes always require authentication.
+
+// RequireRole allows principals with any of the roles. Integrations pass, as
+// they are limited by scopes at the route level instead.
+func RequireRole(ctx context.Context, roles ...entity.Role) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.Role == entity.RoleIntegration || slices.Contains(roles, p.Role) {
+		return nil
+	}
+	return apiErrors.ErrForbidden
+}
+
+// AuthorizeMaster allows admins and the master itself.
+func AuthorizeMaster(ctx context.Context, masterID uuid.UUID) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || organizationWide(p) {
+		return nil
+	}
+	if p.Role == entity.RoleMaster && p.SubjectID == masterID {
+		return nil
+	}
+	return apiErrors.ErrForbidden
+}
+
+// AuthorizeClient allows admins and the client itself.
+func AuthorizeClient(ctx context.Context, clientID uuid.UUID) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || organizationWide(p) {
+		return nil
+	}
+	if p.Role == entity.RoleClient && p.SubjectID == clientID {
+		return nil
+	}
+	return apiErrors.ErrForbidden
+}
+
+// AuthorizeBooking allows admins and the master and client of the booking.
+func AuthorizeBooking(ctx context.Context, b *entity.Booking) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || organizationWide(p) {
+		return nil
+	}
+	switch p.Role {
+	case entity.RoleMaster:
+		if p.SubjectID == b.MasterID {
+			return nil
+		}
+	case entity.RoleClient:
+		if p.SubjectID == b.ClientID {
+			return nil
+		}
+	}
+	return apiErrors.ErrForbidden
+}
+
+// organizationWide reports whether the principal acts on behalf of the whole
+// organization. Integrations are limited by scopes at the route level instead.
+func organizationWide(p *Principal) bool {
+	return p.Role == entity.RoleAdmin || p.Role == entity.RoleIntegration
+}
+
+// HasRole reports whether the context belongs to a principal with the given role.
+func HasRole(ctx context.Context, role entity.Role) bool {
+	p, ok := PrincipalFromContext(ctx)
+	return ok && p.Role == role
+}
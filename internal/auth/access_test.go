@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeOwnership(t *testing.T) {
+	masterID, clientID, other := uuid.New(), uuid.New(), uuid.New()
+	booking := &entity.Booking{MasterID: masterID, ClientID: clientID}
+
+	as := func(role entity.Role, id uuid.UUID) context.Context {
+		return WithPrincipal(context.Background(), &Principal{SubjectID: id, Role: role})
+	}
+
+	tests := []struct {
+		name  string
+		check func() error
+		want  error
+	}{
+		{"admin touches a master", func() error { return AuthorizeMaster(as(entity.RoleAdmin, other), masterID) }, nil},
+		{"master touches itself", func() error { return AuthorizeMaster(as(entity.RoleMaster, masterID), masterID) }, nil},
+		{"master touches another master", func() error { return AuthorizeMaster(as(entity.RoleMaster, other), masterID) }, apiErrors.ErrForbidden},
+		{"client touches a master", func() error { return AuthorizeMaster(as(entity.RoleClient, masterID), masterID) }, apiErrors.ErrForbidden},
+		{"internal caller", func() error { return AuthorizeMaster(context.Background(), masterID) }, nil},
+		{"admin reviews", func() error { return RequireRole(as(entity.RoleAdmin, other), entity.RoleAdmin) }, nil},
+		{"master reviews", func() error { return RequireRole(as(entity.RoleMaster, masterID), entity.RoleAdmin) }, apiErrors.ErrForbidden},
+		{"client reads itself", func() error { return AuthorizeClient(as(entity.RoleClient, clientID), clientID) }, nil},
+		{"client reads another client", func() error { return AuthorizeClient(as(entity.RoleClient, other), clientID) }, apiErrors.ErrForbidden},
+		{"admin reads a booking", func() error { return AuthorizeBooking(as(entity.RoleAdmin, other), booking) }, nil},
+		{"master reads its booking", func() error { return AuthorizeBooking(as(entity.RoleMaster, masterID), booking) }, nil},
+		{"client reads its booking", func() error { return AuthorizeBooking(as(entity.RoleClient, clientID), booking) }, nil},
+		{"client reads the booking of another client", func() error { return AuthorizeBooking(as(entity.RoleClient, other), booking) }, apiErrors.ErrForbidden},
+		{"master reads the booking of another master", func() error { return AuthorizeBooking(as(entity.RoleMaster, other), booking) }, apiErrors.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// APIKeyPrefix marks chrono-api keys, e.g. in secret scanners.
+const APIKeyPrefix = "chr_"
+
+// NewAPIKey returns a random API key, its public prefix and the hash to persist.
+// The prefix identifies the key in listings without revealing it.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	id := make([]byte, 4)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = APIKeyPrefix + hex.EncodeToString(id)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, HashToken(key), nil
+}
+
+// NewFeedToken returns a random calendar feed token and the hash to persist.
+func NewFeedToken() (token, hash string, err error) {
+	return newSecret()
+}
+
+// NewAppPassword returns a random password for calendar clients and the
+// hash to persist.
+func NewAppPassword() (password, hash string, err error) {
+	return newSecret()
+}
+
+func newSecret() (secret, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret = base64.RawURLEncoding.EncodeToString(b)
+	return secret, HashToken(secret), nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+)
+
+// TelegramUser is the Telegram account confirmed by a signed login payload.
+type TelegramUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
+}
+
+// Name returns the display name of the user.
+func (u *TelegramUser) Name() string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		return u.Username
+	}
+	return name
+}
+
+// TelegramVerifier checks payloads signed by Telegram with the bot token, see
+// https://core.telegram.org/widgets/login#checking-authorization and
+// https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+type TelegramVerifier struct {
+	botToken string
+	maxAge   time.Duration
+	now      func() time.Time
+}
+
+func NewTelegramVerifier(botToken string, maxAge time.Duration) *TelegramVerifier {
+	return &TelegramVerifier{
+		botToken: botToken,
+		maxAge:   maxAge,
+		now:      time.Now,
+	}
+}
+
+// VerifyLoginWidget checks the fields sent by the Telegram Login Widget.
+func (v *TelegramVerifier) VerifyLoginWidget(fields map[string]string) (*TelegramUser, error) {
+	secret := sha256.Sum256([]byte(v.botToken))
+	if err := v.verify(fields, secret[:]); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(fields["id"], 10, 64)
+	if err != nil {
+		return nil, apiErrors.ErrInvalidCredentials
+	}
+	return &TelegramUser{
+		ID:        id,
+		FirstName: fields["first_name"],
+		LastName:  fields["last_name"],
+		Username:  fields["username"],
+	}, nil
+}
+
+// VerifyInitData checks the initData query string of a Telegram Mini App.
+func (v *TelegramVerifier) VerifyInitData(initData string) (*TelegramUser, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, apiErrors.ErrInvalidCredentials
+	}
+	fields := make(map[string]string, len(values))
+	for k := range values {
+		fields[k] = values.Get(k)
+	}
+
+	mac := hmac.New(sha256.New, []byte("WebAppData"))
+	mac.Write([]byte(v.botToken))
+	if err := v.verify(fields, mac.Sum(nil)); err != nil {
+		return nil, err
+	}
+
+	var user TelegramUser
+	if err := json.Unmarshal([]byte(fields["user"]), &user); err != nil || user.ID == 0 {
+		return nil, apiErrors.ErrInvalidCredentials
+	}
+	return &user, nil
+}
+
+// verify compares the hash field with the HMAC of the remaining fields
+// sorted by key, and rejects payloads older than maxAge.
+func (v *TelegramVerifier) verify(fields map[string]string, secret []byte) error {
+	if v.botToken == "" {
+		return apiErrors.ErrTelegramDisabled
+	}
+
+	hash, err := hex.DecodeString(fields["hash"])
+	if err != nil || len(hash) == 0 {
+		return apiErrors.ErrInvalidCredentials
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		if k != "hash" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+"="+fields[k])
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	if !hmac.Equal(mac.Sum(nil), hash) {
+		return apiErrors.ErrInvalidCredentials
+	}
+
+	authDate, err := strconv.ParseInt(fields["auth_date"], 10, 64)
+	if err != nil {
+		return apiErrors.ErrInvalidCredentials
+	}
+	if v.maxAge > 0 && v.now().Sub(time.Unix(authDate, 0)) > v.maxAge {
+		return apiErrors.ErrInvalidCredentials
+	}
+	return nil
+}
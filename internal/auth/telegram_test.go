@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBotToken = "123456:test-token"
+
+func sign(secret []byte, checkString string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(checkString))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestTelegramVerifier(t *testing.T) {
+	v := NewTelegramVerifier(testBotToken, time.Hour)
+	authDate := strconv.FormatInt(time.Now().Unix(), 10)
+
+	t.Run("login widget", func(t *testing.T) {
+		secret := sha256.Sum256([]byte(testBotToken))
+		fields := map[string]string{
+			"id":         "42",
+			"first_name": "Anna",
+			"auth_date":  authDate,
+		}
+		fields["hash"] = sign(secret[:], "auth_date="+authDate+"\nfirst_name=Anna\nid=42")
+
+		user, err := v.VerifyLoginWidget(fields)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), user.ID)
+		assert.Equal(t, "Anna", user.Name())
+
+		fields["first_name"] = "Eve"
+		_, err = v.VerifyLoginWidget(fields)
+		assert.ErrorIs(t, err, apiErrors.ErrInvalidCredentials)
+	})
+
+	t.Run("web app init data", func(t *testing.T) {
+		secret := hmac.New(sha256.New, []byte("WebAppData"))
+		secret.Write([]byte(testBotToken))
+
+		user := `{"id":7,"username":"anna","language_code":"ru"}`
+		values := url.Values{}
+		values.Set("auth_date", authDate)
+		values.Set("user", user)
+		values.Set("hash", sign(secret.Sum(nil), "auth_date="+authDate+"\nuser="+user))
+
+		got, err := v.VerifyInitData(values.Encode())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), got.ID)
+		assert.Equal(t, "anna", got.Name())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		old := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+		secret := sha256.Sum256([]byte(testBotToken))
+		fields := map[string]string{
+			"id":        "42",
+			"auth_date": old,
+			"hash":      sign(secret[:], "auth_date="+old+"\nid=42"),
+		}
+
+		_, err := v.VerifyLoginWidget(fields)
+		assert.ErrorIs(t, err, apiErrors.ErrInvalidCredentials)
+	})
+
+	t.Run("without a bot token", func(t *testing.T) {
+		_, err := NewTelegramVerifier("", time.Hour).VerifyInitData("auth_date=" + authDate + "&hash=00")
+		assert.ErrorIs(t, err, apiErrors.ErrTelegramDisabled)
+	})
+}
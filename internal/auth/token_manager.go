@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/pkg/jwt"
+	"github.com/google/uuid"
+)
+
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+type accessClaims struct {
+	jwt.RegisteredClaims
+	OrganizationID uuid.UUID   `json:"org"`
+	Role           entity.Role `json:"role"`
+}
+
+// TokenManager issues and verifies access tokens and generates refresh tokens.
+type TokenManager struct {
+	secret     []byte
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewTokenManager(cfg config.AuthConfig) *TokenManager {
+	return &TokenManager{
+		secret:     []byte(cfg.JWTSecret),
+		issuer:     cfg.Issuer,
+		accessTTL:  cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+	}
+}
+
+// IssueAccessToken returns a signed JWT for the principal and its expiry.
+func (m *TokenManager) IssueAccessToken(p Principal) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.accessTTL)
+
+	token, err := jwt.SignHS256(&accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   p.SubjectID.String(),
+			ID:        uuid.NewString(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		OrganizationID: p.OrganizationID,
+		Role:           p.Role,
+	}, m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// ParseAccessToken verifies the token and returns the principal it was issued for.
+func (m *TokenManager) ParseAccessToken(token string) (*Principal, error) {
+	claims := &accessClaims{}
+	if err := jwt.ParseHS256(token, m.secret, claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer != m.issuer {
+		return nil, jwt.ErrInvalidToken
+	}
+
+	subjectID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, jwt.ErrInvalidToken
+	}
+
+	return &Principal{
+		SubjectID:      subjectID,
+		OrganizationID: claims.OrganizationID,
+		Role:           claims.Role,
+	}, nil
+}
+
+// NewRefreshToken returns an opaque random token, the hash to persist and its expiry.
+func (m *TokenManager) NewRefreshToken() (string, string, time.Time, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashToken(token), time.Now().Add(m.refreshTTL), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of an opaque token. Only hashes are stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
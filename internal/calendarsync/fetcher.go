@@ -0,0 +1,94 @@
+package calendarsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/netguard"
+)
+
+var ErrTooLarge = errors.New("calendar exceeds the size limit")
+
+// Fetcher downloads ICS calendars over HTTP. Unless insecure sources are
+// allowed, it only fetches https URLs of public addresses.
+type Fetcher struct {
+	client        *http.Client
+	maxSize       int64
+	allowInsecure bool
+}
+
+func NewFetcher(cfg config.CalendarSyncConfig) *Fetcher {
+	return &Fetcher{
+		client:        netguard.NewClient(cfg.Timeout, cfg.AllowInsecureSources),
+		maxSize:       cfg.MaxSize,
+		allowInsecure: cfg.AllowInsecureSources,
+	}
+}
+
+// Check returns netguard.ErrForbidden for URLs that Fetch refuses.
+func (f *Fetcher) Check(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return netguard.CheckURL(u, f.allowInsecure)
+}
+
+// Fetch returns the calendar body. Reading more than the size limit fails
+// with ErrTooLarge.
+func (f *Fetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// sources registered before https was required may still use http
+	if err := netguard.CheckURL(req.URL, f.allowInsecure); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > f.maxSize {
+		resp.Body.Close()
+		return nil, ErrTooLarge
+	}
+	return &limitedBody{body: resp.Body, left: f.maxSize}, nil
+}
+
+type limitedBody struct {
+	body io.ReadCloser
+	left int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.left <= 0 {
+		// a single byte more tells a body of exactly the limit from a larger one
+		var probe [1]byte
+		if n, _ := b.body.Read(probe[:]); n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.left {
+		p = p[:b.left]
+	}
+	n, err := b.body.Read(p)
+	b.left -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
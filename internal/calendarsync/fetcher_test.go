@@ -0,0 +1,50 @@
+package calendarsync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/netguard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetcher_InternalSources(t *testing.T) {
+	called := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer server.Close()
+	plain := httptest.NewServer(server.Config.Handler)
+	defer plain.Close()
+
+	f := NewFetcher(config.CalendarSyncConfig{Timeout: time.Second, MaxSize: 1 << 20})
+	for _, url := range []string{
+		server.URL,
+		plain.URL,
+		"https://169.254.169.254/latest/meta-data",
+		"https://localhost/cal.ics",
+	} {
+		_, err := f.Fetch(context.Background(), url)
+		assert.ErrorIs(t, err, netguard.ErrForbidden, url)
+		assert.ErrorIs(t, f.Check(url), netguard.ErrForbidden, url)
+	}
+	assert.False(t, called, "the loopback server is not called")
+}
+
+func TestFetcher_InsecureSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(config.CalendarSyncConfig{Timeout: time.Second, MaxSize: 1 << 20, AllowInsecureSources: true})
+	body, err := f.Fetch(context.Background(), server.URL)
+	if assert.NoError(t, err) {
+		body.Close()
+	}
+}
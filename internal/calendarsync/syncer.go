@@ -0,0 +1,85 @@
+package calendarsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// SourceSyncer imports the calendar of a source into busy blocks.
+type SourceSyncer interface {
+	Sync(ctx context.Context, source *entity.CalendarSource) (*entity.ImportResult, error)
+}
+
+// Syncer periodically re-imports the registered calendar sources of all
+// organizations.
+type Syncer struct {
+	repo      repository.CalendarSourceRepository
+	syncer    SourceSyncer
+	log       logger.Logger
+	interval  time.Duration
+	every     time.Duration
+	batchSize int
+}
+
+func NewSyncer(repo repository.CalendarSourceRepository, syncer SourceSyncer, log logger.Logger, cfg config.CalendarSyncConfig) *Syncer {
+	return &Syncer{
+		repo:      repo,
+		syncer:    syncer,
+		log:       log,
+		interval:  cfg.PollInterval,
+		every:     cfg.Interval,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+// Run syncs due sources until the context is cancelled.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := s.SyncBatch(ctx)
+			if err != nil {
+				s.log.Error("failed to sync calendar sources", "error", err)
+			}
+			if err != nil || n < s.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncBatch syncs one batch of due sources and returns its size. A failing
+// source is recorded on the source and retried at its next sync.
+func (s *Syncer) SyncBatch(ctx context.Context) (int, error) {
+	sources, err := s.repo.Claim(ctx, s.batchSize, s.every)
+	if err != nil {
+		return 0, fmt.Errorf("claim calendar sources: %w", err)
+	}
+
+	for _, src := range sources {
+		ctx := tenant.WithOrganizationID(ctx, src.OrganizationID)
+		result, err := s.syncer.Sync(ctx, src)
+		if err != nil {
+			s.log.Warn("calendar source sync failed", "source_id", src.ID, "error", err)
+			continue
+		}
+		s.log.Info("calendar source synced", "source_id", src.ID,
+			"imported", result.Imported, "removed", result.Removed, "skipped", result.Skipped)
+	}
+	return len(sources), nil
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMaster Role = "master"
+	RoleClient Role = "client"
+
+	// RoleIntegration is an API key; its access is limited by scopes
+	RoleIntegration Role = "integration"
+)
+
+// Admin is a salon manager who administers an organization.
+type Admin struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	PasswordHash   string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// RefreshToken is a single-use token; every refresh revokes it and issues a
+// successor in the same family.
+type RefreshToken struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	SubjectID      uuid.UUID  `json:"subject_id"`
+	Role           Role       `json:"role"`
+	FamilyID       uuid.UUID  `json:"family_id"`
+	TokenHash      string     `json:"-"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -16,13 +16,20 @@ const (
 )
 
 type Booking struct {
-	ID        uuid.UUID     `json:"id"`
-	MasterID  uuid.UUID     `json:"master_id"`
-	ClientID  uuid.UUID     `json:"client_id"`
-	ServiceID uuid.UUID     `json:"service_id"`
-	StartTime time.Time     `json:"start_time"`
-	EndTime   time.Time     `json:"end_time"`
-	Status    BookingStatus `json:"status"`
-	CreatedAt time.Time     `json:"created_at"`
-	UpdatedAt time.Time     `json:"updated_at"`
+	ID             uuid.UUID     `json:"id"`
+	OrganizationID uuid.UUID     `json:"organization_id"`
+	MasterID       uuid.UUID     `json:"master_id"`
+	ClientID       uuid.UUID     `json:"client_id"`
+	ServiceID      uuid.UUID     `json:"service_id"`
+	StartTime      time.Time     `json:"start_time"`
+	EndTime        time.Time     `json:"end_time"`
+	Status         BookingStatus `json:"status"`
+	Price          float64       `json:"price"` // price of the service at booking time
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// CalendarUID identifies the booking in calendar apps across updates.
+func (b *Booking) CalendarUID() string {
+	return b.ID.String() + "@chrono"
 }
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusyBlock is time when a master is unavailable outside of bookings, e.g.
+// an event of the master's personal calendar. UID is unique per master, so
+// imports are idempotent.
+type BusyBlock struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	MasterID       uuid.UUID  `json:"master_id"`
+	SourceID       *uuid.UUID `json:"source_id,omitempty"`
+	UID            string     `json:"uid"`
+	// Href is the CalDAV resource name of blocks created by calendar clients
+	Href      *string   `json:"-"`
+	Summary   string    `json:"summary"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CalendarSource is an external ICS calendar synced into busy blocks.
+type CalendarSource struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	MasterID       uuid.UUID  `json:"master_id"`
+	Name           string     `json:"name"`
+	URL            string     `json:"url"`
+	NextSyncAt     time.Time  `json:"next_sync_at"`
+	LastSyncedAt   *time.Time `json:"last_synced_at,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ImportResult summarizes an ICS import.
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Removed  int `json:"removed"`
+	Skipped  int `json:"skipped"`
+}
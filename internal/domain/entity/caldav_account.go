@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalDAVAccount is the app password a master's calendar client signs in
+// with. Only the hash of the password is stored; it is shown once.
+type CalDAVAccount struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	MasterID       uuid.UUID `json:"master_id"`
+	TokenHash      string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is the secret ICS feed of a master's or client's bookings.
+// Only the hash of the token is stored; the feed URL is shown once.
+type CalendarFeed struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	OwnerRole      Role      `json:"owner_role"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	TokenHash      string    `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventBookingCreated     EventType = "booking.created"
+	EventBookingConfirmed   EventType = "booking.confirmed"
+	EventBookingCompleted   EventType = "booking.completed"
+	EventBookingCancelled   EventType = "booking.cancelled"
+	EventBookingRescheduled EventType = "booking.rescheduled"
+	// EventBookingOutsideHours announces a booking left outside the working
+	// hours of its master by a schedule change
+	EventBookingOutsideHours EventType = "booking.outside_hours"
+
+	EventScheduleCreated EventType = "schedule.created"
+	EventScheduleUpdated EventType = "schedule.updated"
+	EventScheduleDeleted EventType = "schedule.deleted"
+
+	EventTimeOffRequested EventType = "time_off.requested"
+	EventTimeOffApproved  EventType = "time_off.approved"
+	EventTimeOffRejected  EventType = "time_off.rejected"
+)
+
+// Event is a domain event stored in the outbox. The ID is stable across
+// redeliveries, so consumers can use it to drop duplicates.
+type Event struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	Type           EventType       `json:"type"`
+	AggregateID    uuid.UUID       `json:"aggregate_id"`
+	Payload        json.RawMessage `json:"payload"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Attempts       int             `json:"-"`
+	// DeliveredSinks accepted the event on earlier attempts
+	DeliveredSinks []string `json:"-"`
+}
+
+// NewEvent returns an event with the data marshalled as payload.
+func NewEvent(t EventType, aggregateID uuid.UUID, data any) (*Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:        t,
+		AggregateID: aggregateID,
+		Payload:     payload,
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+// BookingStatusEvent returns the event type announcing the booking status.
+// Returning a booking to pending is not announced.
+func BookingStatusEvent(status BookingStatus) (EventType, bool) {
+	switch status {
+	case BookingStatusConfirmed:
+		return EventBookingConfirmed, true
+	case BookingStatusCompleted:
+		return EventBookingCompleted, true
+	case BookingStatusCancelled:
+		return EventBookingCancelled, true
+	default:
+		return "", false
+	}
+}
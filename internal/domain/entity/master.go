@@ -8,6 +8,7 @@ import (
 
 type Master struct {
 	ID               uuid.UUID `json:"id"`
+	OrganizationID   uuid.UUID `json:"organization_id"`
 	Name             string    `json:"name"`
 	Email            *string   `json:"email,omitempty"`
 	Phone            *string   `json:"phone,omitempty"`
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReminderStatus string
+
+const (
+	ReminderStatusPending ReminderStatus = "pending"
+	// ReminderStatusSending reminders are claimed by a worker
+	ReminderStatusSending   ReminderStatus = "sending"
+	ReminderStatusSent      ReminderStatus = "sent"
+	ReminderStatusCancelled ReminderStatus = "cancelled"
+	ReminderStatusFailed    ReminderStatus = "failed"
+)
+
+// Reminder notifies the client of a booking Offset before its start.
+type Reminder struct {
+	ID             uuid.UUID      `json:"id"`
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	BookingID      uuid.UUID      `json:"booking_id"`
+	Offset         time.Duration  `json:"offset"`
+	SendAt         time.Time      `json:"send_at"`
+	Status         ReminderStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	SentAt         *time.Time     `json:"sent_at,omitempty"`
+	LastError      *string        `json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// ReminderDispatch is a claimed reminder together with its booking.
+type ReminderDispatch struct {
+	Reminder *Reminder
+	Booking  *Booking
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportPeriod is the length of the periods a report is split into.
+type ReportPeriod string
+
+const (
+	ReportPeriodDay   ReportPeriod = "day"
+	ReportPeriodWeek  ReportPeriod = "week" // starting on Monday
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+// RevenueQuery selects the bookings starting from From to To, exclusive, and
+// groups them by the periods of their start in Location and, optionally, by
+// master, service and status.
+type RevenueQuery struct {
+	From     time.Time
+	To       time.Time
+	Location string
+	Period   ReportPeriod
+	MasterID uuid.UUID       // uuid.Nil for all masters
+	Statuses []BookingStatus // empty for any status
+
+	ByMaster  bool
+	ByService bool
+	ByStatus  bool
+}
+
+// RevenueRow sums the prices of the bookings of a group. The fields of the
+// groupings not asked for are empty.
+type RevenueRow struct {
+	Period      time.Time
+	MasterID    *uuid.UUID
+	MasterName  string
+	ServiceID   *uuid.UUID
+	ServiceName string
+	Status      BookingStatus
+	Bookings    int
+	Revenue     float64
+}
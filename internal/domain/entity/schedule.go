@@ -15,14 +15,17 @@ const (
 )
 
 type Schedule struct {
-	ID        uuid.UUID    `json:"id"`
-	MasterID  uuid.UUID    `json:"master_id"`
-	Name      string       `json:"name"`
-	Type      ScheduleType `json:"type"`
-	StartDate time.Time    `json:"start_date"`
-	EndDate   *time.Time   `json:"end_date"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
+	ID             uuid.UUID    `json:"id"`
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	MasterID       uuid.UUID    `json:"master_id,omitzero"` // zero for templates
+	Name           string       `json:"name"`
+	Type           ScheduleType `json:"type"`
+	StartDate      time.Time    `json:"start_date"`
+	EndDate        *time.Time   `json:"end_date"`
+	Priority       int          `json:"priority"` // the highest applies where schedules overlap
+	IsTemplate     bool         `json:"is_template"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
 }
 
 type ScheduleDay struct {
@@ -47,3 +50,10 @@ type ScheduleSlot struct {
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
 }
+
+// ScheduleDetails is a schedule together with its days and date overrides.
+type ScheduleDetails struct {
+	*Schedule
+	Days  []*ScheduleDay  `json:"days"`
+	Slots []*ScheduleSlot `json:"slots"`
+}
@@ -7,12 +7,13 @@ import (
 )
 
 type Service struct {
-	ID          uuid.UUID `json:"id"`
-	MasterID    uuid.UUID `json:"master_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Duration    int       `json:"duration"` // in minutes
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	MasterID       uuid.UUID `json:"master_id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Duration       int       `json:"duration"` // in minutes
+	Price          float64   `json:"price"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
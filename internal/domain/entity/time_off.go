@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeOffStatus string
+
+const (
+	TimeOffStatusPending  TimeOffStatus = "pending"
+	TimeOffStatusApproved TimeOffStatus = "approved"
+	TimeOffStatusRejected TimeOffStatus = "rejected"
+)
+
+// TimeOffRequest asks for the days from StartDate to EndDate, inclusive, off
+// for a master. Approving it makes the days days off in the master's schedule.
+type TimeOffRequest struct {
+	ID             uuid.UUID     `json:"id"`
+	OrganizationID uuid.UUID     `json:"organization_id"`
+	MasterID       uuid.UUID     `json:"master_id"`
+	StartDate      time.Time     `json:"start_date"`
+	EndDate        time.Time     `json:"end_date"`
+	Reason         string        `json:"reason"`
+	Status         TimeOffStatus `json:"status"`
+	ReviewedBy     *uuid.UUID    `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time    `json:"reviewed_at,omitempty"`
+	ReviewComment  string        `json:"review_comment,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
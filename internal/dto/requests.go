@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"encoding/json"
+	"strconv"
 	"time"
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
@@ -8,6 +10,106 @@ import (
 	"github.com/google/uuid"
 )
 
+type CreateOrganizationRequest struct {
+	Name     string             `json:"name" validate:"required,min=2,max=255"`
+	Timezone string             `json:"timezone" validate:"omitempty,timezone"`
+	Admin    CreateAdminRequest `json:"admin" validate:"required"`
+}
+
+type CreateAdminRequest struct {
+	Name     string `json:"name" validate:"required,min=2,max=255"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,max=72"` // bcrypt ограничивает длину 72 байтами
+}
+
+type UpdateOrganizationRequest struct {
+	Name     string `json:"name" validate:"required,min=2,max=255"`
+	Timezone string `json:"timezone" validate:"required,timezone"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// TelegramLoginRequest carries either the initData of a Telegram Mini App or
+// the fields sent by the Telegram Login Widget.
+type TelegramLoginRequest struct {
+	InitData  string `json:"init_data,omitempty" validate:"required_without=Hash"`
+	ID        int64  `json:"id,omitempty" validate:"required_without=InitData"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Username  string `json:"username,omitempty"`
+	PhotoURL  string `json:"photo_url,omitempty"`
+	AuthDate  int64  `json:"auth_date,omitempty" validate:"required_without=InitData"`
+	Hash      string `json:"hash,omitempty" validate:"required_without=InitData"`
+}
+
+// WidgetFields returns the signed Login Widget fields, omitting empty ones
+// exactly as Telegram does when computing the hash.
+func (r *TelegramLoginRequest) WidgetFields() map[string]string {
+	fields := map[string]string{
+		"id":        strconv.FormatInt(r.ID, 10),
+		"auth_date": strconv.FormatInt(r.AuthDate, 10),
+		"hash":      r.Hash,
+	}
+	optional := map[string]string{
+		"first_name": r.FirstName,
+		"last_name":  r.LastName,
+		"username":   r.Username,
+		"photo_url":  r.PhotoURL,
+	}
+	for k, v := range optional {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,min=2,max=255"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=masters:read masters:write services:read services:write clients:read clients:write bookings:read bookings:write schedules:read schedules:write reports:read"`
+	// ExpiresAt is optional; keys without it do not expire
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreateAPIKeyResponse contains the plain key, which is returned only once.
+type CreateAPIKeyResponse struct {
+	*entity.APIKey
+	Key string `json:"key"`
+}
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" validate:"required,https_url,max=2048"`
+	// EventTypes filters the delivered events; empty means all events
+	EventTypes []entity.EventType `json:"event_types" validate:"dive,oneof=booking.created booking.confirmed booking.completed booking.cancelled booking.rescheduled booking.outside_hours schedule.created schedule.updated schedule.deleted time_off.requested time_off.approved time_off.rejected"`
+}
+
+type UpdateWebhookRequest struct {
+	URL        string             `json:"url" validate:"required,https_url,max=2048"`
+	EventTypes []entity.EventType `json:"event_types" validate:"dive,oneof=booking.created booking.confirmed booking.completed booking.cancelled booking.rescheduled booking.outside_hours schedule.created schedule.updated schedule.deleted time_off.requested time_off.approved time_off.rejected"`
+	Active     *bool              `json:"active" validate:"required"`
+}
+
+// CreateWebhookResponse contains the signing secret, which is returned only once.
+type CreateWebhookResponse struct {
+	*entity.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
 type CreateMasterRequest struct {
 	Name             string  `json:"name" validate:"required,min=2,max=255"`
 	Email            *string `json:"email,omitempty" validate:"omitempty,email"`
@@ -79,6 +181,12 @@ type CreateBookingRequest struct {
 	ClientID  uuid.UUID `json:"client_id" validate:"required"`
 }
 
+type RescheduleBookingRequest struct {
+	Date      time.Time `json:"date" validate:"required"`
+	StartTime string    `json:"start_time" validate:"required"`
+	EndTime   string    `json:"end_time" validate:"required"`
+}
+
 type BookingStatus string
 
 const (
@@ -140,7 +248,8 @@ type CreateScheduleRequest struct {
 	Type      ScheduleType  `json:"type" validate:"required,oneof=weekly cyclic custom"`
 	StartDate *time.Time    `json:"start_date,omitempty"`
 	EndDate   *time.Time    `json:"end_date,omitempty"`
-	Days      []ScheduleDay `json:"days" validate:"required,dive"`
+	Priority  int           `json:"priority" validate:"min=0,max=100"`
+	Days      []ScheduleDay `json:"days,omitempty" validate:"omitempty,dive"`
 }
 
 type ScheduleDay struct {
@@ -151,13 +260,69 @@ type ScheduleDay struct {
 	IsDayOff  bool    `json:"is_day_off"`
 }
 
+// UpdateScheduleRequest changes the fields present in the request. Days,
+// if present, replace all days of the schedule.
 type UpdateScheduleRequest struct {
-	MasterID  uuid.UUID     `json:"master_id" validate:"required"`
-	Name      string        `json:"name" validate:"required,min=1,max=100"`
-	Type      ScheduleType  `json:"type" validate:"required,oneof=weekly cyclic custom"`
+	MasterID  *uuid.UUID    `json:"master_id,omitempty"`
+	Name      *string       `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Type      *ScheduleType `json:"type,omitempty" validate:"omitempty,oneof=weekly cyclic custom"`
 	StartDate *time.Time    `json:"start_date,omitempty"`
-	EndDate   *time.Time    `json:"end_date,omitempty"`
-	Days      []ScheduleDay `json:"days" validate:"required,dive"`
+	// EndDate null makes the schedule open-ended
+	EndDate  Nullable[time.Time] `json:"end_date"`
+	Priority *int                `json:"priority,omitempty" validate:"omitempty,min=0,max=100"`
+	Days     []ScheduleDay       `json:"days,omitempty" validate:"omitempty,dive"`
+}
+
+// Nullable is a field of a partial update that tells an explicit null from
+// an omitted field: Set is true if the field is present, Value is nil for
+// null.
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Value = &v
+	return nil
+}
+
+// CreateScheduleTemplateRequest creates a schedule not bound to a master.
+type CreateScheduleTemplateRequest struct {
+	Name     string        `json:"name" validate:"required,min=1,max=100"`
+	Type     ScheduleType  `json:"type" validate:"required,oneof=weekly cyclic custom"`
+	Priority int           `json:"priority" validate:"min=0,max=100"`
+	Days     []ScheduleDay `json:"days,omitempty" validate:"omitempty,dive"`
+}
+
+// InstantiateTemplateRequest creates a schedule of the master from a template.
+// The name and priority default to the template's.
+type InstantiateTemplateRequest struct {
+	MasterID  uuid.UUID  `json:"master_id" validate:"required"`
+	Name      *string    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	StartDate time.Time  `json:"start_date" validate:"required"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+	Priority  *int       `json:"priority,omitempty" validate:"omitempty,min=0,max=100"`
+}
+
+// CloneScheduleRequest copies a schedule. Omitted fields keep the values of
+// the source; a new start date moves the end date and the date overrides by
+// the same number of days.
+type CloneScheduleRequest struct {
+	MasterID     *uuid.UUID `json:"master_id,omitempty"`
+	Name         *string    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	StartDate    *time.Time `json:"start_date,omitempty"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	Priority     *int       `json:"priority,omitempty" validate:"omitempty,min=0,max=100"`
+	IncludeSlots bool       `json:"include_slots"`
 }
 
 type CreateScheduleDayRequest struct {
@@ -194,6 +359,63 @@ type UpdateScheduleSlotRequest struct {
 	IsDayOff   bool      `json:"is_day_off"`
 }
 
+// SetSlotRangeRequest overrides every date from From to To, inclusive, with
+// the same hours or a day off.
+type SetSlotRangeRequest struct {
+	From      time.Time `json:"from" validate:"required"`
+	To        time.Time `json:"to" validate:"required"`
+	StartTime *string   `json:"start_time,omitempty"` // формат "15:04"
+	EndTime   *string   `json:"end_time,omitempty"`
+	IsDayOff  bool      `json:"is_day_off"`
+}
+
+// SlotRangeResponse lists the overrides created for a range and the bookings
+// left outside the working hours.
+type SlotRangeResponse struct {
+	Slots     []*entity.ScheduleSlot `json:"slots"`
+	Conflicts []*entity.Booking      `json:"conflicts"`
+}
+
+// ScheduleImpactResponse answers a dry run of a change of a schedule with the
+// bookings the change would leave outside the working hours.
+type ScheduleImpactResponse struct {
+	Conflicts []*entity.Booking `json:"conflicts"`
+}
+
+// CreateTimeOffRequest asks for the days from StartDate to EndDate, inclusive,
+// off for the master.
+type CreateTimeOffRequest struct {
+	MasterID  uuid.UUID `json:"master_id" validate:"required"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required"`
+	Reason    string    `json:"reason" validate:"max=500"`
+}
+
+// ReviewTimeOffRequest approves or rejects a time-off request.
+type ReviewTimeOffRequest struct {
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// TimeOffApprovalResponse is the approved request with the bookings on its
+// days, whose clients have been notified.
+type TimeOffApprovalResponse struct {
+	Request   *entity.TimeOffRequest `json:"request"`
+	Conflicts []*entity.Booking      `json:"conflicts"`
+}
+
+// ScheduleResponse is a schedule with its days.
+type ScheduleResponse struct {
+	*entity.Schedule
+	Days []*entity.ScheduleDay `json:"days"`
+}
+
+// ScheduleDetailsResponse is a schedule with its days and date overrides,
+// optionally expanded into the hours of each date of a window.
+type ScheduleDetailsResponse struct {
+	*entity.ScheduleDetails
+	Dates []ScheduleForDateResponse `json:"dates,omitempty"`
+}
+
 // ScheduleForDateResponse — финальный ответ, если запрашивается расписание на конкретную дату.
 // Если есть override — используется он, иначе возвращается имя расписания.
 type ScheduleForDateResponse struct {
@@ -203,4 +425,105 @@ type ScheduleForDateResponse struct {
 	EndTime   *string   `json:"end_time,omitempty"`
 	IsDayOff  bool      `json:"is_day_off"`
 	Source    string    `json:"source"` // "override" или имя расписания
+	// Busy lists the periods of the date taken by external calendars
+	Busy []Period `json:"busy,omitempty"`
+}
+
+type Period struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// AvailabilityResponse is the availability of a master on a date: the
+// working hours and the periods already taken.
+type AvailabilityResponse struct {
+	MasterID uuid.UUID                 `json:"master_id"`
+	Date     string                    `json:"date"`
+	Schedule []ScheduleForDateResponse `json:"schedule"`
+	// Booked lists the periods taken by bookings, without their details
+	Booked []Period `json:"booked"`
+}
+
+// CreateCalendarSourceRequest registers an ICS calendar synced into busy blocks.
+type CreateCalendarSourceRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	URL  string `json:"url" validate:"required,url,max=2048"`
+}
+
+// CalendarFeedResponse contains the secret feed URL, which is returned only once.
+type CalendarFeedResponse struct {
+	*entity.CalendarFeed
+	URL string `json:"url"`
+}
+
+// CalDAVAccountResponse contains the app password, which is returned only once.
+type CalDAVAccountResponse struct {
+	*entity.CalDAVAccount
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// UtilizationReport compares the working time of masters, as given by their
+// schedules, with the time booked, for the dates From to To, inclusive.
+type UtilizationReport struct {
+	From    string              `json:"from"`
+	To      string              `json:"to"`
+	Masters []MasterUtilization `json:"masters"`
+}
+
+// Utilization is the booked share of the working time. Bookings outside the
+// working hours count too, so Rate may exceed 1.
+type Utilization struct {
+	WorkingMinutes int     `json:"working_minutes"`
+	BookedMinutes  int     `json:"booked_minutes"`
+	Rate           float64 `json:"utilization"` // 0 without working time
+}
+
+type MasterUtilization struct {
+	MasterID   uuid.UUID `json:"master_id"`
+	MasterName string    `json:"master_name"`
+	Utilization
+	Services []ServiceUtilization `json:"services"`
+	Days     []DayUtilization     `json:"days"`
+}
+
+type DayUtilization struct {
+	Date string `json:"date"`
+	Utilization
+	Services []ServiceUtilization `json:"services"`
+}
+
+// ServiceUtilization is the time booked for a service.
+type ServiceUtilization struct {
+	ServiceID     uuid.UUID `json:"service_id"`
+	ServiceName   string    `json:"service_name"`
+	Bookings      int       `json:"bookings"`
+	BookedMinutes int       `json:"booked_minutes"`
+}
+
+// RevenueReport sums the prices of the bookings starting from From to To,
+// inclusive, in the organization's timezone. Prices are the ones at booking
+// time.
+type RevenueReport struct {
+	From     string       `json:"from"`
+	To       string       `json:"to"`
+	Timezone string       `json:"timezone"`
+	Period   string       `json:"period"`
+	Bookings int          `json:"bookings"`
+	Revenue  float64      `json:"revenue"`
+	Rows     []RevenueRow `json:"rows"`
+}
+
+// RevenueRow is the revenue of a period, starting on Period, and of the
+// master, service and status when grouped by them.
+type RevenueRow struct {
+	Period      string     `json:"period"`
+	MasterID    *uuid.UUID `json:"master_id,omitempty"`
+	MasterName  string     `json:"master_name,omitempty"`
+	ServiceID   *uuid.UUID `json:"service_id,omitempty"`
+	ServiceName string     `json:"service_name,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Bookings    int        `json:"bookings"`
+	Revenue     float64    `json:"revenue"`
 }
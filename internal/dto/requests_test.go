@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateScheduleRequest_EndDate(t *testing.T) {
+	for body, want := range map[string]struct{ set, null bool }{
+		`{}`:                                  {set: false},
+		`{"end_date":null}`:                   {set: true, null: true},
+		`{"end_date":"2025-06-01T00:00:00Z"}`: {set: true},
+	} {
+		var req UpdateScheduleRequest
+		assert.NoError(t, json.Unmarshal([]byte(body), &req), body)
+		assert.Equal(t, want.set, req.EndDate.Set, body)
+		assert.Equal(t, want.set && want.null, req.EndDate.Set && req.EndDate.Value == nil, body)
+	}
+}
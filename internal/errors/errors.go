@@ -9,9 +9,44 @@ import (
 var (
 	ErrNotFound = errors.New("resource not found")
 
+	ErrOrganizationRequired = errors.New("organization is not set in context")
+
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrTelegramDisabled   = errors.New("telegram login is not configured")
+	ErrTokenRevoked       = errors.New("token revoked")
+	ErrForbidden          = errors.New("forbidden")
+
 	ErrBookingStatusInvalid   = errors.New("invalid booking status")
 	ErrScheduleTypeInvalid    = errors.New("invalid schedule type")
 	ErrEndTimeBeforeStartTime = errors.New("end time is before start time")
+	ErrEndDateBeforeStartDate = errors.New("end date is before start date")
+	ErrWeeklyDayInvalid       = errors.New("days of weekly schedules have a weekday from 1 (Monday) to 7 and no day index")
+	ErrCyclicDayInvalid       = errors.New("days of cyclic schedules have a day index and no weekday")
+	ErrCustomScheduleDays     = errors.New("custom schedules have no days; their hours are set by date overrides")
+	ErrScheduleDayDuplicate   = errors.New("a weekday or day index appears more than once")
+	ErrScheduleCycleInvalid   = errors.New("the day indexes of a cyclic schedule run from 1 to the length of the cycle")
+	ErrWorkingHoursRequired   = errors.New("working days need a start and an end time")
+	ErrDayOffHours            = errors.New("days off have no start and end times")
+	ErrScheduleOverlap        = errors.New("the master has another schedule of the same priority in this period")
+	ErrDateRangeInvalid       = errors.New("the date range must span 1 to 366 days")
+	ErrScheduleTemplateMaster = errors.New("templates have no master and schedules of masters can not become templates; instantiate or clone the schedule instead")
+
+	ErrAPIKeyExpiry = errors.New("the expiry of a key must be in the future")
+
+	ErrWebhookDeliveryNotDead = errors.New("only dead webhook deliveries can be retried")
+
+	ErrTimeOffReviewed = errors.New("the time-off request has already been reviewed")
+
+	ErrSlotUnavailable     = errors.New("the master is busy at this time")
+	ErrServiceMaster       = errors.New("the service is not offered by this master")
+	ErrBookingClosed       = errors.New("only pending and confirmed bookings can be rescheduled")
+	ErrCalendarInvalid     = errors.New("invalid calendar file")
+	ErrCalendarURLInvalid  = errors.New("calendar url must be an http, https or webcal url")
+	ErrCalendarURLInternal = errors.New("calendar url must be https and reach a public address")
+	ErrCalendarFetchFailed = errors.New("failed to fetch calendar")
+	ErrCalendarRecurring   = errors.New("recurring events are not supported")
+	ErrPreconditionFailed  = errors.New("the resource has changed")
 )
 
 type HTTPError struct {
@@ -30,6 +65,71 @@ func NewHTTPError(code int, message string, inner error) *HTTPError {
 	}
 }
 
+// domainStatuses maps well-known domain errors to the status they are reported with.
+var domainStatuses = []struct {
+	err  error
+	code int
+}{
+	{ErrNotFound, http.StatusNotFound},
+	{ErrForbidden, http.StatusForbidden},
+	{ErrUnauthorized, http.StatusUnauthorized},
+	{ErrTelegramDisabled, http.StatusServiceUnavailable},
+	{ErrWebhookDeliveryNotDead, http.StatusConflict},
+	{ErrTimeOffReviewed, http.StatusConflict},
+	{ErrSlotUnavailable, http.StatusConflict},
+	{ErrBookingClosed, http.StatusConflict},
+	{ErrCalendarInvalid, http.StatusBadRequest},
+	{ErrCalendarURLInvalid, http.StatusBadRequest},
+	{ErrCalendarURLInternal, http.StatusBadRequest},
+	{ErrCalendarFetchFailed, http.StatusBadGateway},
+	{ErrCalendarRecurring, http.StatusBadRequest},
+	{ErrPreconditionFailed, http.StatusPreconditionFailed},
+	{ErrEndTimeBeforeStartTime, http.StatusUnprocessableEntity},
+	{ErrEndDateBeforeStartDate, http.StatusUnprocessableEntity},
+	{ErrWeeklyDayInvalid, http.StatusUnprocessableEntity},
+	{ErrCyclicDayInvalid, http.StatusUnprocessableEntity},
+	{ErrCustomScheduleDays, http.StatusUnprocessableEntity},
+	{ErrScheduleDayDuplicate, http.StatusUnprocessableEntity},
+	{ErrScheduleCycleInvalid, http.StatusUnprocessableEntity},
+	{ErrWorkingHoursRequired, http.StatusUnprocessableEntity},
+	{ErrDayOffHours, http.StatusUnprocessableEntity},
+	{ErrScheduleOverlap, http.StatusUnprocessableEntity},
+	{ErrScheduleTypeInvalid, http.StatusBadRequest},
+	{ErrScheduleTemplateMaster, http.StatusBadRequest},
+	{ErrDateRangeInvalid, http.StatusBadRequest},
+}
+
+// ValidationError is a domain rule broken by a field of the request. It is
+// reported with status 422 and names the field.
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// FromDomain is NewHTTPError for errors returned by usecases: validation
+// errors and well-known domain errors keep their own status and message,
+// anything else is reported with the given code and message.
+func FromDomain(code int, message string, err error) *HTTPError {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return NewHTTPError(http.StatusUnprocessableEntity, validationErr.Error(), err)
+	}
+	for _, d := range domainStatuses {
+		if errors.Is(err, d.err) {
+			return NewHTTPError(d.code, d.err.Error(), err)
+		}
+	}
+	return NewHTTPError(code, message, err)
+}
+
 func (e *HTTPError) Error() string {
 	if e.InnerError != nil {
 		return e.InnerError.Error()
@@ -0,0 +1,143 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// Sink receives domain events from the outbox. Delivery is at-least-once and
+// tracked per sink: an event is redelivered to the sinks that have not
+// accepted it yet, so a failing sink does not hold up the others, but sinks
+// must still tolerate duplicates (Event.ID is stable).
+type Sink interface {
+	// Name identifies the sink in the outbox; it must be unique and stable
+	Name() string
+	Handle(ctx context.Context, event *entity.Event) error
+}
+
+const (
+	maxBackoff = time.Hour
+	// lease must exceed the time the sinks need for a batch
+	leaseDuration = 5 * time.Minute
+)
+
+// Dispatcher polls the outbox and delivers due events to the sinks.
+type Dispatcher struct {
+	repo        repository.OutboxRepository
+	sinks       []Sink
+	log         logger.Logger
+	interval    time.Duration
+	batchSize   int
+	backoff     time.Duration
+	maxAttempts int
+}
+
+func NewDispatcher(repo repository.OutboxRepository, sinks []Sink, log logger.Logger, cfg config.OutboxConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		sinks:       sinks,
+		log:         log,
+		interval:    cfg.PollInterval,
+		batchSize:   cfg.BatchSize,
+		backoff:     cfg.RetryBackoff,
+		maxAttempts: cfg.MaxAttempts,
+	}
+}
+
+// Run dispatches events until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		// drain the backlog before waiting for the next tick
+		for {
+			n, err := d.DispatchBatch(ctx)
+			if err != nil {
+				d.log.Error("failed to dispatch outbox events", "error", err)
+			}
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch delivers one batch of due events and returns its size.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	events, err := d.repo.Claim(ctx, d.batchSize, leaseDuration)
+	if err != nil {
+		return 0, fmt.Errorf("claim events: %w", err)
+	}
+
+	for _, e := range events {
+		delivered, err := d.deliver(ctx, e)
+		if err != nil {
+			attempts := e.Attempts + 1
+			dead := attempts >= d.maxAttempts
+			if dead {
+				d.log.Error("outbox event dead-lettered",
+					"event_id", e.ID, "event_type", e.Type, "attempts", attempts, "error", err)
+			} else {
+				d.log.Warn("outbox event delivery failed",
+					"event_id", e.ID, "event_type", e.Type, "attempts", attempts, "error", err)
+			}
+
+			next := time.Now().Add(d.retryDelay(e.Attempts))
+			if err := d.repo.MarkFailed(ctx, e.ID, next, err.Error(), delivered, dead); err != nil {
+				return len(events), fmt.Errorf("mark event failed: %w", err)
+			}
+			continue
+		}
+
+		if err := d.repo.MarkDelivered(ctx, e.ID); err != nil {
+			return len(events), fmt.Errorf("mark event delivered: %w", err)
+		}
+	}
+
+	return len(events), nil
+}
+
+// deliver hands the event to every sink that has not accepted it yet and
+// returns the sinks that have accepted it so far, along with the errors of
+// the others.
+func (d *Dispatcher) deliver(ctx context.Context, e *entity.Event) ([]string, error) {
+	ctx = tenant.WithOrganizationID(ctx, e.OrganizationID)
+	delivered := append([]string{}, e.DeliveredSinks...)
+	var errs []error
+	for _, s := range d.sinks {
+		if slices.Contains(e.DeliveredSinks, s.Name()) {
+			continue
+		}
+		if err := s.Handle(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", s.Name(), err))
+			continue
+		}
+		delivered = append(delivered, s.Name())
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// retryDelay doubles the backoff with every failed attempt, up to maxBackoff.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 0; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
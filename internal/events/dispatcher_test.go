@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type funcSink struct {
+	name   string
+	handle func(ctx context.Context, e *entity.Event) error
+}
+
+func (f funcSink) Name() string { return f.name }
+
+func (f funcSink) Handle(ctx context.Context, e *entity.Event) error { return f.handle(ctx, e) }
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockOutboxRepository(ctrl)
+	ctx := context.Background()
+
+	var webhooks []uuid.UUID
+	webhook := funcSink{"webhook", func(_ context.Context, e *entity.Event) error {
+		webhooks = append(webhooks, e.ID)
+		return nil
+	}}
+	email := funcSink{"email", func(context.Context, *entity.Event) error { return errors.New("unavailable") }}
+	// the failing sink comes first; the order of the sinks is not defined
+	d := NewDispatcher(repo, []Sink{email, webhook}, logger.DefaultLogger, config.OutboxConfig{
+		BatchSize:    10,
+		RetryBackoff: time.Second,
+		MaxAttempts:  5,
+	})
+
+	t.Run("a failing sink does not hold up the others", func(t *testing.T) {
+		webhooks = nil
+		e := &entity.Event{ID: uuid.New(), Type: entity.EventBookingCancelled, Attempts: 2}
+
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.Event{e}, nil)
+		repo.EXPECT().MarkFailed(ctx, e.ID, gomock.Any(), "sink email: unavailable", []string{"webhook"}, false).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, next time.Time, _ string, _ []string, _ bool) error {
+				// third attempt waits 4x the base backoff
+				assert.WithinDuration(t, time.Now().Add(4*time.Second), next, time.Second)
+				return nil
+			})
+
+		n, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, []uuid.UUID{e.ID}, webhooks)
+	})
+
+	t.Run("retries skip the sinks that accepted the event", func(t *testing.T) {
+		webhooks = nil
+		e := &entity.Event{ID: uuid.New(), Type: entity.EventBookingCancelled, Attempts: 4, DeliveredSinks: []string{"webhook"}}
+
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.Event{e}, nil)
+		// the fifth failure is the last one
+		repo.EXPECT().MarkFailed(ctx, e.ID, gomock.Any(), "sink email: unavailable", []string{"webhook"}, true).Return(nil)
+
+		_, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, webhooks)
+	})
+
+	t.Run("events accepted by all sinks are delivered", func(t *testing.T) {
+		e := &entity.Event{ID: uuid.New(), Type: entity.EventBookingCreated, DeliveredSinks: []string{"email"}}
+
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.Event{e}, nil)
+		repo.EXPECT().MarkDelivered(ctx, e.ID).Return(nil)
+
+		_, err := d.DispatchBatch(ctx)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"context"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// LogSink writes events to the log. It is useful in development.
+type LogSink struct {
+	log logger.Logger
+}
+
+func NewLogSink(log logger.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+func (s *LogSink) Handle(_ context.Context, e *entity.Event) error {
+	s.log.Info("domain event",
+		"event_id", e.ID,
+		"event_type", e.Type,
+		"organization_id", e.OrganizationID,
+		"aggregate_id", e.AggregateID,
+	)
+	return nil
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	apiKeyUseCase *usecase.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(s *server.Server, uc *usecase.APIKeyUseCase) {
+	handler := &APIKeyHandler{apiKeyUseCase: uc}
+
+	// integrations cannot manage keys: the group has no scopes
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+
+	group := s.NewGroup("/api/v1/api-keys", middleware.RequireAuth, admin)
+	group.POST("", handler.CreateAPIKey)
+	group.GET("", handler.ListAPIKeys)
+	group.DELETE("/:id", handler.RevokeAPIKey)
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	apiKey, key, err := h.apiKeyUseCase.CreateAPIKey(ctx, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create api key", err)
+	}
+
+	log.Info("api key created", "api_key_id", apiKey.ID)
+	return c.JSON(http.StatusCreated, dto.CreateAPIKeyResponse{APIKey: apiKey, Key: key})
+}
+
+func (h *APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	keys, err := h.apiKeyUseCase.ListAPIKeys(ctx)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list api keys", err)
+	}
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to revoke api key", err)
+	}
+
+	log.Info("api key revoked", "api_key_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	authUseCase *usecase.AuthUseCase
+}
+
+func NewAuthHandler(s *server.Server, uc *usecase.AuthUseCase) {
+	handler := &AuthHandler{authUseCase: uc}
+
+	group := s.NewGroup("/api/v1/auth")
+	group.POST("/login", handler.Login, middleware.RequireTenant)
+	group.POST("/telegram", handler.TelegramLogin, middleware.RequireTenant)
+	group.POST("/refresh", handler.Refresh)
+	group.POST("/logout", handler.Logout)
+}
+
+// POST /api/v1/auth/login
+func (h *AuthHandler) Login(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	pair, err := h.authUseCase.LoginAdmin(ctx, req.Email, req.Password)
+	if err != nil {
+		if stdErrors.Is(err, errors.ErrInvalidCredentials) {
+			return errors.NewHTTPError(http.StatusUnauthorized, "invalid email or password", err)
+		}
+		return errors.FromDomain(http.StatusInternalServerError, "failed to log in", err)
+	}
+
+	return c.JSON(http.StatusOK, newTokenResponse(pair))
+}
+
+// POST /api/v1/auth/telegram
+func (h *AuthHandler) TelegramLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.TelegramLoginRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	var (
+		pair *auth.TokenPair
+		err  error
+	)
+	if req.InitData != "" {
+		pair, err = h.authUseCase.LoginTelegramWebApp(ctx, req.InitData)
+	} else {
+		pair, err = h.authUseCase.LoginTelegramWidget(ctx, req.WidgetFields())
+	}
+	if err != nil {
+		if stdErrors.Is(err, errors.ErrInvalidCredentials) {
+			return errors.NewHTTPError(http.StatusUnauthorized, "invalid telegram auth data", err)
+		}
+		return errors.FromDomain(http.StatusInternalServerError, "failed to log in", err)
+	}
+
+	return c.JSON(http.StatusOK, newTokenResponse(pair))
+}
+
+// POST /api/v1/auth/refresh
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	pair, err := h.authUseCase.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		if stdErrors.Is(err, errors.ErrUnauthorized) || stdErrors.Is(err, errors.ErrTokenRevoked) {
+			return errors.NewHTTPError(http.StatusUnauthorized, "invalid refresh token", err)
+		}
+		return errors.NewHTTPError(http.StatusInternalServerError, "failed to refresh token", err)
+	}
+
+	return c.JSON(http.StatusOK, newTokenResponse(pair))
+}
+
+// POST /api/v1/auth/logout
+func (h *AuthHandler) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	if err := h.authUseCase.Logout(ctx, req.RefreshToken); err != nil {
+		if stdErrors.Is(err, errors.ErrUnauthorized) {
+			return errors.NewHTTPError(http.StatusUnauthorized, "invalid refresh token", err)
+		}
+		return errors.NewHTTPError(http.StatusInternalServerError, "failed to log out", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func newTokenResponse(pair *auth.TokenPair) dto.TokenResponse {
+	return dto.TokenResponse{
+		AccessToken:      pair.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/realtime"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// wsAuthTimeout is the time a connection has to authenticate
+	wsAuthTimeout = 10 * time.Second
+	wsMaxMessage  = 64 << 10
+)
+
+type AvailabilityHandler struct {
+	availabilityUseCase *usecase.AvailabilityUseCase
+	broker              *realtime.Broker
+	tokens              *auth.TokenManager
+}
+
+func NewAvailabilityHandler(s *server.Server, uc *usecase.AvailabilityUseCase, broker *realtime.Broker, tokens *auth.TokenManager) {
+	handler := &AvailabilityHandler{
+		availabilityUseCase: uc,
+		broker:              broker,
+		tokens:              tokens,
+	}
+
+	// Routes
+	group := s.NewGroup("/api/v1/availability", middleware.Scopes("schedules"))
+	group.GET("/ws", handler.Connect)
+}
+
+// Connect upgrades to a WebSocket serving live availability, see
+// realtime.AvailabilitySession for the messages. Browsers cannot send the
+// Authorization header with the upgrade, so unauthenticated connections
+// must first send an "auth" message with the access token.
+func (h *AvailabilityHandler) Connect(c echo.Context) error {
+	ws := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		ws.MaxPayloadBytes = wsMaxMessage
+
+		ctx := c.Request().Context()
+		log := logger.FromContext(ctx)
+		conn := wsConn{ws: ws}
+
+		if _, ok := auth.PrincipalFromContext(ctx); !ok {
+			principal, err := h.authenticate(ws, conn)
+			if err != nil {
+				_ = conn.Send(realtime.ServerMessage{Type: realtime.MessageError, Error: "authentication required"})
+				return
+			}
+			ctx = auth.WithPrincipal(ctx, principal)
+			ctx = tenant.WithOrganizationID(ctx, principal.OrganizationID)
+		}
+
+		orgID, err := tenant.OrganizationID(ctx)
+		if err != nil {
+			return
+		}
+
+		session := realtime.NewAvailabilitySession(h.broker, h.availabilityUseCase, conn, orgID)
+		if err := session.Run(ctx); err != nil {
+			log.Debug("availability connection closed", "error", err)
+		}
+	}}
+
+	ws.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+func (h *AvailabilityHandler) authenticate(ws *websocket.Conn, conn wsConn) (*auth.Principal, error) {
+	if err := ws.SetReadDeadline(time.Now().Add(wsAuthTimeout)); err != nil {
+		return nil, err
+	}
+	var msg realtime.ClientMessage
+	if err := conn.Receive(&msg); err != nil {
+		return nil, err
+	}
+	if msg.Type != realtime.MessageAuth {
+		return nil, errors.ErrUnauthorized
+	}
+	principal, err := h.tokens.ParseAccessToken(msg.Token)
+	if err != nil {
+		return nil, err
+	}
+	return principal, ws.SetReadDeadline(time.Time{})
+}
+
+// wsConn exchanges JSON messages over a WebSocket.
+type wsConn struct {
+	ws *websocket.Conn
+}
+
+func (c wsConn) Receive(v any) error {
+	return websocket.JSON.Receive(c.ws, v)
+}
+
+func (c wsConn) Send(v any) error {
+	return websocket.JSON.Send(c.ws, v)
+}
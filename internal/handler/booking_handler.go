@@ -8,6 +8,7 @@ import (
 	"github.com/curserio/chrono-api/internal/dto"
 	"github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
 	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/timeutil"
 	"github.com/google/uuid"
@@ -21,13 +22,25 @@ type BookingHandler struct {
 func NewBookingHandler(s *server.Server, uc *usecase.BookingUseCase) {
 	handler := &BookingHandler{bookingUseCase: uc}
 
-	group := s.NewGroup("/api/v1/bookings")
+	// access to a single booking is checked by the usecase against its master and client
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+	adminOrMaster := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "master_id"),
+	)
+	adminOrClient := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleClient, "client_id"),
+	)
+
+	group := s.NewGroup("/api/v1/bookings", middleware.RequireAuth, middleware.Scopes("bookings"))
 	group.POST("", handler.CreateBooking)
 	group.GET("/:id", handler.GetBooking)
-	group.GET("/master/:master_id", handler.GetByMaster)
-	group.GET("/client/:client_id", handler.GetByClient)
+	group.GET("/master/:master_id", handler.GetByMaster, adminOrMaster)
+	group.GET("/client/:client_id", handler.GetByClient, adminOrClient)
 	group.PUT("/:id/status", handler.UpdateStatus)
-	group.DELETE("/:id", handler.DeleteBooking)
+	group.PUT("/:id/reschedule", handler.Reschedule)
+	group.DELETE("/:id", handler.DeleteBooking, admin)
 }
 
 func (h *BookingHandler) CreateBooking(c echo.Context) error {
@@ -55,7 +68,7 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 		Status:    entity.BookingStatusPending,
 	})
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to create booking", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create booking", err)
 	}
 	return c.JSON(http.StatusCreated, booking)
 }
@@ -68,7 +81,7 @@ func (h *BookingHandler) GetBooking(c echo.Context) error {
 	}
 	b, err := h.bookingUseCase.GetBookingByID(ctx, id)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to get booking", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get booking", err)
 	}
 	return c.JSON(http.StatusOK, b)
 }
@@ -109,7 +122,7 @@ func (h *BookingHandler) GetByMaster(c echo.Context) error {
 
 	bookings, err := h.bookingUseCase.GetBookingsByMaster(ctx, masterID, from, to)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list bookings", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list bookings", err)
 	}
 	return c.JSON(http.StatusOK, bookings)
 }
@@ -122,7 +135,7 @@ func (h *BookingHandler) GetByClient(c echo.Context) error {
 	}
 	bookings, err := h.bookingUseCase.GetBookingsByClient(ctx, clientID)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list bookings", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list bookings", err)
 	}
 	return c.JSON(http.StatusOK, bookings)
 }
@@ -144,11 +157,38 @@ func (h *BookingHandler) UpdateStatus(c echo.Context) error {
 	}
 
 	if err := h.bookingUseCase.UpdateBookingStatus(ctx, id, status); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update status", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update status", err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
 
+func (h *BookingHandler) Reschedule(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+	var req dto.RescheduleBookingRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	start, end, err := parseTimeDuration(req.StartTime, req.EndTime)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format", err)
+	}
+
+	date := timeutil.NormalizeDate(req.Date)
+	start = time.Date(date.Year(), date.Month(), date.Day(), start.Hour(), start.Minute(), 0, 0, date.Location())
+	end = time.Date(date.Year(), date.Month(), date.Day(), end.Hour(), end.Minute(), 0, 0, date.Location())
+
+	booking, err := h.bookingUseCase.RescheduleBooking(ctx, id, start, end)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to reschedule booking", err)
+	}
+	return c.JSON(http.StatusOK, booking)
+}
+
 func (h *BookingHandler) DeleteBooking(c echo.Context) error {
 	ctx := c.Request().Context()
 	id, err := uuid.Parse(c.Param("id"))
@@ -156,7 +196,7 @@ func (h *BookingHandler) DeleteBooking(c echo.Context) error {
 		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
 	}
 	if err := h.bookingUseCase.DeleteBooking(ctx, id); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete booking", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete booking", err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxCalendarUpload limits uploaded ICS files
+const maxCalendarUpload = 5 << 20
+
+type BusyBlockHandler struct {
+	busyUseCase *usecase.BusyBlockUseCase
+}
+
+func NewBusyBlockHandler(s *server.Server, uc *usecase.BusyBlockUseCase) {
+	handler := &BusyBlockHandler{busyUseCase: uc}
+
+	adminOrMaster := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "master_id"),
+	)
+	staff := middleware.Authorize(middleware.Role(entity.RoleAdmin, entity.RoleMaster))
+
+	// busy blocks are part of the schedule for integrations
+	group := s.NewGroup("/api/v1/busy-blocks", middleware.RequireAuth, middleware.Scopes("schedules"))
+	group.POST("/master/:master_id/import", handler.Import, adminOrMaster)
+	group.GET("/master/:master_id", handler.ListBlocks, adminOrMaster)
+	group.DELETE("/:id", handler.DeleteBlock, staff)
+
+	group.POST("/master/:master_id/sources", handler.CreateSource, adminOrMaster)
+	group.GET("/master/:master_id/sources", handler.ListSources, adminOrMaster)
+	group.DELETE("/sources/:id", handler.DeleteSource, staff)
+	group.POST("/sources/:id/sync", handler.SyncSource, staff)
+}
+
+// POST /api/v1/busy-blocks/master/:master_id/import
+//
+// The ICS file is the request body or the "file" field of a multipart form.
+func (h *BusyBlockHandler) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxCalendarUpload)
+
+	var body io.Reader = c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "missing calendar file", err)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid calendar file", err)
+		}
+		defer f.Close()
+		body = f
+	}
+
+	result, err := h.busyUseCase.ImportICS(ctx, masterID, body)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to import calendar", err)
+	}
+
+	log.Info("calendar imported", "master_id", masterID,
+		"imported", result.Imported, "removed", result.Removed, "skipped", result.Skipped)
+	return c.JSON(http.StatusOK, result)
+}
+
+// GET /api/v1/busy-blocks/master/:master_id?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *BusyBlockHandler) ListBlocks(c echo.Context) error {
+	ctx := c.Request().Context()
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	from := time.Now().Truncate(24 * time.Hour)
+	if s := c.QueryParam("from"); s != "" {
+		from, err = time.Parse(time.DateOnly, s)
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid 'from' date format (use YYYY-MM-DD)", err)
+		}
+	}
+
+	to := from.AddDate(0, 0, 30)
+	if s := c.QueryParam("to"); s != "" {
+		to, err = time.Parse(time.DateOnly, s)
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid 'to' date format (use YYYY-MM-DD)", err)
+		}
+	}
+
+	if to.Before(from) {
+		return errors.NewHTTPError(http.StatusBadRequest, "'to' date must be after 'from'", nil)
+	}
+
+	blocks, err := h.busyUseCase.ListBlocks(ctx, masterID, from, to)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list busy blocks", err)
+	}
+	return c.JSON(http.StatusOK, blocks)
+}
+
+func (h *BusyBlockHandler) DeleteBlock(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.busyUseCase.DeleteBlock(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete busy block", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *BusyBlockHandler) CreateSource(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	var req dto.CreateCalendarSourceRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	source, err := h.busyUseCase.CreateSource(ctx, &entity.CalendarSource{
+		MasterID: masterID,
+		Name:     req.Name,
+		URL:      req.URL,
+	})
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create calendar source", err)
+	}
+
+	log.Info("calendar source created", "source_id", source.ID, "master_id", masterID)
+	return c.JSON(http.StatusCreated, source)
+}
+
+func (h *BusyBlockHandler) ListSources(c echo.Context) error {
+	ctx := c.Request().Context()
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	sources, err := h.busyUseCase.ListSources(ctx, masterID)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list calendar sources", err)
+	}
+	return c.JSON(http.StatusOK, sources)
+}
+
+func (h *BusyBlockHandler) DeleteSource(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.busyUseCase.DeleteSource(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete calendar source", err)
+	}
+
+	log.Info("calendar source deleted", "source_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *BusyBlockHandler) SyncSource(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	result, err := h.busyUseCase.SyncSource(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to sync calendar source", err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,458 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/caldav"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	caldavPath     = "/caldav/"
+	caldavCalendar = "calendar/"
+	caldavMethods  = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+)
+
+// CalDAVHandler serves the calendars of masters to calendar apps:
+//
+//	/caldav/                          principal discovery
+//	/caldav/:master_id/               principal and calendar home
+//	/caldav/:master_id/calendar/      the calendar collection
+//	/caldav/:master_id/calendar/:name an event
+type CalDAVHandler struct {
+	caldavUseCase *usecase.CalDAVUseCase
+}
+
+func NewCalDAVHandler(s *server.Server, uc *usecase.CalDAVUseCase) {
+	handler := &CalDAVHandler{caldavUseCase: uc}
+
+	adminOrMaster := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "master_id"),
+	)
+
+	accounts := s.NewGroup("/api/v1/caldav", middleware.RequireAuth)
+	accounts.POST("/master/:master_id", handler.CreateAccount, adminOrMaster)
+	accounts.DELETE("/master/:master_id", handler.RevokeAccount, adminOrMaster)
+
+	wellKnown := s.NewGroup("/.well-known")
+	wellKnown.Any("/caldav", func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, caldavPath)
+	})
+
+	// clients probe the capabilities before authenticating
+	probe := s.NewGroup(strings.TrimSuffix(caldavPath, "/"))
+	for _, p := range []string{"", "/", "/:master_id", "/:master_id/", "/:master_id/calendar", "/:master_id/calendar/", "/:master_id/calendar/:name"} {
+		probe.OPTIONS(p, handler.Options)
+	}
+
+	dav := s.NewGroup(strings.TrimSuffix(caldavPath, "/"), handler.authenticate)
+	for _, p := range []string{"", "/"} {
+		dav.Add(echo.PROPFIND, p, handler.PropFindRoot)
+	}
+
+	master := dav.Group("/:master_id", adminOrMaster)
+	for _, p := range []string{"", "/"} {
+		master.Add(echo.PROPFIND, p, handler.PropFindPrincipal)
+	}
+	for _, p := range []string{"/calendar", "/calendar/"} {
+		master.Add(echo.PROPFIND, p, handler.PropFindCalendar)
+		master.Add(echo.REPORT, p, handler.Report)
+	}
+	master.Add(echo.PROPFIND, "/calendar/:name", handler.PropFindObject)
+	master.GET("/calendar/:name", handler.GetObject)
+	master.HEAD("/calendar/:name", handler.GetObject)
+	master.PUT("/calendar/:name", handler.PutObject)
+	master.DELETE("/calendar/:name", handler.DeleteObject)
+}
+
+// POST /api/v1/caldav/master/:master_id
+func (h *CalDAVHandler) CreateAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	account, password, err := h.caldavUseCase.CreateAccount(ctx, masterID)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create caldav account", err)
+	}
+
+	log.Info("caldav account created", "master_id", masterID)
+	return c.JSON(http.StatusCreated, dto.CalDAVAccountResponse{
+		CalDAVAccount: account,
+		URL:           c.Scheme() + "://" + c.Request().Host + caldavPath,
+		Username:      masterID.String(),
+		Password:      password,
+	})
+}
+
+// DELETE /api/v1/caldav/master/:master_id
+func (h *CalDAVHandler) RevokeAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+
+	if err := h.caldavUseCase.RevokeAccount(ctx, masterID); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to revoke caldav account", err)
+	}
+
+	log.Info("caldav account revoked", "master_id", masterID)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *CalDAVHandler) Options(c echo.Context) error {
+	c.Response().Header().Set("DAV", "1, calendar-access")
+	c.Response().Header().Set(echo.HeaderAllow, caldavMethods)
+	return c.NoContent(http.StatusOK)
+}
+
+// authenticate signs in calendar apps with Basic credentials: the master ID
+// and the app password. Requests already authenticated, e.g. with a bearer
+// token, pass through.
+func (h *CalDAVHandler) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		username, password, ok := c.Request().BasicAuth()
+		if !ok {
+			if _, ok := auth.PrincipalFromContext(ctx); ok {
+				return next(c)
+			}
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Chrono"`)
+			return errors.NewHTTPError(http.StatusUnauthorized, "authentication required", errors.ErrUnauthorized)
+		}
+
+		principal, err := h.caldavUseCase.Authenticate(ctx, username, password)
+		if err != nil {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Chrono"`)
+			return errors.FromDomain(http.StatusInternalServerError, "failed to authenticate", err)
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		ctx = tenant.WithOrganizationID(ctx, principal.OrganizationID)
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+// PROPFIND /caldav/
+func (h *CalDAVHandler) PropFindRoot(c echo.Context) error {
+	props, err := caldav.ParsePropFind(c.Request().Body)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid propfind request", err)
+	}
+
+	p, _ := auth.PrincipalFromContext(c.Request().Context())
+	root := caldav.NewResponse(caldavPath)
+	root.Set(caldav.ResourceType, "<d:collection/>")
+	root.Set(caldav.CurrentUserPrincipal, caldav.HrefValue(principalHref(p.SubjectID)))
+
+	return multistatus(c, []*caldav.Response{root}, props)
+}
+
+// PROPFIND /caldav/:master_id/
+func (h *CalDAVHandler) PropFindPrincipal(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+	props, err := caldav.ParsePropFind(c.Request().Body)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid propfind request", err)
+	}
+
+	name, err := h.caldavUseCase.CalendarName(ctx, masterID)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get calendar", err)
+	}
+
+	href := principalHref(masterID)
+	principal := caldav.NewResponse(href)
+	principal.Set(caldav.ResourceType, "<d:collection/><d:principal/>")
+	principal.SetText(caldav.DisplayName, name)
+	principal.Set(caldav.CurrentUserPrincipal, caldav.HrefValue(href))
+	principal.Set(caldav.PrincipalURL, caldav.HrefValue(href))
+	principal.Set(caldav.CalendarHomeSet, caldav.HrefValue(href))
+	responses := []*caldav.Response{principal}
+
+	if depth(c) > 0 {
+		calendar, err := h.calendarResponse(c, masterID, name)
+		if err != nil {
+			return err
+		}
+		responses = append(responses, calendar)
+	}
+	return multistatus(c, responses, props)
+}
+
+// PROPFIND /caldav/:master_id/calendar/
+func (h *CalDAVHandler) PropFindCalendar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+	props, err := caldav.ParsePropFind(c.Request().Body)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid propfind request", err)
+	}
+
+	name, err := h.caldavUseCase.CalendarName(ctx, masterID)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get calendar", err)
+	}
+	calendar, err := h.calendarResponse(c, masterID, name)
+	if err != nil {
+		return err
+	}
+	responses := []*caldav.Response{calendar}
+
+	if depth(c) > 0 {
+		objects, err := h.caldavUseCase.ListObjects(ctx, masterID, time.Time{}, time.Time{})
+		if err != nil {
+			return errors.FromDomain(http.StatusInternalServerError, "failed to list events", err)
+		}
+		for _, obj := range objects {
+			responses = append(responses, objectResponse(masterID, obj, false))
+		}
+	}
+	return multistatus(c, responses, props)
+}
+
+// PROPFIND /caldav/:master_id/calendar/:name
+func (h *CalDAVHandler) PropFindObject(c echo.Context) error {
+	masterID, name, err := objectParams(c)
+	if err != nil {
+		return err
+	}
+	props, err := caldav.ParsePropFind(c.Request().Body)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid propfind request", err)
+	}
+
+	obj, err := h.caldavUseCase.GetObject(c.Request().Context(), masterID, name)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get event", err)
+	}
+	return multistatus(c, []*caldav.Response{objectResponse(masterID, obj, false)}, props)
+}
+
+// REPORT /caldav/:master_id/calendar/
+func (h *CalDAVHandler) Report(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+	report, err := caldav.ParseReport(c.Request().Body)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid report request", err)
+	}
+	withData := report.Props == nil || slices.Contains(report.Props, caldav.CalendarData)
+
+	var responses []*caldav.Response
+	if report.Multiget {
+		prefix := calendarHref(masterID)
+		for _, href := range report.Hrefs {
+			name, err := url.PathUnescape(strings.TrimPrefix(href, prefix))
+			if err != nil || !strings.HasPrefix(href, prefix) {
+				responses = append(responses, &caldav.Response{Href: href, Status: http.StatusNotFound})
+				continue
+			}
+
+			obj, err := h.caldavUseCase.GetObject(ctx, masterID, name)
+			if err != nil {
+				httpErr := errors.FromDomain(http.StatusInternalServerError, "failed to get event", err)
+				if httpErr.Code != http.StatusNotFound {
+					return httpErr
+				}
+				responses = append(responses, &caldav.Response{Href: href, Status: http.StatusNotFound})
+				continue
+			}
+			responses = append(responses, objectResponse(masterID, obj, withData))
+		}
+	} else {
+		objects, err := h.caldavUseCase.ListObjects(ctx, masterID, report.Start, report.End)
+		if err != nil {
+			return errors.FromDomain(http.StatusInternalServerError, "failed to list events", err)
+		}
+		for _, obj := range objects {
+			responses = append(responses, objectResponse(masterID, obj, withData))
+		}
+	}
+	return multistatus(c, responses, report.Props)
+}
+
+// GET /caldav/:master_id/calendar/:name
+func (h *CalDAVHandler) GetObject(c echo.Context) error {
+	masterID, name, err := objectParams(c)
+	if err != nil {
+		return err
+	}
+
+	obj, err := h.caldavUseCase.GetObject(c.Request().Context(), masterID, name)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get event", err)
+	}
+
+	c.Response().Header().Set("ETag", obj.ETag)
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", objectData(obj))
+}
+
+// PUT /caldav/:master_id/calendar/:name
+func (h *CalDAVHandler) PutObject(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, name, err := objectParams(c)
+	if err != nil {
+		return err
+	}
+
+	obj, created, err := h.caldavUseCase.PutObject(ctx, masterID, name, c.Request().Body, precondition(c))
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to save event", err)
+	}
+
+	log.Info("caldav event saved", "master_id", masterID, "name", name, "created", created)
+	if obj != nil {
+		c.Response().Header().Set("ETag", obj.ETag)
+	}
+	if created {
+		return c.NoContent(http.StatusCreated)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DELETE /caldav/:master_id/calendar/:name
+func (h *CalDAVHandler) DeleteObject(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	masterID, name, err := objectParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.caldavUseCase.DeleteObject(ctx, masterID, name, precondition(c)); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete event", err)
+	}
+
+	log.Info("caldav event deleted", "master_id", masterID, "name", name)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// calendarResponse describes the calendar collection. Its ctag changes with
+// any event, so clients can skip unchanged calendars.
+func (h *CalDAVHandler) calendarResponse(c echo.Context, masterID uuid.UUID, name string) (*caldav.Response, error) {
+	objects, err := h.caldavUseCase.ListObjects(c.Request().Context(), masterID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, errors.FromDomain(http.StatusInternalServerError, "failed to list events", err)
+	}
+
+	tag := sha256.New()
+	for _, obj := range objects {
+		tag.Write([]byte(obj.Name + obj.ETag))
+	}
+
+	r := caldav.NewResponse(calendarHref(masterID))
+	r.Set(caldav.ResourceType, "<d:collection/><c:calendar/>")
+	r.SetText(caldav.DisplayName, name)
+	r.SetText(caldav.GetCTag, hex.EncodeToString(tag.Sum(nil))[:32])
+	r.Set(caldav.SupportedCalendarComponentSet, `<c:comp name="VEVENT"/>`)
+	r.Set(caldav.CurrentUserPrincipal, caldav.HrefValue(principalHref(masterID)))
+	return r, nil
+}
+
+func objectResponse(masterID uuid.UUID, obj *usecase.CalendarObject, withData bool) *caldav.Response {
+	r := caldav.NewResponse(calendarHref(masterID) + url.PathEscape(obj.Name))
+	r.Set(caldav.ResourceType, "")
+	r.SetText(caldav.GetETag, obj.ETag)
+	r.SetText(caldav.GetContentType, "text/calendar; charset=utf-8; component=VEVENT")
+	if withData {
+		r.SetText(caldav.CalendarData, string(objectData(obj)))
+	}
+	return r
+}
+
+func objectData(obj *usecase.CalendarObject) []byte {
+	cal := &ical.Calendar{
+		ProdID: "-//Chrono//CalDAV//EN",
+		Events: []ical.Event{obj.Event},
+	}
+	return cal.Bytes()
+}
+
+func objectParams(c echo.Context) (uuid.UUID, string, error) {
+	masterID, err := uuid.Parse(c.Param("master_id"))
+	if err != nil {
+		return uuid.Nil, "", errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+	name, err := url.PathUnescape(c.Param("name"))
+	if err != nil {
+		return uuid.Nil, "", errors.NewHTTPError(http.StatusBadRequest, "invalid event name", err)
+	}
+	return masterID, name, nil
+}
+
+func precondition(c echo.Context) usecase.Precondition {
+	return usecase.Precondition{
+		IfMatch:     c.Request().Header.Get("If-Match"),
+		IfNoneMatch: c.Request().Header.Get("If-None-Match"),
+	}
+}
+
+// depth returns the Depth header; infinity is served as 1.
+func depth(c echo.Context) int {
+	if c.Request().Header.Get("Depth") == "0" {
+		return 0
+	}
+	return 1
+}
+
+func multistatus(c echo.Context, responses []*caldav.Response, props []xml.Name) error {
+	var b bytes.Buffer
+	if err := caldav.WriteMultistatus(&b, responses, props); err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, "failed to render multistatus", err)
+	}
+	return c.Blob(http.StatusMultiStatus, echo.MIMEApplicationXMLCharsetUTF8, b.Bytes())
+}
+
+func principalHref(masterID uuid.UUID) string {
+	return caldavPath + masterID.String() + "/"
+}
+
+func calendarHref(masterID uuid.UUID) string {
+	return principalHref(masterID) + caldavCalendar
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const feedPath = "/calendar/"
+
+type CalendarFeedHandler struct {
+	feedUseCase *usecase.CalendarFeedUseCase
+}
+
+func NewCalendarFeedHandler(s *server.Server, uc *usecase.CalendarFeedUseCase) {
+	handler := &CalendarFeedHandler{feedUseCase: uc}
+
+	adminOrMaster := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "master_id"),
+	)
+	adminOrClient := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleClient, "client_id"),
+	)
+
+	group := s.NewGroup("/api/v1/calendar-feeds", middleware.RequireAuth)
+	group.POST("/master/:master_id", handler.CreateMasterFeed, adminOrMaster)
+	group.DELETE("/master/:master_id", handler.RevokeMasterFeed, adminOrMaster)
+	group.POST("/client/:client_id", handler.CreateClientFeed, adminOrClient)
+	group.DELETE("/client/:client_id", handler.RevokeClientFeed, adminOrClient)
+
+	// calendar apps cannot authenticate: the secret token is the credential
+	public := s.NewGroup(strings.TrimSuffix(feedPath, "/"))
+	public.GET("/:token", handler.GetFeed)
+}
+
+func (h *CalendarFeedHandler) CreateMasterFeed(c echo.Context) error {
+	return h.createFeed(c, entity.RoleMaster, "master_id")
+}
+
+func (h *CalendarFeedHandler) RevokeMasterFeed(c echo.Context) error {
+	return h.revokeFeed(c, entity.RoleMaster, "master_id")
+}
+
+func (h *CalendarFeedHandler) CreateClientFeed(c echo.Context) error {
+	return h.createFeed(c, entity.RoleClient, "client_id")
+}
+
+func (h *CalendarFeedHandler) RevokeClientFeed(c echo.Context) error {
+	return h.revokeFeed(c, entity.RoleClient, "client_id")
+}
+
+func (h *CalendarFeedHandler) createFeed(c echo.Context, role entity.Role, param string) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	ownerID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid "+param, err)
+	}
+
+	feed, token, err := h.feedUseCase.CreateFeed(ctx, role, ownerID)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create calendar feed", err)
+	}
+
+	log.Info("calendar feed created", "owner_role", role, "owner_id", ownerID)
+	return c.JSON(http.StatusCreated, dto.CalendarFeedResponse{
+		CalendarFeed: feed,
+		URL:          c.Scheme() + "://" + c.Request().Host + feedPath + token + ".ics",
+	})
+}
+
+func (h *CalendarFeedHandler) revokeFeed(c echo.Context, role entity.Role, param string) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	ownerID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid "+param, err)
+	}
+
+	if err := h.feedUseCase.RevokeFeed(ctx, role, ownerID); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to revoke calendar feed", err)
+	}
+
+	log.Info("calendar feed revoked", "owner_role", role, "owner_id", ownerID)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /calendar/:token.ics
+func (h *CalendarFeedHandler) GetFeed(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	cal, err := h.feedUseCase.Feed(ctx, token)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to render calendar feed", err)
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=300")
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", cal.Bytes())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/curserio/chrono-api/internal/dto"
 	"github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
 	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/logger"
 	"github.com/google/uuid"
@@ -22,12 +23,22 @@ type ClientHandler struct {
 func NewClientHandler(s *server.Server, uc *usecase.ClientUseCase) {
 	handler := &ClientHandler{clientUseCase: uc}
 
-	group := s.NewGroup("/api/v1/clients")
-	group.POST("", handler.CreateClient)
-	group.GET("/:id", handler.GetClient)
-	group.GET("", handler.ListClients)
-	group.PUT("/:id", handler.UpdateClient)
-	group.DELETE("/:id", handler.DeleteClient)
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+	adminOrSelf := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleClient, "id"),
+	)
+	staffOrSelf := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin, entity.RoleMaster),
+		middleware.Self(entity.RoleClient, "id"),
+	)
+
+	group := s.NewGroup("/api/v1/clients", middleware.RequireAuth, middleware.Scopes("clients"))
+	group.POST("", handler.CreateClient, admin)
+	group.GET("/:id", handler.GetClient, staffOrSelf)
+	group.GET("", handler.ListClients, admin)
+	group.PUT("/:id", handler.UpdateClient, adminOrSelf)
+	group.DELETE("/:id", handler.DeleteClient, admin)
 }
 
 func (h *ClientHandler) CreateClient(c echo.Context) error {
@@ -50,7 +61,7 @@ func (h *ClientHandler) CreateClient(c echo.Context) error {
 		Language:         req.Language,
 	})
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to create client", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create client", err)
 	}
 
 	log.Info("client created", "client_id", client.ID)
@@ -66,7 +77,7 @@ func (h *ClientHandler) GetClient(c echo.Context) error {
 
 	client, err := h.clientUseCase.GetClientByID(ctx, id)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to get client", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get client", err)
 	}
 
 	return c.JSON(http.StatusOK, client)
@@ -90,7 +101,7 @@ func (h *ClientHandler) ListClients(c echo.Context) error {
 
 	clients, err := h.clientUseCase.ListClients(ctx, offset, limit)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list clients", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list clients", err)
 	}
 	return c.JSON(http.StatusOK, clients)
 }
@@ -120,7 +131,7 @@ func (h *ClientHandler) UpdateClient(c echo.Context) error {
 	}
 	client.ID = id
 	if err := h.clientUseCase.UpdateClient(ctx, client); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update client", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update client", err)
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -133,7 +144,7 @@ func (h *ClientHandler) DeleteClient(c echo.Context) error {
 	}
 
 	if err := h.clientUseCase.DeleteClient(ctx, id); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete client", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete client", err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/realtime"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// keepaliveInterval keeps idle streams from being closed by proxies.
+const keepaliveInterval = 25 * time.Second
+
+type MasterEventsHandler struct {
+	masterUseCase *usecase.MasterUseCase
+	broker        *realtime.Broker
+}
+
+func NewMasterEventsHandler(s *server.Server, uc *usecase.MasterUseCase, broker *realtime.Broker) {
+	handler := &MasterEventsHandler{
+		masterUseCase: uc,
+		broker:        broker,
+	}
+
+	// Policies
+	adminOrSelf := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "id"),
+	)
+
+	// Routes
+	group := s.NewGroup("/api/v1/masters", middleware.RequireAuth, middleware.Scopes("masters"))
+	group.GET("/:id/events", handler.StreamEvents, adminOrSelf)
+}
+
+// StreamEvents streams the booking and schedule changes of the master as
+// Server-Sent Events. The stream ends if the client falls behind; clients
+// reconnect and reload their state.
+func (h *MasterEventsHandler) StreamEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid master ID", err)
+	}
+
+	master, err := h.masterUseCase.GetMasterByID(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get master", err)
+	}
+
+	messages, unsubscribe := h.broker.Subscribe(master.OrganizationID, master.ID)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(msg)
+			if err != nil {
+				log.Error("failed to marshal master event", "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, data); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
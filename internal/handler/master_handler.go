@@ -9,6 +9,7 @@ import (
 	"github.com/curserio/chrono-api/internal/dto"
 	"github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
 	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/logger"
 	"github.com/google/uuid"
@@ -24,13 +25,20 @@ func NewMasterHandler(s *server.Server, uc *usecase.MasterUseCase) {
 		masterUseCase: uc,
 	}
 
+	// Policies
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+	adminOrSelf := middleware.Authorize(
+		middleware.Role(entity.RoleAdmin),
+		middleware.Self(entity.RoleMaster, "id"),
+	)
+
 	// Routes
-	group := s.NewGroup("/api/v1/masters")
-	group.POST("", handler.CreateMaster)
+	group := s.NewGroup("/api/v1/masters", middleware.RequireAuth, middleware.Scopes("masters"))
+	group.POST("", handler.CreateMaster, admin)
 	group.GET("", handler.ListMasters)
 	group.GET("/:id", handler.GetMaster)
-	group.PUT("/:id", handler.UpdateMaster)
-	group.DELETE("/:id", handler.DeleteMaster)
+	group.PUT("/:id", handler.UpdateMaster, adminOrSelf)
+	group.DELETE("/:id", handler.DeleteMaster, admin)
 }
 
 func (h *MasterHandler) CreateMaster(c echo.Context) error {
@@ -60,7 +68,7 @@ func (h *MasterHandler) CreateMaster(c echo.Context) error {
 
 	master, err := h.masterUseCase.CreateMaster(ctx, master)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
+		return errors.FromDomain(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
 	}
 
 	log.Info("master created", "master_id", master.ID)
@@ -78,7 +86,7 @@ func (h *MasterHandler) GetMaster(c echo.Context) error {
 
 	master, err := h.masterUseCase.GetMasterByID(ctx, id)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
+		return errors.FromDomain(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
 	}
 
 	log.Info("master retrieved", "master_id", id)
@@ -104,7 +112,7 @@ func (h *MasterHandler) ListMasters(c echo.Context) error {
 
 	masters, err := h.masterUseCase.ListMasters(ctx, offset, limit)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list masters", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list masters", err)
 	}
 	return c.JSON(http.StatusOK, masters)
 }
@@ -139,7 +147,7 @@ func (h *MasterHandler) UpdateMaster(c echo.Context) error {
 	master.ID = id
 
 	if err := h.masterUseCase.UpdateMaster(ctx, master); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update master", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update master", err)
 	}
 
 	log.Info("master updated", "master_id", id)
@@ -157,7 +165,7 @@ func (h *MasterHandler) DeleteMaster(c echo.Context) error {
 	}
 
 	if err := h.masterUseCase.DeleteMaster(ctx, id); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete master", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete master", err)
 	}
 
 	log.Info("master deleted", "master_id", id)
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type OrganizationHandler struct {
+	orgUseCase *usecase.OrganizationUseCase
+}
+
+func NewOrganizationHandler(s *server.Server, uc *usecase.OrganizationUseCase) {
+	handler := &OrganizationHandler{orgUseCase: uc}
+
+	ownAdmin := middleware.Authorize(middleware.OwnOrganization("id"))
+
+	group := s.NewGroup("/api/v1/organizations")
+	group.POST("", handler.CreateOrganization)
+	group.GET("/:id", handler.GetOrganization, ownAdmin)
+	group.PUT("/:id", handler.UpdateOrganization, ownAdmin)
+}
+
+func (h *OrganizationHandler) CreateOrganization(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	var req dto.CreateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	org, err := h.orgUseCase.CreateOrganization(ctx, &entity.Organization{
+		Name:     req.Name,
+		Timezone: req.Timezone,
+	}, &entity.Admin{
+		Name:  req.Admin.Name,
+		Email: req.Admin.Email,
+	}, req.Admin.Password)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create organization", err)
+	}
+
+	log.Info("organization created", "organization_id", org.ID)
+	return c.JSON(http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) GetOrganization(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	org, err := h.orgUseCase.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get organization", err)
+	}
+	return c.JSON(http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) UpdateOrganization(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	var req dto.UpdateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	org := &entity.Organization{
+		ID:       id,
+		Name:     req.Name,
+		Timezone: req.Timezone,
+	}
+	if err := h.orgUseCase.UpdateOrganization(ctx, org); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update organization", err)
+	}
+
+	log.Info("organization updated", "organization_id", id)
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler struct {
+	reportUseCase *usecase.ReportUseCase
+}
+
+func NewReportHandler(s *server.Server, uc *usecase.ReportUseCase) {
+	handler := &ReportHandler{reportUseCase: uc}
+
+	// masters get their own reports
+	staff := middleware.Authorize(middleware.Role(entity.RoleAdmin, entity.RoleMaster))
+
+	group := s.NewGroup("/api/v1/reports", middleware.RequireAuth, middleware.Scopes("reports"))
+	group.GET("/utilization", handler.Utilization, staff)
+	group.GET("/revenue", handler.Revenue, staff)
+}
+
+// GET /api/v1/reports/utilization?from=YYYY-MM-DD&to=YYYY-MM-DD&master_id=&format=csv
+func (h *ReportHandler) Utilization(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	from, to, err := reportRange(c)
+	if err != nil {
+		return err
+	}
+	masterID, err := reportMaster(c)
+	if err != nil {
+		return err
+	}
+	csvFormat, err := reportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.reportUseCase.Utilization(ctx, masterID, from, to)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to build utilization report", err)
+	}
+	if !csvFormat {
+		return c.JSON(http.StatusOK, report)
+	}
+
+	// one row per master and day with the totals, followed by a row per
+	// service booked that day
+	rows := [][]string{{"master_id", "master_name", "date", "service_id", "service_name", "bookings", "working_minutes", "booked_minutes", "utilization"}}
+	for _, m := range report.Masters {
+		for _, d := range m.Days {
+			rows = append(rows, []string{
+				m.MasterID.String(), m.MasterName, d.Date, "", "", "",
+				strconv.Itoa(d.WorkingMinutes), strconv.Itoa(d.BookedMinutes), strconv.FormatFloat(d.Rate, 'f', -1, 64),
+			})
+			for _, s := range d.Services {
+				rows = append(rows, []string{
+					m.MasterID.String(), m.MasterName, d.Date, s.ServiceID.String(), s.ServiceName, strconv.Itoa(s.Bookings),
+					"", strconv.Itoa(s.BookedMinutes), "",
+				})
+			}
+		}
+	}
+	return csvBlob(c, "utilization_"+report.From+"_"+report.To+".csv", rows)
+}
+
+// GET /api/v1/reports/revenue?from=YYYY-MM-DD&to=YYYY-MM-DD&period=day|week|month&group_by=master,service,status&status=completed,...&master_id=&format=csv
+func (h *ReportHandler) Revenue(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	from, to, err := reportRange(c)
+	if err != nil {
+		return err
+	}
+	masterID, err := reportMaster(c)
+	if err != nil {
+		return err
+	}
+	csvFormat, err := reportFormat(c)
+	if err != nil {
+		return err
+	}
+
+	opts := usecase.RevenueOptions{Period: entity.ReportPeriod(c.QueryParam("period"))}
+	switch opts.Period {
+	case "", entity.ReportPeriodDay, entity.ReportPeriodWeek, entity.ReportPeriodMonth:
+	default:
+		return errors.NewHTTPError(http.StatusBadRequest, "period must be day, week or month", nil)
+	}
+	for _, g := range queryList(c, "group_by") {
+		switch g {
+		case "master":
+			opts.ByMaster = true
+		case "service":
+			opts.ByService = true
+		case "status":
+			opts.ByStatus = true
+		default:
+			return errors.NewHTTPError(http.StatusBadRequest, "group_by must list master, service or status", nil)
+		}
+	}
+	for _, s := range queryList(c, "status") {
+		status := entity.BookingStatus(s)
+		switch status {
+		case entity.BookingStatusPending, entity.BookingStatusConfirmed, entity.BookingStatusCompleted, entity.BookingStatusCancelled:
+			opts.Statuses = append(opts.Statuses, status)
+		default:
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid booking status", nil)
+		}
+	}
+
+	report, err := h.reportUseCase.Revenue(ctx, masterID, from, to, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to build revenue report", err)
+	}
+	if !csvFormat {
+		return c.JSON(http.StatusOK, report)
+	}
+
+	rows := [][]string{{"period", "master_id", "master_name", "service_id", "service_name", "status", "bookings", "revenue"}}
+	for _, r := range report.Rows {
+		var masterID, serviceID string
+		if r.MasterID != nil {
+			masterID = r.MasterID.String()
+		}
+		if r.ServiceID != nil {
+			serviceID = r.ServiceID.String()
+		}
+		rows = append(rows, []string{
+			r.Period, masterID, r.MasterName, serviceID, r.ServiceName, r.Status,
+			strconv.Itoa(r.Bookings), strconv.FormatFloat(r.Revenue, 'f', 2, 64),
+		})
+	}
+	return csvBlob(c, "revenue_"+report.From+"_"+report.To+".csv", rows)
+}
+
+// reportRange reads the required from and to dates of a report.
+func reportRange(c echo.Context) (time.Time, time.Time, error) {
+	from, err := time.Parse(time.DateOnly, c.QueryParam("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewHTTPError(http.StatusBadRequest, "invalid from date format (use YYYY-MM-DD)", err)
+	}
+	to, err := time.Parse(time.DateOnly, c.QueryParam("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.NewHTTPError(http.StatusBadRequest, "invalid to date format (use YYYY-MM-DD)", err)
+	}
+	return from, to, nil
+}
+
+// reportMaster reads the optional master_id filter; uuid.Nil stands for all
+// masters.
+func reportMaster(c echo.Context) (uuid.UUID, error) {
+	s := c.QueryParam("master_id")
+	if s == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+	}
+	return id, nil
+}
+
+// reportFormat reports whether CSV is asked for instead of JSON.
+func reportFormat(c echo.Context) (bool, error) {
+	switch c.QueryParam("format") {
+	case "", "json":
+		return false, nil
+	case "csv":
+		return true, nil
+	default:
+		return false, errors.NewHTTPError(http.StatusBadRequest, "format must be json or csv", nil)
+	}
+}
+
+// queryList splits a comma-separated query parameter, skipping empty items.
+func queryList(c echo.Context, name string) []string {
+	var list []string
+	for _, item := range strings.Split(c.QueryParam(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// csvBlob responds with the rows as a CSV attachment.
+func csvBlob(c echo.Context, filename string, rows [][]string) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		return errors.NewHTTPError(http.StatusInternalServerError, "failed to write csv", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/curserio/chrono-api/internal/dto"
 	"github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
 	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/logger"
 	"github.com/curserio/chrono-api/pkg/timeutil"
@@ -23,25 +24,37 @@ type ScheduleHandler struct {
 func NewScheduleHandler(s *server.Server, uc *usecase.ScheduleUseCase) {
 	handler := &ScheduleHandler{scheduleUseCase: uc}
 
-	group := s.NewGroup("/api/v1/schedules")
-	group.POST("", handler.CreateSchedule)
-	group.GET("", handler.ListSchedules)
+	// masters manage only their own schedules, checked by the usecase
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+	staff := middleware.Authorize(middleware.Role(entity.RoleAdmin, entity.RoleMaster))
+
+	group := s.NewGroup("/api/v1/schedules", middleware.RequireAuth, middleware.Scopes("schedules"))
+	group.POST("", handler.CreateSchedule, staff)
+	group.GET("", handler.ListSchedules, admin)
 	group.GET("/:id", handler.GetSchedule)
-	group.PUT("/:id", handler.UpdateSchedule)
-	group.DELETE("/:id", handler.DeleteSchedule)
+	group.GET("/:id/details", handler.GetScheduleDetails)
+	group.POST("/:id/clone", handler.CloneSchedule, staff)
+	group.PUT("/:id", handler.UpdateSchedule, staff)
+	group.DELETE("/:id", handler.DeleteSchedule, staff)
+
+	group.POST("/templates", handler.CreateTemplate, admin)
+	group.GET("/templates", handler.ListTemplates, staff)
+	group.POST("/templates/:id/instantiate", handler.InstantiateTemplate, staff)
 
 	group.GET("/master/:master_id/date/:date", handler.GetScheduleForDate)
 	group.GET("/master/:master_id/range", handler.GetScheduleForRange)
 
-	group.POST("/:id/days", handler.AddDay)
+	group.POST("/:id/days", handler.AddDay, staff)
 	group.GET("/:id/days", handler.ListDays)
-	group.PUT("/days/:id", handler.UpdateDay)
-	group.DELETE("/days/:id", handler.DeleteDay)
+	group.PUT("/days/:id", handler.UpdateDay, staff)
+	group.DELETE("/days/:id", handler.DeleteDay, staff)
 
-	group.POST("/:id/slots", handler.AddSlot)
+	group.POST("/:id/slots", handler.AddSlot, staff)
 	group.GET("/:id/slots", handler.ListSlots)
-	group.PUT("/slots/:id", handler.UpdateSlot)
-	group.DELETE("/slots/:id", handler.DeleteSlot)
+	group.PUT("/:id/slots/range", handler.SetSlotRange, staff)
+	group.DELETE("/:id/slots/range", handler.ClearSlotRange, staff)
+	group.PUT("/slots/:id", handler.UpdateSlot, staff)
+	group.DELETE("/slots/:id", handler.DeleteSlot, staff)
 }
 
 func (h *ScheduleHandler) CreateSchedule(c echo.Context) error {
@@ -68,35 +81,20 @@ func (h *ScheduleHandler) CreateSchedule(c echo.Context) error {
 		Type:      scheduleType,
 		StartDate: time.Now(),
 		EndDate:   req.EndDate,
+		Priority:  req.Priority,
 	}
 	if req.StartDate != nil {
 		schedule.StartDate = *req.StartDate
 	}
 
-	days := make([]*entity.ScheduleDay, 0, len(req.Days))
-
-	for _, d := range req.Days {
-		day := &entity.ScheduleDay{
-			ScheduleID: schedule.ID,
-			Weekday:    d.Weekday,
-			DayIndex:   d.DayIndex,
-			IsDayOff:   d.IsDayOff,
-		}
-		if d.StartTime != nil && d.EndTime != nil {
-			start, end, err := parseTimeDuration(*d.StartTime, *d.EndTime)
-			if err != nil {
-				return errors.NewHTTPError(http.StatusBadRequest, "invalid time range", err)
-			}
-			day.StartTime = &start
-			day.EndTime = &end
-		}
-
-		days = append(days, day)
+	days, err := scheduleDays(req.Days)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
 	}
 
 	schedule, err = h.scheduleUseCase.CreateSchedule(ctx, schedule, days)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
+		return errors.FromDomain(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), err)
 	}
 
 	log.Info("schedule created", "schedule_id", schedule.ID)
@@ -114,13 +112,50 @@ func (h *ScheduleHandler) GetSchedule(c echo.Context) error {
 
 	schedule, err := h.scheduleUseCase.GetScheduleByID(ctx, id)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "Failed to get schedule", err)
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to get schedule", err)
 	}
 
 	log.Info("schedule retrieved", "schedule_id", id)
 	return c.JSON(http.StatusOK, schedule)
 }
 
+// maxDetailsWindow limits the window a schedule is expanded for.
+const maxDetailsWindow = 92 * 24 * time.Hour
+
+// GetScheduleDetails returns the schedule with its days and date overrides.
+// With from and to (YYYY-MM-DD), the overrides are limited to the window and
+// the schedule is expanded into the hours of each of its dates.
+func (h *ScheduleHandler) GetScheduleDetails(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid ID", err)
+	}
+
+	var from, to *time.Time
+	if fromStr, toStr := c.QueryParam("from"), c.QueryParam("to"); fromStr != "" || toStr != "" {
+		fromDate, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid from date format (use YYYY-MM-DD)", err)
+		}
+		toDate, err := time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid to date format (use YYYY-MM-DD)", err)
+		}
+		if toDate.Before(fromDate) || toDate.Sub(fromDate) >= maxDetailsWindow {
+			return errors.NewHTTPError(http.StatusBadRequest, "the window must span 1 to 92 days", nil)
+		}
+		from, to = &fromDate, &toDate
+	}
+
+	resp, err := h.scheduleUseCase.GetScheduleDetails(ctx, id, from, to)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to get schedule", err)
+	}
+	return jsonWithETag(c, resp)
+}
+
 func (h *ScheduleHandler) ListSchedules(c echo.Context) error {
 	ctx := c.Request().Context()
 
@@ -138,7 +173,7 @@ func (h *ScheduleHandler) ListSchedules(c echo.Context) error {
 
 	schedules, err := h.scheduleUseCase.ListSchedules(ctx, offset, limit)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "Failed to list schedules", err)
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to list schedules", err)
 	}
 	return c.JSON(http.StatusOK, schedules)
 }
@@ -156,33 +191,174 @@ func (h *ScheduleHandler) UpdateSchedule(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return errors.NewHTTPError(http.StatusBadRequest, "Invalid body", err)
 	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	upd := usecase.ScheduleUpdate{
+		MasterID:  req.MasterID,
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		Priority:  req.Priority,
+	}
+	if req.EndDate.Set {
+		upd.EndDate = req.EndDate.Value
+		upd.ClearEndDate = req.EndDate.Value == nil
+	}
+	if req.Type != nil {
+		scheduleType, err := req.Type.ToEntity()
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid type value", err)
+		}
+		upd.Type = &scheduleType
+	}
+	if req.Days != nil {
+		if upd.Days, err = scheduleDays(req.Days); err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
+		}
+	}
+
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	schedule, days, conflicts, err := h.scheduleUseCase.UpdateSchedule(ctx, id, upd, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to update schedule", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
+	}
+
+	log.Info("schedule updated", "schedule_id", id)
+	return c.JSON(http.StatusOK, dto.ScheduleResponse{Schedule: schedule, Days: days})
+}
+
+func (h *ScheduleHandler) DeleteSchedule(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid ID", err)
+	}
+
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.DeleteSchedule(ctx, id, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to delete schedule", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
+	}
+
+	log.Info("schedule deleted", "schedule_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CloneSchedule copies the schedule with its days, and optionally its date
+// overrides, to another master or period.
+func (h *ScheduleHandler) CloneSchedule(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid ID", err)
+	}
+
+	var req dto.CloneScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	cp := usecase.ScheduleCopy{
+		Name:         req.Name,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Priority:     req.Priority,
+		IncludeSlots: req.IncludeSlots,
+	}
+	if req.MasterID != nil {
+		cp.MasterID = *req.MasterID
+	}
+
+	schedule, err := h.scheduleUseCase.CloneSchedule(ctx, id, cp)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to clone schedule", err)
+	}
+
+	log.Info("schedule cloned", "source_id", id, "schedule_id", schedule.ID)
+	return c.JSON(http.StatusCreated, schedule)
+}
+
+func (h *ScheduleHandler) CreateTemplate(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	var req dto.CreateScheduleTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
 
 	scheduleType, err := req.Type.ToEntity()
 	if err != nil {
 		return errors.NewHTTPError(http.StatusBadRequest, "invalid type value", err)
 	}
+	days, err := scheduleDays(req.Days)
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
+	}
 
-	schedule := &entity.Schedule{
-		ID:        id,
-		MasterID:  req.MasterID,
+	template := &entity.Schedule{
 		Name:      req.Name,
 		Type:      scheduleType,
 		StartDate: time.Now(),
-		EndDate:   req.EndDate,
+		Priority:  req.Priority,
 	}
-	if req.StartDate != nil {
-		schedule.StartDate = *req.StartDate
+	template, err = h.scheduleUseCase.CreateTemplate(ctx, template, days)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to create template", err)
 	}
 
-	if err := h.scheduleUseCase.UpdateSchedule(ctx, schedule); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "Failed to update schedule", err)
+	log.Info("schedule template created", "schedule_id", template.ID)
+	return c.JSON(http.StatusCreated, dto.ScheduleResponse{Schedule: template, Days: days})
+}
+
+func (h *ScheduleHandler) ListTemplates(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	if limit > 1000 {
+		limit = 1000
 	}
 
-	log.Info("schedule updated", "schedule_id", id)
-	return c.NoContent(http.StatusOK)
+	templates, err := h.scheduleUseCase.ListTemplates(ctx, offset, limit)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to list templates", err)
+	}
+	return c.JSON(http.StatusOK, templates)
 }
 
-func (h *ScheduleHandler) DeleteSchedule(c echo.Context) error {
+// InstantiateTemplate creates a schedule of a master with the days of the
+// template, starting on the given date.
+func (h *ScheduleHandler) InstantiateTemplate(c echo.Context) error {
 	ctx := c.Request().Context()
 	log := logger.FromContext(ctx)
 
@@ -191,12 +367,27 @@ func (h *ScheduleHandler) DeleteSchedule(c echo.Context) error {
 		return errors.NewHTTPError(http.StatusBadRequest, "Invalid ID", err)
 	}
 
-	if err := h.scheduleUseCase.DeleteSchedule(ctx, id); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "Failed to delete schedule", err)
+	var req dto.InstantiateTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
 	}
 
-	log.Info("schedule deleted", "schedule_id", id)
-	return c.NoContent(http.StatusNoContent)
+	schedule, err := h.scheduleUseCase.InstantiateTemplate(ctx, id, usecase.ScheduleCopy{
+		MasterID:  req.MasterID,
+		Name:      req.Name,
+		StartDate: &req.StartDate,
+		EndDate:   req.EndDate,
+		Priority:  req.Priority,
+	})
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "Failed to instantiate template", err)
+	}
+
+	log.Info("schedule template instantiated", "template_id", id, "schedule_id", schedule.ID)
+	return c.JSON(http.StatusCreated, schedule)
 }
 
 func (h *ScheduleHandler) AddDay(c echo.Context) error {
@@ -218,17 +409,20 @@ func (h *ScheduleHandler) AddDay(c echo.Context) error {
 		DayIndex:   req.DayIndex,
 		IsDayOff:   req.IsDayOff,
 	}
-	if req.StartTime != nil && req.EndTime != nil {
-		start, end, err := parseTimeDuration(*req.StartTime, *req.EndTime)
-		if err != nil {
-			return errors.NewHTTPError(http.StatusBadRequest, "invalid time range", err)
-		}
-		day.StartTime = &start
-		day.EndTime = &end
+	if day.StartTime, day.EndTime, err = parseHours(req.StartTime, req.EndTime); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
 	}
 
-	if err := h.scheduleUseCase.AddDay(ctx, day); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to add day", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.AddDay(ctx, day, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to add day", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.JSON(http.StatusCreated, day)
 }
@@ -244,7 +438,7 @@ func (h *ScheduleHandler) ListDays(c echo.Context) error {
 
 	days, err := h.scheduleUseCase.GetDaysBySchedule(ctx, scheduleID)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list days", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list days", err)
 	}
 	return c.JSON(http.StatusOK, days)
 }
@@ -269,17 +463,20 @@ func (h *ScheduleHandler) UpdateDay(c echo.Context) error {
 		DayIndex: req.DayIndex,
 		IsDayOff: req.IsDayOff,
 	}
-	if req.StartTime != nil && req.EndTime != nil {
-		start, end, err := parseTimeDuration(*req.StartTime, *req.EndTime)
-		if err != nil {
-			return errors.NewHTTPError(http.StatusBadRequest, "invalid time range", err)
-		}
-		day.StartTime = &start
-		day.EndTime = &end
+	if day.StartTime, day.EndTime, err = parseHours(req.StartTime, req.EndTime); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
 	}
 
-	if err := h.scheduleUseCase.UpdateDay(ctx, day); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update day", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.UpdateDay(ctx, day, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update day", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -293,8 +490,16 @@ func (h *ScheduleHandler) DeleteDay(c echo.Context) error {
 		return errors.NewHTTPError(http.StatusBadRequest, "invalid day id", err)
 	}
 
-	if err := h.scheduleUseCase.DeleteDay(ctx, dayID); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete day", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.DeleteDay(ctx, dayID, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete day", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -319,17 +524,20 @@ func (h *ScheduleHandler) AddSlot(c echo.Context) error {
 		IsDayOff:   req.IsDayOff,
 	}
 
-	if req.StartTime != nil && req.EndTime != nil {
-		start, end, err := parseTimeDuration(*req.StartTime, *req.EndTime)
-		if err != nil {
-			return errors.NewHTTPError(http.StatusBadRequest, "invalid time range", err)
-		}
-		slot.StartTime = &start
-		slot.EndTime = &end
+	if slot.StartTime, slot.EndTime, err = parseHours(req.StartTime, req.EndTime); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
 	}
 
-	if err := h.scheduleUseCase.AddSlot(ctx, slot); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to add slot", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.AddSlot(ctx, slot, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to add slot", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.JSON(http.StatusCreated, slot)
 }
@@ -345,11 +553,85 @@ func (h *ScheduleHandler) ListSlots(c echo.Context) error {
 
 	slots, err := h.scheduleUseCase.GetSlotsBySchedule(ctx, scheduleID)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list slots", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list slots", err)
 	}
 	return c.JSON(http.StatusOK, slots)
 }
 
+// PUT /api/v1/schedules/:id/slots/range
+//
+// SetSlotRange overrides a range of dates, e.g. a vacation, in one request. The
+// response lists the bookings that no longer fit the working hours.
+func (h *ScheduleHandler) SetSlotRange(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid schedule id", err)
+	}
+
+	var req dto.SetSlotRangeRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	r := usecase.SlotRange{From: req.From, To: req.To, IsDayOff: req.IsDayOff}
+	if r.StartTime, r.EndTime, err = parseHours(req.StartTime, req.EndTime); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
+	}
+
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	slots, conflicts, err := h.scheduleUseCase.SetSlotRange(ctx, scheduleID, r, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to set slots", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
+	}
+
+	log.Info("schedule slots set", "schedule_id", scheduleID, "slots", len(slots), "conflicts", len(conflicts))
+	return c.JSON(http.StatusOK, dto.SlotRangeResponse{Slots: slots, Conflicts: conflicts})
+}
+
+// DELETE /api/v1/schedules/:id/slots/range?from=&to=
+func (h *ScheduleHandler) ClearSlotRange(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid schedule id", err)
+	}
+
+	from, err := time.Parse(time.DateOnly, c.QueryParam("from"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid from date format (use YYYY-MM-DD)", err)
+	}
+	to, err := time.Parse(time.DateOnly, c.QueryParam("to"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid to date format (use YYYY-MM-DD)", err)
+	}
+
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.ClearSlotRange(ctx, scheduleID, from, to, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete slots", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 // PUT /api/v1/schedules/slots/:id
 func (h *ScheduleHandler) UpdateSlot(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -369,17 +651,20 @@ func (h *ScheduleHandler) UpdateSlot(c echo.Context) error {
 		Date:     timeutil.NormalizeDate(req.Date),
 		IsDayOff: req.IsDayOff,
 	}
-	if req.StartTime != nil && req.EndTime != nil {
-		start, end, err := parseTimeDuration(*req.StartTime, *req.EndTime)
-		if err != nil {
-			return errors.NewHTTPError(http.StatusBadRequest, "invalid time range", err)
-		}
-		slot.StartTime = &start
-		slot.EndTime = &end
+	if slot.StartTime, slot.EndTime, err = parseHours(req.StartTime, req.EndTime); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid time format (use HH:MM)", err)
 	}
 
-	if err := h.scheduleUseCase.UpdateSlot(ctx, slot); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update slot", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.UpdateSlot(ctx, slot, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update slot", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -393,8 +678,16 @@ func (h *ScheduleHandler) DeleteSlot(c echo.Context) error {
 		return errors.NewHTTPError(http.StatusBadRequest, "invalid slot id", err)
 	}
 
-	if err := h.scheduleUseCase.DeleteSlot(ctx, slotID); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete slot", err)
+	opts, err := changeOptions(c)
+	if err != nil {
+		return err
+	}
+	conflicts, err := h.scheduleUseCase.DeleteSlot(ctx, slotID, opts)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete slot", err)
+	}
+	if opts.DryRun {
+		return c.JSON(http.StatusOK, dto.ScheduleImpactResponse{Conflicts: conflicts})
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -421,7 +714,7 @@ func (h *ScheduleHandler) GetScheduleForDate(c echo.Context) error {
 
 	resp, err := h.scheduleUseCase.GetScheduleForDate(ctx, masterID, date)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to get schedule for date", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get schedule for date", err)
 	}
 
 	// Если ничего нет — возвращаем пустой массив, чтобы клиенту было проще
@@ -466,7 +759,7 @@ func (h *ScheduleHandler) GetScheduleForRange(c echo.Context) error {
 
 	resp, err := h.scheduleUseCase.GetScheduleForRange(ctx, masterID, fromDate, toDate)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to get schedule range", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get schedule range", err)
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -483,6 +776,67 @@ func parseTimeOfDay(s string) (time.Time, error) {
 	return t, nil
 }
 
+// changeOptions reads the dry_run and on_conflict query parameters accepted by
+// the changes of schedules, days and slots.
+func changeOptions(c echo.Context) (usecase.ChangeOptions, error) {
+	var opts usecase.ChangeOptions
+	if v := c.QueryParam("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, errors.NewHTTPError(http.StatusBadRequest, "invalid dry_run value", err)
+		}
+		opts.DryRun = dryRun
+	}
+
+	switch action := usecase.ConflictAction(c.QueryParam("on_conflict")); action {
+	case "", usecase.ConflictKeep, usecase.ConflictNotify, usecase.ConflictCancel:
+		opts.OnConflict = action
+	default:
+		return opts, errors.NewHTTPError(http.StatusBadRequest, "on_conflict must be keep, notify or cancel", nil)
+	}
+	return opts, nil
+}
+
+// scheduleDays converts the requested days. Only the format of the times is
+// checked here; the rules of the schedule type are the usecase's.
+func scheduleDays(req []dto.ScheduleDay) ([]*entity.ScheduleDay, error) {
+	days := make([]*entity.ScheduleDay, 0, len(req))
+	for _, d := range req {
+		day := &entity.ScheduleDay{
+			Weekday:  d.Weekday,
+			DayIndex: d.DayIndex,
+			IsDayOff: d.IsDayOff,
+		}
+		var err error
+		if day.StartTime, day.EndTime, err = parseHours(d.StartTime, d.EndTime); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseHours parses the optional "HH:MM" times of a day or slot; whether
+// they are required and ordered is checked by the usecase.
+func parseHours(start, end *string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if start != nil {
+		t, err := parseTimeOfDay(*start)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &t
+	}
+	if end != nil {
+		t, err := parseTimeOfDay(*end)
+		if err != nil {
+			return nil, nil, err
+		}
+		to = &t
+	}
+	return from, to, nil
+}
+
 func parseTimeDuration(from, to string) (time.Time, time.Time, error) {
 	// парсим "HH:MM"
 	start, err := parseTimeOfDay(from)
@@ -8,6 +8,7 @@ import (
 	"github.com/curserio/chrono-api/internal/dto"
 	"github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
 	"github.com/curserio/chrono-api/internal/usecase"
 	"github.com/curserio/chrono-api/pkg/logger"
 	"github.com/google/uuid"
@@ -21,12 +22,15 @@ type ServiceHandler struct {
 func NewServiceHandler(s *server.Server, uc *usecase.ServiceUseCase) {
 	handler := &ServiceHandler{serviceUseCase: uc}
 
-	group := s.NewGroup("/api/v1/services")
-	group.POST("", handler.CreateService)
+	// masters manage only their own services, checked by the usecase
+	staff := middleware.Authorize(middleware.Role(entity.RoleAdmin, entity.RoleMaster))
+
+	group := s.NewGroup("/api/v1/services", middleware.RequireAuth, middleware.Scopes("services"))
+	group.POST("", handler.CreateService, staff)
 	group.GET("/:id", handler.GetService)
 	group.GET("/master/:master_id", handler.ListByMaster)
-	group.PUT("/:id", handler.UpdateService)
-	group.DELETE("/:id", handler.DeleteService)
+	group.PUT("/:id", handler.UpdateService, staff)
+	group.DELETE("/:id", handler.DeleteService, staff)
 }
 
 func (h *ServiceHandler) CreateService(c echo.Context) error {
@@ -46,7 +50,7 @@ func (h *ServiceHandler) CreateService(c echo.Context) error {
 		Price:       req.Price,
 	})
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to create service", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create service", err)
 	}
 
 	log.Info("service created", "service_id", service.ID)
@@ -61,7 +65,7 @@ func (h *ServiceHandler) GetService(c echo.Context) error {
 	}
 	svc, err := h.serviceUseCase.GetServiceByID(ctx, id)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to get service", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get service", err)
 	}
 	return c.JSON(http.StatusOK, svc)
 }
@@ -75,7 +79,7 @@ func (h *ServiceHandler) ListByMaster(c echo.Context) error {
 
 	svcs, err := h.serviceUseCase.ListServicesByMaster(ctx, masterID)
 	if err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to list services", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list services", err)
 	}
 	return c.JSON(http.StatusOK, svcs)
 }
@@ -102,7 +106,7 @@ func (h *ServiceHandler) UpdateService(c echo.Context) error {
 	}
 	service.ID = id
 	if err := h.serviceUseCase.UpdateService(ctx, service); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to update service", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update service", err)
 	}
 	return c.NoContent(http.StatusOK)
 }
@@ -115,7 +119,7 @@ func (h *ServiceHandler) DeleteService(c echo.Context) error {
 	}
 
 	if err := h.serviceUseCase.DeleteService(ctx, id); err != nil {
-		return errors.NewHTTPError(http.StatusInternalServerError, "failed to delete service", err)
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete service", err)
 	}
 	return c.NoContent(http.StatusNoContent)
 }
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type TimeOffHandler struct {
+	timeOffUseCase *usecase.TimeOffUseCase
+}
+
+func NewTimeOffHandler(s *server.Server, uc *usecase.TimeOffUseCase) {
+	handler := &TimeOffHandler{timeOffUseCase: uc}
+
+	// Policies
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+	staff := middleware.Authorize(middleware.Role(entity.RoleAdmin, entity.RoleMaster))
+
+	// Routes; time off ends up in the schedule
+	group := s.NewGroup("/api/v1/time-off", middleware.RequireAuth, middleware.Scopes("schedules"))
+	group.POST("", handler.RequestTimeOff, staff)
+	group.GET("", handler.ListTimeOff, staff)
+	group.GET("/:id", handler.GetTimeOff, staff)
+	group.DELETE("/:id", handler.CancelTimeOff, staff)
+	group.POST("/:id/approve", handler.ApproveTimeOff, admin)
+	group.POST("/:id/reject", handler.RejectTimeOff, admin)
+}
+
+// POST /api/v1/time-off
+func (h *TimeOffHandler) RequestTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	var req dto.CreateTimeOffRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	timeOff := &entity.TimeOffRequest{
+		MasterID:  req.MasterID,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Reason:    req.Reason,
+	}
+	if err := h.timeOffUseCase.RequestTimeOff(ctx, timeOff); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to request time off", err)
+	}
+
+	log.Info("time off requested", "time_off_id", timeOff.ID, "master_id", timeOff.MasterID)
+	return c.JSON(http.StatusCreated, timeOff)
+}
+
+// GET /api/v1/time-off?master_id=&status=pending
+func (h *TimeOffHandler) ListTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var masterID uuid.UUID
+	if s := c.QueryParam("master_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return errors.NewHTTPError(http.StatusBadRequest, "invalid master id", err)
+		}
+		masterID = id
+	}
+
+	status := entity.TimeOffStatus(c.QueryParam("status"))
+	switch status {
+	case "", entity.TimeOffStatusPending, entity.TimeOffStatusApproved, entity.TimeOffStatusRejected:
+	default:
+		return errors.NewHTTPError(http.StatusBadRequest, "status must be pending, approved or rejected", nil)
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	requests, err := h.timeOffUseCase.ListTimeOff(ctx, masterID, status, offset, limit)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list time off", err)
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// GET /api/v1/time-off/:id
+func (h *TimeOffHandler) GetTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	timeOff, err := h.timeOffUseCase.GetTimeOff(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get time off", err)
+	}
+	return c.JSON(http.StatusOK, timeOff)
+}
+
+// DELETE /api/v1/time-off/:id
+//
+// CancelTimeOff withdraws a request that has not been reviewed yet.
+func (h *TimeOffHandler) CancelTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.timeOffUseCase.CancelTimeOff(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to cancel time off", err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /api/v1/time-off/:id/approve
+//
+// ApproveTimeOff makes the days of the request days off. The response lists
+// the bookings on these days; their clients are notified.
+func (h *TimeOffHandler) ApproveTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, req, err := h.bindReview(c)
+	if err != nil {
+		return err
+	}
+
+	timeOff, conflicts, err := h.timeOffUseCase.ApproveTimeOff(ctx, id, req.Comment)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to approve time off", err)
+	}
+
+	log.Info("time off approved", "time_off_id", id, "master_id", timeOff.MasterID, "conflicts", len(conflicts))
+	return c.JSON(http.StatusOK, dto.TimeOffApprovalResponse{Request: timeOff, Conflicts: conflicts})
+}
+
+// POST /api/v1/time-off/:id/reject
+func (h *TimeOffHandler) RejectTimeOff(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, req, err := h.bindReview(c)
+	if err != nil {
+		return err
+	}
+
+	timeOff, err := h.timeOffUseCase.RejectTimeOff(ctx, id, req.Comment)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to reject time off", err)
+	}
+
+	log.Info("time off rejected", "time_off_id", id, "master_id", timeOff.MasterID)
+	return c.JSON(http.StatusOK, timeOff)
+}
+
+// bindReview reads the id and the optional body of a review.
+func (h *TimeOffHandler) bindReview(c echo.Context) (uuid.UUID, dto.ReviewTimeOffRequest, error) {
+	var req dto.ReviewTimeOffRequest
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return id, req, errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+	if err := c.Bind(&req); err != nil {
+		return id, req, errors.NewHTTPError(http.StatusBadRequest, "invalid body", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return id, req, errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+	return id, req, nil
+}
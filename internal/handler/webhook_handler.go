@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/infrastructure/http/server"
+	"github.com/curserio/chrono-api/internal/middleware"
+	"github.com/curserio/chrono-api/internal/usecase"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookUseCase *usecase.WebhookUseCase
+}
+
+func NewWebhookHandler(s *server.Server, uc *usecase.WebhookUseCase) {
+	handler := &WebhookHandler{webhookUseCase: uc}
+
+	admin := middleware.Authorize(middleware.Role(entity.RoleAdmin))
+
+	group := s.NewGroup("/api/v1/webhooks", middleware.RequireAuth, admin)
+	group.POST("", handler.CreateWebhook)
+	group.GET("", handler.ListWebhooks)
+	group.GET("/:id", handler.GetWebhook)
+	group.PUT("/:id", handler.UpdateWebhook)
+	group.DELETE("/:id", handler.DeleteWebhook)
+
+	group.GET("/:id/deliveries", handler.ListDeliveries)
+	group.GET("/deliveries/:id", handler.GetDelivery)
+	group.POST("/deliveries/:id/retry", handler.RetryDelivery)
+}
+
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	var req dto.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	sub, secret, err := h.webhookUseCase.CreateSubscription(ctx, &entity.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to create webhook", err)
+	}
+
+	log.Info("webhook created", "webhook_id", sub.ID)
+	return c.JSON(http.StatusCreated, dto.CreateWebhookResponse{WebhookSubscription: sub, Secret: secret})
+}
+
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	subs, err := h.webhookUseCase.ListSubscriptions(ctx)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list webhooks", err)
+	}
+	return c.JSON(http.StatusOK, subs)
+}
+
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	sub, err := h.webhookUseCase.GetSubscription(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get webhook", err)
+	}
+	return c.JSON(http.StatusOK, sub)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid request", err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "Validation failed", err)
+	}
+
+	if err := h.webhookUseCase.UpdateSubscription(ctx, &entity.WebhookSubscription{
+		ID:         id,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     *req.Active,
+	}); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to update webhook", err)
+	}
+
+	log.Info("webhook updated", "webhook_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.webhookUseCase.DeleteSubscription(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to delete webhook", err)
+	}
+
+	log.Info("webhook deleted", "webhook_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GET /webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	offset, err := strconv.Atoi(c.QueryParam("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(ctx, id, offset, limit)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to list webhook deliveries", err)
+	}
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// GET /webhooks/deliveries/:id
+func (h *WebhookHandler) GetDelivery(c echo.Context) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	delivery, err := h.webhookUseCase.GetDelivery(ctx, id)
+	if err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to get webhook delivery", err)
+	}
+	return c.JSON(http.StatusOK, delivery)
+}
+
+// POST /webhooks/deliveries/:id/retry
+func (h *WebhookHandler) RetryDelivery(c echo.Context) error {
+	ctx := c.Request().Context()
+	log := logger.FromContext(ctx)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return errors.NewHTTPError(http.StatusBadRequest, "invalid id", err)
+	}
+
+	if err := h.webhookUseCase.RetryDelivery(ctx, id); err != nil {
+		return errors.FromDomain(http.StatusInternalServerError, "failed to retry webhook delivery", err)
+	}
+
+	log.Info("webhook delivery requeued", "delivery_id", id)
+	return c.NoContent(http.StatusAccepted)
+}
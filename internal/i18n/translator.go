@@ -11,6 +11,8 @@ import (
 
 type Translator struct {
 	translations map[language.Tag]map[string]string
+	supported    []language.Tag
+	matcher      language.Matcher
 }
 
 func NewTranslator(localesPath string) (*Translator, error) {
@@ -35,6 +37,9 @@ func NewTranslator(localesPath string) (*Translator, error) {
 		t.translations[lang] = translations
 	}
 
+	t.supported = supportedLangs
+	t.matcher = language.NewMatcher(supportedLangs)
+
 	return t, nil
 }
 
@@ -46,3 +51,23 @@ func (t *Translator) Translate(lang language.Tag, key string) string {
 	}
 	return key // Возвращаем ключ, если перевод не найден
 }
+
+// Translatef translates the key and formats it with the arguments. Messages
+// may use indexed verbs, e.g. %[2]s, to reorder arguments per language.
+func (t *Translator) Translatef(lang language.Tag, key string, args ...any) string {
+	return fmt.Sprintf(t.Translate(lang, key), args...)
+}
+
+// Match returns the supported language closest to a user's language, e.g.
+// Russian for "ru-RU". Unknown or empty languages fall back to fallback.
+func (t *Translator) Match(lang string, fallback language.Tag) language.Tag {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return fallback
+	}
+	_, i, confidence := t.matcher.Match(tag)
+	if confidence == language.No {
+		return fallback
+	}
+	return t.supported[i]
+}
@@ -20,6 +20,7 @@ type Server struct {
 	addr            string
 	defaultLanguage string
 	logger          logger.Logger
+	middleware      []echo.MiddlewareFunc
 }
 
 func New(addr, serviceName string, lc fx.Lifecycle, opts ...func(*Server)) *Server {
@@ -47,6 +48,8 @@ func New(addr, serviceName string, lc fx.Lifecycle, opts ...func(*Server)) *Serv
 	e.Use(middleware.I18nMiddleware(server.defaultLanguage))
 	// timezone
 	e.Use(middleware.WithUserContext)
+	// custom, e.g. authentication
+	e.Use(server.middleware...)
 
 	server.echo = e
 
@@ -1,6 +1,9 @@
 package server
 
-import "github.com/curserio/chrono-api/pkg/logger"
+import (
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
 
 const (
 	defaultLanguage = "en"
@@ -17,3 +20,10 @@ func WithDefaultLanguage(lang string) func(*Server) {
 		s.defaultLanguage = lang
 	}
 }
+
+// WithMiddleware registers middleware that runs for every route after the built-in ones.
+func WithMiddleware(m ...echo.MiddlewareFunc) func(*Server) {
+	return func(s *Server) {
+		s.middleware = append(s.middleware, m...)
+	}
+}
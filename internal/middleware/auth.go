@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyHeader carries the API key of server-to-server integrations.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves an API key to the integration principal.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error)
+}
+
+// Authenticate validates the bearer access token or the API key, if any, and
+// puts the principal and its organization into the request context. Requests
+// without credentials pass through unauthenticated; RequireAuth rejects them
+// where needed.
+func Authenticate(tokens *auth.TokenManager, keys APIKeyAuthenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var principal *auth.Principal
+
+			if key := c.Request().Header.Get(APIKeyHeader); key != "" {
+				p, err := keys.AuthenticateAPIKey(c.Request().Context(), key)
+				if err != nil {
+					return apiErrors.FromDomain(http.StatusInternalServerError, "failed to check api key", err)
+				}
+				principal = p
+			} else {
+				token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+				if !ok {
+					return next(c)
+				}
+
+				p, err := tokens.ParseAccessToken(token)
+				if err != nil {
+					return apiErrors.NewHTTPError(http.StatusUnauthorized, "invalid access token", err)
+				}
+				principal = p
+			}
+
+			ctx := auth.WithPrincipal(c.Request().Context(), principal)
+			ctx = tenant.WithOrganizationID(ctx, principal.OrganizationID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// RequireAuth rejects requests without an authenticated principal.
+func RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := auth.PrincipalFromContext(c.Request().Context()); !ok {
+			return apiErrors.NewHTTPError(http.StatusUnauthorized, "authentication required", apiErrors.ErrUnauthorized)
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type apiKeys map[string]*auth.Principal
+
+func (k apiKeys) AuthenticateAPIKey(_ context.Context, key string) (*auth.Principal, error) {
+	if p, ok := k[key]; ok {
+		return p, nil
+	}
+	return nil, apiErrors.ErrUnauthorized
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	integration := &auth.Principal{SubjectID: uuid.New(), OrganizationID: uuid.New(), Role: entity.RoleIntegration, Scopes: []string{"bookings:read"}}
+	keys := apiKeys{"chr_valid": integration}
+
+	tests := []struct {
+		name   string
+		key    string
+		method string
+		want   int
+	}{
+		{"valid key with the scope", "chr_valid", http.MethodGet, http.StatusOK},
+		{"valid key without the scope", "chr_valid", http.MethodPost, http.StatusForbidden},
+		{"unknown, revoked or expired key", "chr_invalid", http.MethodGet, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set(APIKeyHeader, tt.key)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			h := Authenticate(nil, keys)(RequireAuth(Scopes("bookings")(Authorize()(func(c echo.Context) error {
+				p, _ := auth.PrincipalFromContext(c.Request().Context())
+				assert.Equal(t, integration, p)
+				orgID, err := tenant.OrganizationID(c.Request().Context())
+				assert.NoError(t, err)
+				assert.Equal(t, integration.OrganizationID, orgID)
+				return c.NoContent(http.StatusOK)
+			}))))
+			err := h(c)
+
+			if tt.want == http.StatusOK {
+				assert.NoError(t, err)
+				return
+			}
+			httpErr, ok := apiErrors.Unwrap(err)
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.want, httpErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/labstack/echo/v4"
+)
+
+// Policy grants access to a route. Handler groups declare the policies of
+// each route; checks that need the stored entity (e.g. the owner of a
+// booking) are done by the usecases.
+type Policy func(c echo.Context, p *auth.Principal) bool
+
+// Authorize lets the request through if any of the policies grants access.
+func Authorize(policies ...Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p, ok := auth.PrincipalFromContext(c.Request().Context())
+			if !ok {
+				return apiErrors.NewHTTPError(http.StatusUnauthorized, "authentication required", apiErrors.ErrUnauthorized)
+			}
+
+			// Integrations are authorized by the scopes of the group instead
+			if p.Role == entity.RoleIntegration {
+				if granted, _ := c.Get(scopeGrantedKey).(bool); granted {
+					return next(c)
+				}
+				return apiErrors.NewHTTPError(http.StatusForbidden, "forbidden", apiErrors.ErrForbidden)
+			}
+
+			for _, policy := range policies {
+				if policy(c, p) {
+					return next(c)
+				}
+			}
+
+			return apiErrors.NewHTTPError(http.StatusForbidden, "forbidden", apiErrors.ErrForbidden)
+		}
+	}
+}
+
+const scopeGrantedKey = "scope_granted"
+
+// Scopes limits integrations to the scopes of the resource: "<resource>:read"
+// for safe methods and "<resource>:write" for the others. Once granted, the
+// role policies of the group's routes are not applied to the integration.
+// Groups without Scopes are closed to integrations.
+func Scopes(resource string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p, ok := auth.PrincipalFromContext(c.Request().Context())
+			if !ok || p.Role != entity.RoleIntegration {
+				return next(c)
+			}
+
+			scope := resource + ":write"
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = resource + ":read"
+			}
+
+			if !p.HasScope(scope) {
+				return apiErrors.NewHTTPError(http.StatusForbidden, "missing scope "+scope, apiErrors.ErrForbidden)
+			}
+
+			c.Set(scopeGrantedKey, true)
+			return next(c)
+		}
+	}
+}
+
+// Role grants access to principals with any of the given roles.
+func Role(roles ...entity.Role) Policy {
+	return func(_ echo.Context, p *auth.Principal) bool {
+		for _, r := range roles {
+			if p.Role == r {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Self grants access to a principal of the given role whose own id is in the path parameter.
+func Self(role entity.Role, param string) Policy {
+	return func(c echo.Context, p *auth.Principal) bool {
+		return p.Role == role && c.Param(param) == p.SubjectID.String()
+	}
+}
+
+// OwnOrganization grants access to admins of the organization whose id is in the path parameter.
+func OwnOrganization(param string) Policy {
+	return func(c echo.Context, p *auth.Principal) bool {
+		return p.Role == entity.RoleAdmin && c.Param(param) == p.OrganizationID.String()
+	}
+}
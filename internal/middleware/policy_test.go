@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	self, other := uuid.New(), uuid.New()
+	admin := &auth.Principal{SubjectID: uuid.New(), Role: entity.RoleAdmin}
+	master := &auth.Principal{SubjectID: self, Role: entity.RoleMaster}
+	client := &auth.Principal{SubjectID: self, Role: entity.RoleClient}
+	integration := &auth.Principal{SubjectID: uuid.New(), Role: entity.RoleIntegration, Scopes: []string{"masters:read"}}
+
+	adminOnly := []Policy{Role(entity.RoleAdmin)}
+	adminOrMaster := []Policy{Role(entity.RoleAdmin), Self(entity.RoleMaster, "id")}
+	adminOrClient := []Policy{Role(entity.RoleAdmin), Self(entity.RoleClient, "id")}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		method    string
+		policies  []Policy
+		id        uuid.UUID
+		want      int
+	}{
+		{"unauthenticated", nil, http.MethodGet, adminOnly, self, http.StatusUnauthorized},
+		{"admin deletes a master", admin, http.MethodDelete, adminOnly, other, http.StatusOK},
+		{"client deletes a master", client, http.MethodDelete, adminOnly, other, http.StatusForbidden},
+		{"master deletes itself", master, http.MethodDelete, adminOnly, self, http.StatusForbidden},
+		{"master updates itself", master, http.MethodPut, adminOrMaster, self, http.StatusOK},
+		{"master updates another master", master, http.MethodPut, adminOrMaster, other, http.StatusForbidden},
+		{"client reads its bookings", client, http.MethodGet, adminOrClient, self, http.StatusOK},
+		{"client reads the bookings of another client", client, http.MethodGet, adminOrClient, other, http.StatusForbidden},
+		{"master reads the bookings of a client", master, http.MethodGet, adminOrClient, self, http.StatusForbidden},
+		{"admin reads the bookings of a client", admin, http.MethodGet, adminOrClient, other, http.StatusOK},
+		{"integration with the scope", integration, http.MethodGet, adminOnly, other, http.StatusOK},
+		{"integration without the scope", integration, http.MethodDelete, adminOnly, other, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id.String())
+
+			h := Scopes("masters")(Authorize(tt.policies...)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}))
+			err := h(c)
+
+			if tt.want == http.StatusOK {
+				assert.NoError(t, err)
+				return
+			}
+			httpErr, ok := apiErrors.Unwrap(err)
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.want, httpErr.Code)
+			}
+		})
+	}
+}
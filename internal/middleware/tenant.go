@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const OrganizationHeader = "X-Organization-ID"
+
+// RequireTenant scopes the request context to the organization passed in the
+// X-Organization-ID header. Authenticated requests are already scoped to the
+// principal's organization and the header is ignored for them; the header is
+// only meant for unauthenticated endpoints such as login.
+func RequireTenant(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, err := tenant.OrganizationID(c.Request().Context()); err == nil {
+			return next(c)
+		}
+
+		raw := c.Request().Header.Get(OrganizationHeader)
+		if raw == "" {
+			return apiErrors.NewHTTPError(http.StatusBadRequest, "organization is required", apiErrors.ErrOrganizationRequired)
+		}
+
+		orgID, err := uuid.Parse(raw)
+		if err != nil {
+			return apiErrors.NewHTTPError(http.StatusBadRequest, "invalid organization id", err)
+		}
+
+		ctx := tenant.WithOrganizationID(c.Request().Context(), orgID)
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		return next(c)
+	}
+}
@@ -0,0 +1,86 @@
+// Package netguard keeps outbound requests to tenant-supplied URLs, such as
+// webhooks and calendar sources, away from hosts of our own network.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbidden is returned for URLs that are not https or that resolve to a
+// loopback, private, link-local or otherwise internal address.
+var ErrForbidden = errors.New("target is not a public https address")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which
+// netip.Addr.IsPrivate does not cover.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddress reports whether requests may be sent to the address.
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// CheckURL rejects URLs that the client of NewClient refuses anyway, so they
+// can be turned down before they are stored: schemes other than https, and
+// hosts that are localhost or an internal address. Other host names are
+// checked when the client connects.
+func CheckURL(u *url.URL, allowInsecure bool) error {
+	if allowInsecure {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return ErrForbidden
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbidden
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !PublicAddress(addr) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// NewClient returns a client for tenant-supplied URLs. Unless insecure
+// targets are allowed, e.g. in local development, it refuses plain http and
+// checks every address it connects to, redirects and DNS rebinding included,
+// in the dialer.
+func NewClient(timeout time.Duration, allowInsecure bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowInsecure {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !PublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrForbidden, address)
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		// without a Proxy: a proxy would connect to the target instead of the dialer
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return CheckURL(req.URL, allowInsecure)
+		},
+	}
+}
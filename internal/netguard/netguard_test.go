@@ -0,0 +1,74 @@
+package netguard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicAddress(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"fd00::1":          false,
+		"fe80::1":          false,
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, PublicAddress(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	for raw, allowed := range map[string]bool{
+		"https://calendar.example.com/a.ics": true,
+		"https://93.184.216.34/a.ics":        true,
+		"http://calendar.example.com/a.ics":  false,
+		"https://localhost:8443/a.ics":       false,
+		"https://db.localhost/a.ics":         false,
+		"https://127.0.0.1:5432":             false,
+		"https://169.254.169.254/latest":     false,
+		"https://[::1]/a.ics":                false,
+		"https://10.0.0.5/a.ics":             false,
+	} {
+		u, err := url.Parse(raw)
+		assert.NoError(t, err)
+		if allowed {
+			assert.NoError(t, CheckURL(u, false), raw)
+		} else {
+			assert.ErrorIs(t, CheckURL(u, false), ErrForbidden, raw)
+		}
+		assert.NoError(t, CheckURL(u, true), raw)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	called := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	defer server.Close()
+
+	// the dialer refuses the loopback address even without CheckURL
+	_, err := NewClient(time.Second, false).Get(server.URL)
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.False(t, called)
+
+	redirect := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/latest/meta-data", http.StatusFound))
+	defer redirect.Close()
+	client := NewClient(time.Second, false)
+	// only the redirect is checked: the first request goes to the test server
+	client.Transport = http.DefaultTransport
+	_, err = client.Get(redirect.URL)
+	assert.ErrorIs(t, err, ErrForbidden)
+}
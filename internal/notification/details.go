@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+)
+
+// timeLayout is how booking times appear in notifications
+const timeLayout = "02.01.2006 15:04"
+
+// details is a booking with the parties and the service it involves.
+type details struct {
+	Booking *entity.Booking
+	Master  *entity.Master
+	Client  *entity.Client
+	Service *entity.Service
+}
+
+// directory loads booking details for the organization in the context.
+type directory struct {
+	masters  repository.MasterRepository
+	clients  repository.ClientRepository
+	services repository.ServiceRepository
+}
+
+func (d *directory) load(ctx context.Context, b *entity.Booking) (*details, error) {
+	master, err := d.masters.GetByID(ctx, b.MasterID)
+	if err != nil {
+		return nil, fmt.Errorf("get master: %w", err)
+	}
+	client, err := d.clients.GetByID(ctx, b.ClientID)
+	if err != nil {
+		return nil, fmt.Errorf("get client: %w", err)
+	}
+	service, err := d.services.GetByID(ctx, b.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get service: %w", err)
+	}
+	return &details{
+		Booking: b,
+		Master:  master,
+		Client:  client,
+		Service: service,
+	}, nil
+}
+
+// localTime formats t in the recipient's time zone, or UTC if it is unknown.
+func localTime(t time.Time, timezone string) string {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(timeLayout)
+}
+
+// retryable reports whether any of the possibly joined errors is not
+// permanent, so redelivering the event may help.
+func retryable(err error, permanent func(error) bool) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			if retryable(e, permanent) {
+				return true
+			}
+		}
+		return false
+	}
+	return !permanent(err)
+}
@@ -0,0 +1,270 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/i18n"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"golang.org/x/text/language"
+)
+
+// Templates are named after the message, e.g. "reminder", with the subject
+// in "reminder.subject". Each language has a plain text and an HTML file.
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+type emailTemplates struct {
+	text map[language.Tag]*texttemplate.Template
+	html map[language.Tag]*htmltemplate.Template
+}
+
+func loadEmailTemplates(langs ...language.Tag) (*emailTemplates, error) {
+	t := &emailTemplates{
+		text: make(map[language.Tag]*texttemplate.Template),
+		html: make(map[language.Tag]*htmltemplate.Template),
+	}
+	for _, lang := range langs {
+		text, err := texttemplate.ParseFS(templateFS, fmt.Sprintf("templates/%s.txt.tmpl", lang))
+		if err != nil {
+			return nil, err
+		}
+		html, err := htmltemplate.ParseFS(templateFS, fmt.Sprintf("templates/%s.html.tmpl", lang))
+		if err != nil {
+			return nil, err
+		}
+		t.text[lang] = text
+		t.html[lang] = html
+	}
+	return t, nil
+}
+
+// emailData is available to the templates. Times are formatted in the
+// recipient's time zone.
+type emailData struct {
+	RecipientName string
+	ClientName    string
+	MasterName    string
+	ServiceName   string
+	Start         string
+	End           string
+	Timezone      string
+}
+
+func (t *emailTemplates) render(lang language.Tag, name string, data *emailData) (*Email, error) {
+	text, html := t.text[lang], t.html[lang]
+	if text == nil || html == nil {
+		return nil, fmt.Errorf("no email templates for %s", lang)
+	}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.ExecuteTemplate(&textBody, name, data); err != nil {
+		return nil, err
+	}
+	if err := html.ExecuteTemplate(&htmlBody, name, data); err != nil {
+		return nil, err
+	}
+	return &Email{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// EmailNotifier emails masters and clients that have an address. It is an
+// outbox sink for booking events and a reminder notifier. Client messages
+// about confirmed bookings and reminders carry an .ics invite, cancellations
+// carry the matching cancellation.
+//
+// As with Telegram, a failed event is redelivered as a whole and permanent
+// rejections are not retried.
+type EmailNotifier struct {
+	sender      *SMTPSender
+	templates   *emailTemplates
+	translator  *i18n.Translator
+	directory   *directory
+	defaultLang language.Tag
+	log         logger.Logger
+}
+
+func NewEmailNotifier(
+	sender *SMTPSender,
+	translator *i18n.Translator,
+	masters repository.MasterRepository,
+	clients repository.ClientRepository,
+	services repository.ServiceRepository,
+	defaultLang language.Tag,
+	log logger.Logger,
+) (*EmailNotifier, error) {
+	templates, err := loadEmailTemplates(language.English, language.Russian)
+	if err != nil {
+		return nil, fmt.Errorf("load email templates: %w", err)
+	}
+	return &EmailNotifier{
+		sender:      sender,
+		templates:   templates,
+		translator:  translator,
+		directory:   &directory{masters: masters, clients: clients, services: services},
+		defaultLang: defaultLang,
+		log:         log,
+	}, nil
+}
+
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// Handle notifies about new, confirmed and cancelled bookings and about
+// bookings left outside the working hours.
+func (n *EmailNotifier) Handle(ctx context.Context, e *entity.Event) error {
+	switch e.Type {
+	case entity.EventBookingCreated, entity.EventBookingConfirmed, entity.EventBookingCancelled,
+		entity.EventBookingOutsideHours:
+	default:
+		return nil
+	}
+
+	var b entity.Booking
+	if err := json.Unmarshal(e.Payload, &b); err != nil {
+		return fmt.Errorf("decode booking: %w", err)
+	}
+
+	d, err := n.directory.load(ctx, &b)
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		n.log.Warn("email notification dropped", "event_id", e.ID, "error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	master, client := masterRecipient(d.Master), clientRecipient(d.Client)
+	switch e.Type {
+	case entity.EventBookingCreated:
+		err = errors.Join(
+			n.send(ctx, master, "booking_created_master", d, ""),
+			n.send(ctx, client, "booking_created", d, ""),
+		)
+	case entity.EventBookingConfirmed:
+		err = n.send(ctx, client, "booking_confirmed", d, ical.MethodRequest)
+	case entity.EventBookingCancelled:
+		err = errors.Join(
+			n.send(ctx, master, "booking_cancelled", d, ""),
+			n.send(ctx, client, "booking_cancelled", d, ical.MethodCancel),
+		)
+	case entity.EventBookingOutsideHours:
+		err = n.send(ctx, client, "booking_outside_hours", d, "")
+	}
+
+	if err != nil && !retryable(err, permanentSMTP) {
+		n.log.Warn("email notification dropped", "event_id", e.ID, "error", err)
+		return nil
+	}
+	return err
+}
+
+// Notify sends the reminder to the client.
+func (n *EmailNotifier) Notify(ctx context.Context, _ *entity.Reminder, b *entity.Booking) error {
+	d, err := n.directory.load(ctx, b)
+	if err != nil {
+		return err
+	}
+	if d.Client.Email == nil || *d.Client.Email == "" {
+		return reminder.ErrNoRecipient
+	}
+	return n.send(ctx, clientRecipient(d.Client), "reminder", d, ical.MethodRequest)
+}
+
+// recipient is a master or client addressed by email.
+type recipient struct {
+	Name     string
+	Email    *string
+	Language string
+	Timezone string
+}
+
+func masterRecipient(m *entity.Master) recipient {
+	return recipient{Name: m.Name, Email: m.Email, Language: m.Language, Timezone: m.Timezone}
+}
+
+func clientRecipient(c *entity.Client) recipient {
+	return recipient{Name: c.Name, Email: c.Email, Language: c.Language, Timezone: c.Timezone}
+}
+
+// send renders and sends the message, skipping recipients without an
+// address. A non-empty method attaches the booking as an invite.
+func (n *EmailNotifier) send(ctx context.Context, to recipient, name string, d *details, method string) error {
+	if to.Email == nil || *to.Email == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(to.Timezone)
+	if err != nil || to.Timezone == "" {
+		loc = time.UTC
+	}
+
+	lang := n.translator.Match(to.Language, n.defaultLang)
+	msg, err := n.templates.render(lang, name, &emailData{
+		RecipientName: to.Name,
+		ClientName:    d.Client.Name,
+		MasterName:    d.Master.Name,
+		ServiceName:   d.Service.Name,
+		Start:         d.Booking.StartTime.In(loc).Format(timeLayout),
+		End:           d.Booking.EndTime.In(loc).Format("15:04"),
+		Timezone:      loc.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("render %s email: %w", name, err)
+	}
+	msg.To = *to.Email
+
+	if method != "" {
+		msg.Attachments = append(msg.Attachments, Attachment{
+			Filename:    "invite.ics",
+			ContentType: fmt.Sprintf("text/calendar; charset=utf-8; method=%s", method),
+			Data:        n.invite(d, method, *to.Email).Bytes(),
+		})
+	}
+	return n.sender.Send(ctx, msg)
+}
+
+// invite returns the booking as a calendar event. Its UID is stable, so
+// calendar apps update or remove the event they imported before.
+func (n *EmailNotifier) invite(d *details, method, attendee string) *ical.Calendar {
+	status := ical.StatusConfirmed
+	if method == ical.MethodCancel {
+		status = ical.StatusCancelled
+	}
+	return &ical.Calendar{
+		ProdID: "-//Chrono//Booking//EN",
+		Method: method,
+		Events: []ical.Event{{
+			UID:       d.Booking.CalendarUID(),
+			Sequence:  int(d.Booking.UpdatedAt.Unix()),
+			Stamp:     time.Now(),
+			Start:     d.Booking.StartTime,
+			End:       d.Booking.EndTime,
+			Summary:   fmt.Sprintf("%s — %s", d.Service.Name, d.Master.Name),
+			Status:    status,
+			Organizer: n.sender.From(),
+			Attendees: []string{attendee},
+		}},
+	}
+}
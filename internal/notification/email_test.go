@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/i18n"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+)
+
+// fakeSMTP is a minimal SMTP server that accepts every message.
+type fakeSMTP struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	messages map[string]string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTP{ln: ln, messages: map[string]string{}}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	var rcpt string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL"):
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT"):
+			rcpt = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages[rcpt] = data.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTP) message(t *testing.T, to string) *mail.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, ok := s.messages[to]
+	require.True(t, ok, "no message to %s", to)
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestEmailNotifier(t *testing.T) {
+	translator, err := i18n.NewTranslator("../../locales")
+	require.NoError(t, err)
+
+	clientEmail := "bob@example.com"
+	master := &entity.Master{ID: uuid.New(), Name: "Anna", Language: "en", Timezone: "UTC"}
+	client := &entity.Client{ID: uuid.New(), Name: "Bob", Email: &clientEmail, Language: "ru", Timezone: "Europe/Moscow"}
+	service := &entity.Service{ID: uuid.New(), Name: "Haircut"}
+	booking := &entity.Booking{
+		ID:        uuid.New(),
+		MasterID:  master.ID,
+		ClientID:  client.ID,
+		ServiceID: service.ID,
+		StartTime: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC),
+		Status:    entity.BookingStatusConfirmed,
+	}
+
+	ctrl := gomock.NewController(t)
+	masters := mock.NewMockMasterRepository(ctrl)
+	clients := mock.NewMockClientRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	masters.EXPECT().GetByID(gomock.Any(), master.ID).Return(master, nil).AnyTimes()
+	clients.EXPECT().GetByID(gomock.Any(), client.ID).Return(client, nil).AnyTimes()
+	services.EXPECT().GetByID(gomock.Any(), service.ID).Return(service, nil).AnyTimes()
+
+	smtpServer := startFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(smtpServer.ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	sender, err := NewSMTPSender(config.SMTPConfig{
+		Host:    host,
+		Port:    portNum,
+		From:    "Chrono <noreply@example.com>",
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	n, err := NewEmailNotifier(sender, translator, masters, clients, services, language.English, logger.DefaultLogger)
+	require.NoError(t, err)
+
+	t.Run("confirmed booking carries an invite", func(t *testing.T) {
+		event, err := entity.NewEvent(entity.EventBookingConfirmed, booking.ID, booking)
+		require.NoError(t, err)
+		require.NoError(t, n.Handle(context.Background(), event))
+
+		msg := smtpServer.message(t, clientEmail)
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Ваша запись на Haircut подтверждена", subject)
+
+		_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		parts := multipart.NewReader(msg.Body, params["boundary"])
+
+		body, err := parts.NextPart()
+		require.NoError(t, err)
+		_, params, err = mime.ParseMediaType(body.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		text, err := multipart.NewReader(body, params["boundary"]).NextPart()
+		require.NoError(t, err)
+		plain, _ := io.ReadAll(text)
+		// booking times are shown in the client's time zone
+		assert.Contains(t, string(plain), "10.03.2025 12:00–13:00 (Europe/Moscow)")
+
+		invite, err := parts.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "invite.ics", invite.FileName())
+		assert.Contains(t, invite.Header.Get("Content-Type"), "method=REQUEST")
+	})
+
+	t.Run("reminder without address", func(t *testing.T) {
+		client.Email = nil
+		t.Cleanup(func() { client.Email = &clientEmail })
+		assert.ErrorIs(t, n.Notify(context.Background(), &entity.Reminder{}, booking), reminder.ErrNoRecipient)
+	})
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/i18n"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"golang.org/x/text/language"
+)
+
+// TelegramNotifier messages masters and clients with a linked Telegram
+// account. It is an outbox sink for booking events and a reminder notifier.
+// Messages are localized to the recipient's language.
+//
+// A failed event is redelivered as a whole, so a recipient who already got
+// the message may get it again; permanent Bot API errors are not retried.
+type TelegramNotifier struct {
+	client      *TelegramClient
+	translator  *i18n.Translator
+	directory   *directory
+	defaultLang language.Tag
+	log         logger.Logger
+}
+
+func NewTelegramNotifier(
+	client *TelegramClient,
+	translator *i18n.Translator,
+	masters repository.MasterRepository,
+	clients repository.ClientRepository,
+	services repository.ServiceRepository,
+	defaultLang language.Tag,
+	log logger.Logger,
+) *TelegramNotifier {
+	return &TelegramNotifier{
+		client:      client,
+		translator:  translator,
+		directory:   &directory{masters: masters, clients: clients, services: services},
+		defaultLang: defaultLang,
+		log:         log,
+	}
+}
+
+func (n *TelegramNotifier) Name() string {
+	return "telegram"
+}
+
+// Handle notifies about new, confirmed and cancelled bookings and about
+// bookings left outside the working hours.
+func (n *TelegramNotifier) Handle(ctx context.Context, e *entity.Event) error {
+	switch e.Type {
+	case entity.EventBookingCreated, entity.EventBookingConfirmed, entity.EventBookingCancelled,
+		entity.EventBookingOutsideHours:
+	default:
+		return nil
+	}
+
+	var b entity.Booking
+	if err := json.Unmarshal(e.Payload, &b); err != nil {
+		return fmt.Errorf("decode booking: %w", err)
+	}
+
+	d, err := n.directory.load(ctx, &b)
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		n.log.Warn("telegram notification dropped", "event_id", e.ID, "error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	master, client := d.Master, d.Client
+	switch e.Type {
+	case entity.EventBookingCreated:
+		err = errors.Join(
+			n.send(ctx, master.TelegramID, master.Language, "notification_booking_created_master",
+				client.Name, d.Service.Name, localTime(b.StartTime, master.Timezone)),
+			n.send(ctx, client.TelegramID, client.Language, "notification_booking_created_client",
+				d.Service.Name, localTime(b.StartTime, client.Timezone)),
+		)
+	case entity.EventBookingConfirmed:
+		err = n.send(ctx, client.TelegramID, client.Language, "notification_booking_confirmed",
+			d.Service.Name, localTime(b.StartTime, client.Timezone))
+	case entity.EventBookingCancelled:
+		err = errors.Join(
+			n.send(ctx, master.TelegramID, master.Language, "notification_booking_cancelled",
+				d.Service.Name, localTime(b.StartTime, master.Timezone)),
+			n.send(ctx, client.TelegramID, client.Language, "notification_booking_cancelled",
+				d.Service.Name, localTime(b.StartTime, client.Timezone)),
+		)
+	case entity.EventBookingOutsideHours:
+		err = n.send(ctx, client.TelegramID, client.Language, "notification_booking_outside_hours",
+			d.Service.Name, localTime(b.StartTime, client.Timezone))
+	}
+	return n.dropPermanent(e, err)
+}
+
+// Notify sends the reminder to the client.
+func (n *TelegramNotifier) Notify(ctx context.Context, _ *entity.Reminder, b *entity.Booking) error {
+	d, err := n.directory.load(ctx, b)
+	if err != nil {
+		return err
+	}
+	if d.Client.TelegramID == nil {
+		return reminder.ErrNoRecipient
+	}
+	return n.send(ctx, d.Client.TelegramID, d.Client.Language, "notification_reminder",
+		d.Service.Name, d.Master.Name, localTime(b.StartTime, d.Client.Timezone))
+}
+
+// send translates the message and sends it, skipping recipients without a
+// linked Telegram account.
+func (n *TelegramNotifier) send(ctx context.Context, chatID *int64, lang, key string, args ...any) error {
+	if chatID == nil {
+		return nil
+	}
+	text := n.translator.Translatef(n.translator.Match(lang, n.defaultLang), key, args...)
+	return n.client.SendMessage(ctx, *chatID, text)
+}
+
+// dropPermanent logs and swallows permanent Bot API errors, which would
+// otherwise hold the event in the outbox forever.
+func (n *TelegramNotifier) dropPermanent(e *entity.Event, err error) error {
+	if err == nil {
+		return nil
+	}
+	if !retryable(err, permanentTelegram) {
+		n.log.Warn("telegram notification dropped", "event_id", e.ID, "error", err)
+		return nil
+	}
+	return err
+}
+
+func permanentTelegram(err error) bool {
+	var tgErr *TelegramError
+	return errors.As(err, &tgErr) && tgErr.Permanent()
+}
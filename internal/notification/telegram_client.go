@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TelegramClient calls the Telegram Bot API. The base URL is configurable
+// so tests can point it at a local server.
+type TelegramClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func NewTelegramClient(baseURL, token string, timeout time.Duration) *TelegramClient {
+	return &TelegramClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// TelegramError is an error returned by the Bot API.
+type TelegramError struct {
+	Code        int
+	Description string
+}
+
+func (e *TelegramError) Error() string {
+	return fmt.Sprintf("telegram: %d %s", e.Code, e.Description)
+}
+
+// Permanent reports whether a retry cannot succeed, e.g. because the user
+// blocked the bot or the chat does not exist.
+func (e *TelegramError) Permanent() bool {
+	return e.Code >= 400 && e.Code < 500 && e.Code != http.StatusTooManyRequests
+}
+
+// SendMessage sends a plain text message to the chat.
+func (c *TelegramClient) SendMessage(ctx context.Context, chatID int64, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", c.baseURL, c.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// the URL contains the token
+		return fmt.Errorf("telegram: send message: %w", stripURL(err))
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return &TelegramError{Code: resp.StatusCode, Description: "invalid response"}
+	}
+	if !result.OK {
+		code := result.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
+		}
+		return &TelegramError{Code: code, Description: result.Description}
+	}
+	return nil
+}
+
+// stripURL drops the request URL from net/http errors.
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
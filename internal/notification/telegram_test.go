@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/i18n"
+	"github.com/curserio/chrono-api/internal/reminder"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+)
+
+// fakeBotAPI records sent messages by chat and rejects the blocked chat.
+type fakeBotAPI struct {
+	mu       sync.Mutex
+	messages map[int64]string
+	blocked  int64
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/bottest-token/sendMessage" {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		ChatID int64  `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if req.ChatID == f.blocked {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+		return
+	}
+
+	f.mu.Lock()
+	f.messages[req.ChatID] = req.Text
+	f.mu.Unlock()
+	_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	translator, err := i18n.NewTranslator("../../locales")
+	require.NoError(t, err)
+
+	masterChat, clientChat := int64(1001), int64(2002)
+	master := &entity.Master{ID: uuid.New(), Name: "Anna", TelegramID: &masterChat, Language: "ru-RU", Timezone: "Europe/Moscow"}
+	client := &entity.Client{ID: uuid.New(), Name: "Bob", TelegramID: &clientChat, Language: "en", Timezone: "UTC"}
+	service := &entity.Service{ID: uuid.New(), Name: "Haircut"}
+	booking := &entity.Booking{
+		ID:        uuid.New(),
+		MasterID:  master.ID,
+		ClientID:  client.ID,
+		ServiceID: service.ID,
+		StartTime: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		Status:    entity.BookingStatusPending,
+	}
+
+	setup := func(t *testing.T, api *fakeBotAPI) *TelegramNotifier {
+		ctrl := gomock.NewController(t)
+		masters := mock.NewMockMasterRepository(ctrl)
+		clients := mock.NewMockClientRepository(ctrl)
+		services := mock.NewMockServiceRepository(ctrl)
+		masters.EXPECT().GetByID(gomock.Any(), master.ID).Return(master, nil).AnyTimes()
+		clients.EXPECT().GetByID(gomock.Any(), client.ID).Return(client, nil).AnyTimes()
+		services.EXPECT().GetByID(gomock.Any(), service.ID).Return(service, nil).AnyTimes()
+
+		server := httptest.NewServer(api)
+		t.Cleanup(server.Close)
+
+		tg := NewTelegramClient(server.URL, "test-token", time.Second)
+		return NewTelegramNotifier(tg, translator, masters, clients, services, language.English, logger.DefaultLogger)
+	}
+
+	t.Run("booking created is localized per recipient", func(t *testing.T) {
+		api := &fakeBotAPI{messages: map[int64]string{}}
+		n := setup(t, api)
+
+		event, err := entity.NewEvent(entity.EventBookingCreated, booking.ID, booking)
+		require.NoError(t, err)
+		require.NoError(t, n.Handle(context.Background(), event))
+
+		assert.Equal(t, "Новая запись: Bob, Haircut на 10.03.2025 12:00", api.messages[masterChat])
+		assert.Equal(t, "Your booking for Haircut on 10.03.2025 09:00 is awaiting confirmation", api.messages[clientChat])
+	})
+
+	t.Run("blocked recipient is not retried", func(t *testing.T) {
+		api := &fakeBotAPI{messages: map[int64]string{}, blocked: masterChat}
+		n := setup(t, api)
+
+		event, err := entity.NewEvent(entity.EventBookingCancelled, booking.ID, booking)
+		require.NoError(t, err)
+		assert.NoError(t, n.Handle(context.Background(), event))
+		assert.Contains(t, api.messages, clientChat)
+	})
+
+	t.Run("reminder", func(t *testing.T) {
+		api := &fakeBotAPI{messages: map[int64]string{}}
+		n := setup(t, api)
+
+		require.NoError(t, n.Notify(context.Background(), &entity.Reminder{}, booking))
+		assert.Equal(t, "Reminder: Haircut with Anna on 10.03.2025 09:00", api.messages[clientChat])
+
+		client.TelegramID = nil
+		t.Cleanup(func() { client.TelegramID = &clientChat })
+		assert.ErrorIs(t, n.Notify(context.Background(), &entity.Reminder{}, booking), reminder.ErrNoRecipient)
+	})
+}
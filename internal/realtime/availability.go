@@ -0,0 +1,270 @@
+package realtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/dto"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+)
+
+// Limits of a single availability connection.
+const (
+	maxSubscriptions = 10
+	maxRangeDays     = 31
+)
+
+// Types of the messages exchanged over an availability connection.
+const (
+	MessageAuth         = "auth"
+	MessageSubscribe    = "subscribe"
+	MessageUnsubscribe  = "unsubscribe"
+	MessagePing         = "ping"
+	MessagePong         = "pong"
+	MessageSubscribed   = "subscribed"
+	MessageUnsubscribed = "unsubscribed"
+	MessageAvailability = "availability"
+	MessageError        = "error"
+)
+
+// ClientMessage is sent by the client. Dates are inclusive and formatted as
+// 2006-01-02.
+type ClientMessage struct {
+	Type     string    `json:"type"`
+	Token    string    `json:"token,omitempty"`
+	MasterID uuid.UUID `json:"master_id,omitempty"`
+	From     string    `json:"from,omitempty"`
+	To       string    `json:"to,omitempty"`
+}
+
+// ServerMessage is sent to the client.
+type ServerMessage struct {
+	Type         string                    `json:"type"`
+	MasterID     *uuid.UUID                `json:"master_id,omitempty"`
+	Availability *dto.AvailabilityResponse `json:"availability,omitempty"`
+	Error        string                    `json:"error,omitempty"`
+}
+
+// Conn is a connection exchanging JSON messages.
+type Conn interface {
+	Receive(v any) error
+	Send(v any) error
+}
+
+type AvailabilityLoader interface {
+	GetAvailability(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*dto.AvailabilityResponse, error)
+}
+
+var errStreamLost = errors.New("stream of changes lost")
+
+// AvailabilitySession serves the subscriptions of one connection. A client
+// subscribes to date ranges of masters and receives their availability right
+// away and then again for every date whose availability changes.
+type AvailabilitySession struct {
+	broker *Broker
+	loader AvailabilityLoader
+	conn   Conn
+
+	organizationID uuid.UUID
+	subs           map[uuid.UUID]*subscription
+	changed        chan uuid.UUID
+	lost           chan struct{}
+}
+
+type subscription struct {
+	from, to    time.Time
+	unsubscribe func()
+	// sent is the last availability sent per date
+	sent map[string][]byte
+}
+
+func NewAvailabilitySession(broker *Broker, loader AvailabilityLoader, conn Conn, organizationID uuid.UUID) *AvailabilitySession {
+	return &AvailabilitySession{
+		broker:         broker,
+		loader:         loader,
+		conn:           conn,
+		organizationID: organizationID,
+		subs:           make(map[uuid.UUID]*subscription),
+		changed:        make(chan uuid.UUID, subscriberBuffer),
+		lost:           make(chan struct{}, 1),
+	}
+}
+
+// Run serves the connection until the client disconnects, the context is
+// cancelled or the changes can no longer be followed. In the last case the
+// client should reconnect.
+func (s *AvailabilitySession) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer func() {
+		for _, sub := range s.subs {
+			sub.unsubscribe()
+		}
+	}()
+
+	requests := make(chan ClientMessage)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			var msg ClientMessage
+			if err := s.conn.Receive(&msg); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case requests <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-readErr:
+			return err
+		case <-s.lost:
+			_ = s.sendError("changes can no longer be followed, reconnect")
+			return errStreamLost
+		case msg := <-requests:
+			err = s.handle(ctx, msg)
+		case masterID := <-s.changed:
+			err = s.refresh(ctx, masterID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *AvailabilitySession) handle(ctx context.Context, msg ClientMessage) error {
+	switch msg.Type {
+	case MessageSubscribe:
+		return s.subscribe(ctx, msg)
+	case MessageUnsubscribe:
+		if sub, ok := s.subs[msg.MasterID]; ok {
+			sub.unsubscribe()
+			delete(s.subs, msg.MasterID)
+		}
+		return s.conn.Send(ServerMessage{Type: MessageUnsubscribed, MasterID: &msg.MasterID})
+	case MessagePing:
+		return s.conn.Send(ServerMessage{Type: MessagePong})
+	default:
+		return s.sendError("unknown message type")
+	}
+}
+
+// subscribe replaces an earlier subscription to the same master.
+func (s *AvailabilitySession) subscribe(ctx context.Context, msg ClientMessage) error {
+	from, err1 := time.Parse(time.DateOnly, msg.From)
+	to, err2 := time.Parse(time.DateOnly, msg.To)
+	switch {
+	case msg.MasterID == uuid.Nil:
+		return s.sendError("master_id is required")
+	case err1 != nil || err2 != nil:
+		return s.sendError("from and to must be dates formatted as YYYY-MM-DD")
+	case to.Before(from) || to.Sub(from) >= maxRangeDays*24*time.Hour:
+		return s.sendError("the range must span 1 to 31 days")
+	}
+
+	sub, ok := s.subs[msg.MasterID]
+	if !ok {
+		if len(s.subs) >= maxSubscriptions {
+			return s.sendError("too many subscriptions")
+		}
+		// follow before loading, so that no change is missed in between
+		sub = &subscription{unsubscribe: s.follow(ctx, msg.MasterID)}
+		s.subs[msg.MasterID] = sub
+	}
+	sub.from, sub.to = from, to
+	sub.sent = make(map[string][]byte)
+
+	availability, err := s.loader.GetAvailability(ctx, msg.MasterID, from, to)
+	if err != nil {
+		sub.unsubscribe()
+		delete(s.subs, msg.MasterID)
+		if errors.Is(err, apiErrors.ErrNotFound) {
+			return s.sendError("master not found")
+		}
+		return s.sendError("failed to get availability")
+	}
+
+	if err := s.conn.Send(ServerMessage{Type: MessageSubscribed, MasterID: &msg.MasterID}); err != nil {
+		return err
+	}
+	return s.send(sub, availability)
+}
+
+// follow passes the changes of the master to the session until unsubscribed.
+func (s *AvailabilitySession) follow(ctx context.Context, masterID uuid.UUID) func() {
+	messages, unsubscribe := s.broker.Subscribe(s.organizationID, masterID)
+	done := make(chan struct{})
+	go func() {
+		for range messages {
+			select {
+			case s.changed <- masterID:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-done:
+		case <-ctx.Done():
+		default:
+			// dropped by the broker
+			select {
+			case s.lost <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		unsubscribe()
+	}
+}
+
+// refresh sends the availability of the subscribed dates of the master that
+// changed since they were last sent.
+func (s *AvailabilitySession) refresh(ctx context.Context, masterID uuid.UUID) error {
+	sub, ok := s.subs[masterID]
+	if !ok {
+		// a change that arrived after unsubscribing
+		return nil
+	}
+
+	availability, err := s.loader.GetAvailability(ctx, masterID, sub.from, sub.to)
+	if err != nil {
+		return s.sendError("failed to get availability")
+	}
+	return s.send(sub, availability)
+}
+
+func (s *AvailabilitySession) send(sub *subscription, availability []*dto.AvailabilityResponse) error {
+	for _, a := range availability {
+		data, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(sub.sent[a.Date], data) {
+			continue
+		}
+		sub.sent[a.Date] = data
+
+		if err := s.conn.Send(ServerMessage{Type: MessageAvailability, MasterID: &a.MasterID, Availability: a}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AvailabilitySession) sendError(msg string) error {
+	return s.conn.Send(ServerMessage{Type: MessageError, Error: msg})
+}
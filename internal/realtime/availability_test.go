@@ -0,0 +1,106 @@
+package realtime
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/dto"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type chanConn struct {
+	in  chan ClientMessage
+	out chan ServerMessage
+}
+
+func (c *chanConn) Receive(v any) error {
+	msg, ok := <-c.in
+	if !ok {
+		return io.EOF
+	}
+	*v.(*ClientMessage) = msg
+	return nil
+}
+
+func (c *chanConn) Send(v any) error {
+	c.out <- v.(ServerMessage)
+	return nil
+}
+
+// bookedLoader reports the booked periods per date.
+type bookedLoader struct {
+	mu       sync.Mutex
+	masterID uuid.UUID
+	booked   map[string][]dto.Period
+}
+
+func (l *bookedLoader) GetAvailability(_ context.Context, masterID uuid.UUID, from, to time.Time) ([]*dto.AvailabilityResponse, error) {
+	if masterID != l.masterID {
+		return nil, apiErrors.ErrNotFound
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []*dto.AvailabilityResponse
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format(time.DateOnly)
+		out = append(out, &dto.AvailabilityResponse{MasterID: masterID, Date: key, Booked: l.booked[key]})
+	}
+	return out, nil
+}
+
+func TestAvailabilitySession(t *testing.T) {
+	orgID, masterID := uuid.New(), uuid.New()
+	broker := NewBroker()
+	loader := &bookedLoader{masterID: masterID, booked: map[string][]dto.Period{}}
+	conn := &chanConn{in: make(chan ClientMessage), out: make(chan ServerMessage, 10)}
+
+	done := make(chan error)
+	go func() {
+		done <- NewAvailabilitySession(broker, loader, conn, orgID).Run(context.Background())
+	}()
+
+	conn.in <- ClientMessage{Type: MessageSubscribe, MasterID: masterID, From: "2025-03-10", To: "2025-03-11"}
+	assert.Equal(t, MessageSubscribed, (<-conn.out).Type)
+	assert.Equal(t, "2025-03-10", (<-conn.out).Availability.Date)
+	assert.Equal(t, "2025-03-11", (<-conn.out).Availability.Date)
+
+	t.Run("only changed dates are pushed", func(t *testing.T) {
+		start := time.Date(2025, 3, 11, 10, 0, 0, 0, time.UTC)
+		loader.mu.Lock()
+		loader.booked["2025-03-11"] = []dto.Period{{StartTime: start, EndTime: start.Add(time.Hour)}}
+		loader.mu.Unlock()
+
+		broker.Publish(Message{OrganizationID: orgID, MasterID: masterID})
+
+		msg := <-conn.out
+		assert.Equal(t, MessageAvailability, msg.Type)
+		assert.Equal(t, "2025-03-11", msg.Availability.Date)
+		assert.Len(t, msg.Availability.Booked, 1)
+
+		conn.in <- ClientMessage{Type: MessagePing}
+		assert.Equal(t, MessagePong, (<-conn.out).Type, "nothing else was pushed")
+	})
+
+	t.Run("unknown master", func(t *testing.T) {
+		conn.in <- ClientMessage{Type: MessageSubscribe, MasterID: uuid.New(), From: "2025-03-10", To: "2025-03-10"}
+
+		msg := <-conn.out
+		assert.Equal(t, MessageError, msg.Type)
+		assert.Equal(t, "master not found", msg.Error)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		conn.in <- ClientMessage{Type: MessageSubscribe, MasterID: masterID, From: "2025-03-10", To: "2025-05-10"}
+
+		assert.Equal(t, MessageError, (<-conn.out).Type)
+	})
+
+	close(conn.in)
+	assert.ErrorIs(t, <-done, io.EOF)
+}
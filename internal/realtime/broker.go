@@ -0,0 +1,107 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is the number of messages a subscriber may fall behind
+// before it is dropped.
+const subscriberBuffer = 32
+
+// Message is a change concerning a master, broadcast to all API instances.
+type Message struct {
+	ID             uuid.UUID        `json:"id"`
+	OrganizationID uuid.UUID        `json:"organization_id"`
+	MasterID       uuid.UUID        `json:"master_id"`
+	Type           entity.EventType `json:"type"`
+	AggregateID    uuid.UUID        `json:"aggregate_id"`
+	Payload        json.RawMessage  `json:"payload,omitempty"`
+	OccurredAt     time.Time        `json:"occurred_at"`
+}
+
+type topic struct {
+	organizationID uuid.UUID
+	masterID       uuid.UUID
+}
+
+// Broker fans messages out to the subscribers of this instance.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[topic]map[chan Message]struct{}
+	closed bool
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[topic]map[chan Message]struct{})}
+}
+
+// Subscribe returns the messages of the master until unsubscribe is called.
+// The channel is closed if the subscriber falls behind or the broker shuts
+// down; the subscriber should then reconnect and reload its state.
+func (b *Broker) Subscribe(organizationID, masterID uuid.UUID) (<-chan Message, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Message, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	t := topic{organizationID: organizationID, masterID: masterID}
+	if b.subs[t] == nil {
+		b.subs[t] = make(map[chan Message]struct{})
+	}
+	b.subs[t][ch] = struct{}{}
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(t, ch)
+	}
+}
+
+// Publish delivers the message to the subscribers of its master without
+// blocking.
+func (b *Broker) Publish(msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := topic{organizationID: msg.OrganizationID, masterID: msg.MasterID}
+	for ch := range b.subs[t] {
+		select {
+		case ch <- msg:
+		default:
+			b.remove(t, ch)
+		}
+	}
+}
+
+// Close ends all subscriptions, so that open streams finish on shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for t, chans := range b.subs {
+		for ch := range chans {
+			b.remove(t, ch)
+		}
+	}
+	b.closed = true
+}
+
+func (b *Broker) remove(t topic, ch chan Message) {
+	if _, ok := b.subs[t][ch]; !ok {
+		return
+	}
+	delete(b.subs[t], ch)
+	if len(b.subs[t]) == 0 {
+		delete(b.subs, t)
+	}
+	close(ch)
+}
@@ -0,0 +1,71 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type funcNotifier func(channel string, payload []byte)
+
+func (f funcNotifier) Publish(_ context.Context, channel string, payload []byte) error {
+	f(channel, payload)
+	return nil
+}
+
+func TestPublisher_Broker(t *testing.T) {
+	orgID, masterID := uuid.New(), uuid.New()
+	broker := NewBroker()
+	publisher := NewPublisher(funcNotifier(func(channel string, payload []byte) {
+		assert.Equal(t, Channel, channel)
+		var msg Message
+		assert.NoError(t, json.Unmarshal(payload, &msg))
+		broker.Publish(msg)
+	}))
+
+	mine, unsubscribe := broker.Subscribe(orgID, masterID)
+	defer unsubscribe()
+	other, unsubscribeOther := broker.Subscribe(uuid.New(), masterID)
+	defer unsubscribeOther()
+
+	booking := &entity.Booking{ID: uuid.New(), MasterID: masterID}
+	payload, _ := json.Marshal(booking)
+	err := publisher.Handle(context.Background(), &entity.Event{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Type:           entity.EventBookingCreated,
+		AggregateID:    booking.ID,
+		Payload:        payload,
+	})
+	assert.NoError(t, err)
+
+	msg := <-mine
+	assert.Equal(t, entity.EventBookingCreated, msg.Type)
+	assert.Equal(t, booking.ID, msg.AggregateID)
+	assert.Len(t, other, 0, "other organizations do not see the event")
+
+	t.Run("events without a master are ignored", func(t *testing.T) {
+		err := publisher.Handle(context.Background(), &entity.Event{OrganizationID: orgID, Payload: []byte(`{}`)})
+		assert.NoError(t, err)
+		assert.Len(t, mine, 0)
+	})
+
+	t.Run("slow subscribers are dropped", func(t *testing.T) {
+		slow, unsubscribe := broker.Subscribe(orgID, masterID)
+		defer unsubscribe()
+
+		for range subscriberBuffer + 1 {
+			broker.Publish(Message{OrganizationID: orgID, MasterID: masterID})
+		}
+
+		n := 0
+		for range slow {
+			n++
+		}
+		assert.Equal(t, subscriberBuffer, n)
+	})
+}
@@ -0,0 +1,59 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// reconnectDelay is the wait before listening again after a failure.
+const reconnectDelay = 5 * time.Second
+
+type Subscriber interface {
+	Listen(ctx context.Context, channel string, handle func(payload []byte)) error
+}
+
+// Listener passes the messages broadcast by any API instance to the local
+// broker.
+type Listener struct {
+	sub    Subscriber
+	broker *Broker
+	log    logger.Logger
+}
+
+func NewListener(sub Subscriber, broker *Broker, log logger.Logger) *Listener {
+	return &Listener{
+		sub:    sub,
+		broker: broker,
+		log:    log,
+	}
+}
+
+// Run listens until the context is cancelled, reconnecting on failures.
+// Messages sent while reconnecting are lost.
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		err := l.sub.Listen(ctx, Channel, l.handle)
+		if ctx.Err() != nil {
+			return
+		}
+		l.log.Error("realtime listener failed", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) handle(payload []byte) {
+	var msg Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		l.log.Error("invalid realtime message", "error", err)
+		return
+	}
+	l.broker.Publish(msg)
+}
@@ -0,0 +1,71 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// Channel is the notification channel shared by all API instances.
+const Channel = "chrono_events"
+
+// Notifications are limited to 8000 bytes; larger payloads are left out and
+// subscribers reload the aggregate instead.
+const maxNotification = 7900
+
+type Notifier interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+// Publisher is the outbox sink broadcasting the events of masters to all
+// API instances.
+type Publisher struct {
+	notifier Notifier
+}
+
+func NewPublisher(notifier Notifier) *Publisher {
+	return &Publisher{notifier: notifier}
+}
+
+func (p *Publisher) Name() string {
+	return "realtime"
+}
+
+// Handle broadcasts events whose payload names a master, i.e. bookings and
+// schedules. Other events are ignored.
+func (p *Publisher) Handle(ctx context.Context, e *entity.Event) error {
+	var target struct {
+		MasterID uuid.UUID `json:"master_id"`
+	}
+	if err := json.Unmarshal(e.Payload, &target); err != nil || target.MasterID == uuid.Nil {
+		return nil
+	}
+
+	msg := Message{
+		ID:             e.ID,
+		OrganizationID: e.OrganizationID,
+		MasterID:       target.MasterID,
+		Type:           e.Type,
+		AggregateID:    e.AggregateID,
+		Payload:        e.Payload,
+		OccurredAt:     e.OccurredAt,
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal realtime message: %w", err)
+	}
+	if len(data) > maxNotification {
+		msg.Payload = nil
+		if data, err = json.Marshal(msg); err != nil {
+			return fmt.Errorf("marshal realtime message: %w", err)
+		}
+	}
+
+	if err := p.notifier.Publish(ctx, Channel, data); err != nil {
+		return fmt.Errorf("publish realtime message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// ErrNoRecipient is returned by a notifier that cannot reach the client,
+// e.g. because no Telegram account is linked. It is not retried.
+var ErrNoRecipient = errors.New("no recipient")
+
+// Notifier delivers a reminder to the client of the booking, e.g. by
+// Telegram or email. The context carries the booking's organization.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, r *entity.Reminder, b *entity.Booking) error
+}
+
+// LogNotifier writes reminders to the log. It is useful in development.
+type LogNotifier struct {
+	log logger.Logger
+}
+
+func NewLogNotifier(log logger.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Name() string {
+	return "log"
+}
+
+func (n *LogNotifier) Notify(_ context.Context, r *entity.Reminder, b *entity.Booking) error {
+	n.log.Info("booking reminder",
+		"reminder_id", r.ID,
+		"booking_id", b.ID,
+		"client_id", b.ClientID,
+		"start_time", b.StartTime,
+		"offset", r.Offset,
+	)
+	return nil
+}
@@ -0,0 +1,85 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+)
+
+// Planner is the outbox sink that keeps reminders in line with bookings.
+// It reloads the booking instead of trusting the event payload, so replayed
+// or reordered events converge on the booking's current state.
+type Planner struct {
+	bookings  repository.BookingRepository
+	reminders repository.ReminderRepository
+	tx        repository.TxManager
+	offsets   []time.Duration
+}
+
+func NewPlanner(bookings repository.BookingRepository, reminders repository.ReminderRepository, tx repository.TxManager, offsets []time.Duration) *Planner {
+	return &Planner{
+		bookings:  bookings,
+		reminders: reminders,
+		tx:        tx,
+		offsets:   offsets,
+	}
+}
+
+func (p *Planner) Name() string {
+	return "reminder"
+}
+
+func (p *Planner) Handle(ctx context.Context, e *entity.Event) error {
+	switch e.Type {
+	case entity.EventBookingConfirmed, entity.EventBookingRescheduled,
+		entity.EventBookingCancelled, entity.EventBookingCompleted:
+	default:
+		return nil
+	}
+
+	b, err := p.bookings.GetByID(ctx, e.AggregateID)
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		// deleted bookings take their reminders with them
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get booking: %w", err)
+	}
+
+	return p.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return p.plan(ctx, b, time.Now())
+	})
+}
+
+// plan schedules a reminder per offset for confirmed bookings and cancels
+// the pending ones otherwise. Offsets that are already past are skipped
+// rather than sent late.
+func (p *Planner) plan(ctx context.Context, b *entity.Booking, now time.Time) error {
+	keep := make([]time.Duration, 0, len(p.offsets))
+	if b.Status == entity.BookingStatusConfirmed {
+		for _, offset := range p.offsets {
+			sendAt := b.StartTime.Add(-offset)
+			if !sendAt.After(now) {
+				continue
+			}
+			if err := p.reminders.Upsert(ctx, &entity.Reminder{
+				BookingID: b.ID,
+				Offset:    offset,
+				SendAt:    sendAt,
+			}); err != nil {
+				return fmt.Errorf("plan reminder: %w", err)
+			}
+			keep = append(keep, offset)
+		}
+	}
+
+	if err := p.reminders.CancelPending(ctx, b.ID, keep); err != nil {
+		return fmt.Errorf("cancel reminders: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package reminder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanner_plan(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{24 * time.Hour, 2 * time.Hour}
+
+	t.Run("confirmed booking skips past offsets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock.NewMockReminderRepository(ctrl)
+		p := NewPlanner(nil, repo, nil, offsets)
+
+		b := &entity.Booking{ID: uuid.New(), Status: entity.BookingStatusConfirmed, StartTime: now.Add(5 * time.Hour)}
+
+		repo.EXPECT().Upsert(gomock.Any(), &entity.Reminder{
+			BookingID: b.ID,
+			Offset:    2 * time.Hour,
+			SendAt:    now.Add(3 * time.Hour),
+		}).Return(nil)
+		repo.EXPECT().CancelPending(gomock.Any(), b.ID, []time.Duration{2 * time.Hour}).Return(nil)
+
+		assert.NoError(t, p.plan(context.Background(), b, now))
+	})
+
+	t.Run("cancelled booking cancels all", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock.NewMockReminderRepository(ctrl)
+		p := NewPlanner(nil, repo, nil, offsets)
+
+		b := &entity.Booking{ID: uuid.New(), Status: entity.BookingStatusCancelled, StartTime: now.Add(48 * time.Hour)}
+
+		repo.EXPECT().CancelPending(gomock.Any(), b.ID, []time.Duration{}).Return(nil)
+
+		assert.NoError(t, p.plan(context.Background(), b, now))
+	})
+}
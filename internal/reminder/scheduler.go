@@ -0,0 +1,149 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+// staleAfter must exceed the time the notifiers need for a batch
+const staleAfter = 10 * time.Minute
+
+// Scheduler sends due reminders through the notifiers. A reminder counts as
+// sent once any notifier accepts it. Only reminders no notifier accepted are
+// retried, and reminders interrupted mid-send are failed instead, so clients
+// never get the same reminder twice.
+type Scheduler struct {
+	repo        repository.ReminderRepository
+	notifiers   []Notifier
+	log         logger.Logger
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewScheduler(repo repository.ReminderRepository, notifiers []Notifier, log logger.Logger, cfg config.ReminderConfig) *Scheduler {
+	return &Scheduler{
+		repo:        repo,
+		notifiers:   notifiers,
+		log:         log,
+		interval:    cfg.PollInterval,
+		batchSize:   cfg.BatchSize,
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     cfg.RetryBackoff,
+	}
+}
+
+// Run sends reminders until the context is cancelled. Without notifiers it
+// returns at once, leaving reminders pending.
+func (s *Scheduler) Run(ctx context.Context) {
+	if len(s.notifiers) == 0 {
+		s.log.Warn("no reminder notifiers configured, reminders are not sent")
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := s.SendBatch(ctx)
+			if err != nil {
+				s.log.Error("failed to send reminders", "error", err)
+			}
+			if err != nil || n < s.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendBatch sends one batch of due reminders and returns its size.
+func (s *Scheduler) SendBatch(ctx context.Context) (int, error) {
+	stale, err := s.repo.FailStale(ctx, time.Now().Add(-staleAfter))
+	if err != nil {
+		return 0, fmt.Errorf("fail stale reminders: %w", err)
+	}
+	if stale > 0 {
+		s.log.Warn("reminders interrupted while sending", "count", stale)
+	}
+
+	dispatches, err := s.repo.Claim(ctx, s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim reminders: %w", err)
+	}
+
+	for _, d := range dispatches {
+		if err := s.send(ctx, d); err != nil {
+			return len(dispatches), err
+		}
+	}
+	return len(dispatches), nil
+}
+
+func (s *Scheduler) send(ctx context.Context, d *entity.ReminderDispatch) error {
+	notifyCtx := tenant.WithOrganizationID(ctx, d.Reminder.OrganizationID)
+
+	var errs []error
+	delivered, retryable := 0, false
+	for _, n := range s.notifiers {
+		err := n.Notify(notifyCtx, d.Reminder, d.Booking)
+		switch {
+		case err == nil:
+			delivered++
+			continue
+		case !errors.Is(err, ErrNoRecipient):
+			retryable = true
+		}
+		errs = append(errs, fmt.Errorf("notifier %s: %w", n.Name(), err))
+	}
+	sendErr := errors.Join(errs...)
+
+	if delivered > 0 {
+		if sendErr != nil {
+			s.log.Warn("reminder partially sent", "reminder_id", d.Reminder.ID, "error", sendErr)
+		}
+		if err := s.repo.MarkSent(ctx, d.Reminder.ID); err != nil {
+			return fmt.Errorf("mark reminder sent: %w", err)
+		}
+		return nil
+	}
+
+	// nothing went out, so a retry cannot duplicate the reminder
+	var retryAt *time.Time
+	next := time.Now().Add(s.retryDelay(d.Reminder.Attempts))
+	if retryable && d.Reminder.Attempts < s.maxAttempts && next.Before(d.Booking.StartTime) {
+		retryAt = &next
+	} else {
+		s.log.Warn("reminder failed",
+			"reminder_id", d.Reminder.ID, "booking_id", d.Booking.ID, "attempts", d.Reminder.Attempts, "error", sendErr)
+	}
+	if err := s.repo.MarkFailed(ctx, d.Reminder.ID, sendErr.Error(), retryAt); err != nil {
+		return fmt.Errorf("mark reminder failed: %w", err)
+	}
+	return nil
+}
+
+// retryDelay doubles the backoff with every failed attempt. Attempts
+// includes the current one.
+func (s *Scheduler) retryDelay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
@@ -0,0 +1,69 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type funcNotifier func(r *entity.Reminder) error
+
+func (f funcNotifier) Name() string { return "test" }
+
+func (f funcNotifier) Notify(_ context.Context, r *entity.Reminder, _ *entity.Booking) error {
+	return f(r)
+}
+
+func TestScheduler_SendBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockReminderRepository(ctrl)
+	ctx := context.Background()
+
+	booking := &entity.Booking{ID: uuid.New(), StartTime: time.Now().Add(24 * time.Hour)}
+	sent := &entity.Reminder{ID: uuid.New(), Attempts: 1}
+	failing := &entity.Reminder{ID: uuid.New(), Attempts: 2}
+	exhausted := &entity.Reminder{ID: uuid.New(), Attempts: 3}
+
+	ok := funcNotifier(func(r *entity.Reminder) error {
+		if r.ID == sent.ID {
+			return nil
+		}
+		return errors.New("unavailable")
+	})
+	down := funcNotifier(func(*entity.Reminder) error { return errors.New("down") })
+
+	s := NewScheduler(repo, []Notifier{ok, down}, logger.DefaultLogger, config.ReminderConfig{
+		BatchSize:    10,
+		MaxAttempts:  3,
+		RetryBackoff: time.Minute,
+	})
+
+	repo.EXPECT().FailStale(ctx, gomock.Any()).Return(int64(0), nil)
+	repo.EXPECT().Claim(ctx, 10).Return([]*entity.ReminderDispatch{
+		{Reminder: sent, Booking: booking},
+		{Reminder: failing, Booking: booking},
+		{Reminder: exhausted, Booking: booking},
+	}, nil)
+	// one notifier is enough, retrying would duplicate the reminder
+	repo.EXPECT().MarkSent(ctx, sent.ID).Return(nil)
+	repo.EXPECT().MarkFailed(ctx, failing.ID, gomock.Any(), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, retryAt *time.Time) error {
+			// second attempt waits 2x the base backoff
+			assert.WithinDuration(t, time.Now().Add(2*time.Minute), *retryAt, time.Second)
+			return nil
+		})
+	repo.EXPECT().MarkFailed(ctx, exhausted.ID, gomock.Any(), gomock.Nil()).Return(nil)
+
+	n, err := s.SendBatch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
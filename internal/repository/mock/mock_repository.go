@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/curserio/chrono-api/internal/repository (interfaces: MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository)
+// Source: github.com/curserio/chrono-api/internal/repository (interfaces: TxManager,OrganizationRepository,AdminRepository,RefreshTokenRepository,APIKeyRepository,MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository,OutboxRepository,WebhookRepository,WebhookDeliveryRepository,ReminderRepository,CalendarFeedRepository,BusyBlockRepository,CalendarSourceRepository,CalDAVAccountRepository,TimeOffRepository)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock/mock_repository.go -package=mock github.com/curserio/chrono-api/internal/repository MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository
+//	mockgen -destination=mock/mock_repository.go -package=mock github.com/curserio/chrono-api/internal/repository TxManager,OrganizationRepository,AdminRepository,RefreshTokenRepository,APIKeyRepository,MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository,OutboxRepository,WebhookRepository,WebhookDeliveryRepository,ReminderRepository,CalendarFeedRepository,BusyBlockRepository,CalendarSourceRepository,CalDAVAccountRepository,TimeOffRepository
 //
 
 // Package mock is a generated GoMock package.
@@ -19,6 +19,356 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTxManagerMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTxManager)(nil).WithinTx), ctx, fn)
+}
+
+// MockOrganizationRepository is a mock of OrganizationRepository interface.
+type MockOrganizationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationRepositoryMockRecorder is the mock recorder for MockOrganizationRepository.
+type MockOrganizationRepositoryMockRecorder struct {
+	mock *MockOrganizationRepository
+}
+
+// NewMockOrganizationRepository creates a new mock instance.
+func NewMockOrganizationRepository(ctrl *gomock.Controller) *MockOrganizationRepository {
+	mock := &MockOrganizationRepository{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepository) EXPECT() *MockOrganizationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *entity.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationRepositoryMockRecorder) Create(ctx, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationRepository)(nil).Create), ctx, org)
+}
+
+// GetByID mocks base method.
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrganizationRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrganizationRepository)(nil).GetByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockOrganizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, org)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrganizationRepositoryMockRecorder) Update(ctx, org any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrganizationRepository)(nil).Update), ctx, org)
+}
+
+// MockAdminRepository is a mock of AdminRepository interface.
+type MockAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminRepositoryMockRecorder is the mock recorder for MockAdminRepository.
+type MockAdminRepositoryMockRecorder struct {
+	mock *MockAdminRepository
+}
+
+// NewMockAdminRepository creates a new mock instance.
+func NewMockAdminRepository(ctrl *gomock.Controller) *MockAdminRepository {
+	mock := &MockAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRepository) EXPECT() *MockAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAdminRepository) Create(ctx context.Context, admin *entity.Admin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, admin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAdminRepositoryMockRecorder) Create(ctx, admin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAdminRepository)(nil).Create), ctx, admin)
+}
+
+// GetByEmail mocks base method.
+func (m *MockAdminRepository) GetByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockAdminRepositoryMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockAdminRepository)(nil).GetByEmail), ctx, email)
+}
+
+// GetByID mocks base method.
+func (m *MockAdminRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAdminRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAdminRepository)(nil).GetByID), ctx, id)
+}
+
+// MockRefreshTokenRepository is a mock of RefreshTokenRepository interface.
+type MockRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenRepositoryMockRecorder is the mock recorder for MockRefreshTokenRepository.
+type MockRefreshTokenRepositoryMockRecorder struct {
+	mock *MockRefreshTokenRepository
+}
+
+// NewMockRefreshTokenRepository creates a new mock instance.
+func NewMockRefreshTokenRepository(ctrl *gomock.Controller) *MockRefreshTokenRepository {
+	mock := &MockRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenRepository) EXPECT() *MockRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByHash mocks base method.
+func (m *MockRefreshTokenRepository) GetByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockRefreshTokenRepositoryMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockRefreshTokenRepository)(nil).GetByHash), ctx, hash)
+}
+
+// Revoke mocks base method.
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRefreshTokenRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Revoke), ctx, id)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockRefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockRefreshTokenRepository)(nil).RevokeFamily), ctx, familyID)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepository)(nil).Create), ctx, key)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetByHash), ctx, hash)
+}
+
+// List mocks base method.
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIKeyRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIKeyRepository)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepository)(nil).Revoke), ctx, id)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockAPIKeyRepositoryMockRecorder) TouchLastUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockAPIKeyRepository)(nil).TouchLastUsed), ctx, id)
+}
+
 // MockMasterRepository is a mock of MasterRepository interface.
 type MockMasterRepository struct {
 	ctrl     *gomock.Controller
@@ -86,6 +436,21 @@ func (mr *MockMasterRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMasterRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByTelegramID mocks base method.
+func (m *MockMasterRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Master, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTelegramID", ctx, telegramID)
+	ret0, _ := ret[0].(*entity.Master)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTelegramID indicates an expected call of GetByTelegramID.
+func (mr *MockMasterRepositoryMockRecorder) GetByTelegramID(ctx, telegramID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTelegramID", reflect.TypeOf((*MockMasterRepository)(nil).GetByTelegramID), ctx, telegramID)
+}
+
 // List mocks base method.
 func (m *MockMasterRepository) List(ctx context.Context, offset, limit int) ([]*entity.Master, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +574,20 @@ func (mr *MockScheduleRepositoryMockRecorder) DeleteDay(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDay", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteDay), ctx, id)
 }
 
+// DeleteDaysByScheduleID mocks base method.
+func (m *MockScheduleRepository) DeleteDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDaysByScheduleID", ctx, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDaysByScheduleID indicates an expected call of DeleteDaysByScheduleID.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteDaysByScheduleID(ctx, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDaysByScheduleID", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteDaysByScheduleID), ctx, scheduleID)
+}
+
 // DeleteSlot mocks base method.
 func (m *MockScheduleRepository) DeleteSlot(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -223,6 +602,20 @@ func (mr *MockScheduleRepositoryMockRecorder) DeleteSlot(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteSlot), ctx, id)
 }
 
+// DeleteSlotsInRange mocks base method.
+func (m *MockScheduleRepository) DeleteSlotsInRange(ctx context.Context, scheduleID uuid.UUID, from, to time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlotsInRange", ctx, scheduleID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlotsInRange indicates an expected call of DeleteSlotsInRange.
+func (mr *MockScheduleRepositoryMockRecorder) DeleteSlotsInRange(ctx, scheduleID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlotsInRange", reflect.TypeOf((*MockScheduleRepository)(nil).DeleteSlotsInRange), ctx, scheduleID, from, to)
+}
+
 // GetByID mocks base method.
 func (m *MockScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +661,21 @@ func (mr *MockScheduleRepositoryMockRecorder) GetDayByID(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDayByID", reflect.TypeOf((*MockScheduleRepository)(nil).GetDayByID), ctx, id)
 }
 
+// GetDaysByDayIndex mocks base method.
+func (m *MockScheduleRepository) GetDaysByDayIndex(ctx context.Context, scheduleID uuid.UUID, dayIndex int) ([]*entity.ScheduleDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDaysByDayIndex", ctx, scheduleID, dayIndex)
+	ret0, _ := ret[0].([]*entity.ScheduleDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDaysByDayIndex indicates an expected call of GetDaysByDayIndex.
+func (mr *MockScheduleRepositoryMockRecorder) GetDaysByDayIndex(ctx, scheduleID, dayIndex any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaysByDayIndex", reflect.TypeOf((*MockScheduleRepository)(nil).GetDaysByDayIndex), ctx, scheduleID, dayIndex)
+}
+
 // GetDaysByScheduleID mocks base method.
 func (m *MockScheduleRepository) GetDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]*entity.ScheduleDay, error) {
 	m.ctrl.T.Helper()
@@ -284,40 +692,100 @@ func (mr *MockScheduleRepositoryMockRecorder) GetDaysByScheduleID(ctx, scheduleI
 }
 
 // GetDaysByWeekday mocks base method.
-func (m *MockScheduleRepository) GetDaysByWeekday(ctx context.Context, masterID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
+func (m *MockScheduleRepository) GetDaysByWeekday(ctx context.Context, scheduleID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDaysByWeekday", ctx, masterID, weekday)
+	ret := m.ctrl.Call(m, "GetDaysByWeekday", ctx, scheduleID, weekday)
 	ret0, _ := ret[0].([]*entity.ScheduleDay)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDaysByWeekday indicates an expected call of GetDaysByWeekday.
-func (mr *MockScheduleRepositoryMockRecorder) GetDaysByWeekday(ctx, masterID, weekday any) *gomock.Call {
+func (mr *MockScheduleRepositoryMockRecorder) GetDaysByWeekday(ctx, scheduleID, weekday any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaysByWeekday", reflect.TypeOf((*MockScheduleRepository)(nil).GetDaysByWeekday), ctx, masterID, weekday)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaysByWeekday", reflect.TypeOf((*MockScheduleRepository)(nil).GetDaysByWeekday), ctx, scheduleID, weekday)
 }
 
-// GetSlotByID mocks base method.
-func (m *MockScheduleRepository) GetSlotByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error) {
+// GetDaysCount mocks base method.
+func (m *MockScheduleRepository) GetDaysCount(ctx context.Context, scheduleID uuid.UUID) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSlotByID", ctx, id)
-	ret0, _ := ret[0].(*entity.ScheduleSlot)
+	ret := m.ctrl.Call(m, "GetDaysCount", ctx, scheduleID)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSlotByID indicates an expected call of GetSlotByID.
-func (mr *MockScheduleRepositoryMockRecorder) GetSlotByID(ctx, id any) *gomock.Call {
+// GetDaysCount indicates an expected call of GetDaysCount.
+func (mr *MockScheduleRepositoryMockRecorder) GetDaysCount(ctx, scheduleID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotByID", reflect.TypeOf((*MockScheduleRepository)(nil).GetSlotByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaysCount", reflect.TypeOf((*MockScheduleRepository)(nil).GetDaysCount), ctx, scheduleID)
 }
 
-// GetSlotsByDate mocks base method.
-func (m *MockScheduleRepository) GetSlotsByDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.ScheduleSlot, error) {
+// GetDetails mocks base method.
+func (m *MockScheduleRepository) GetDetails(ctx context.Context, id uuid.UUID, from, to *time.Time) (*entity.ScheduleDetails, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSlotsByDate", ctx, masterID, date)
-	ret0, _ := ret[0].([]*entity.ScheduleSlot)
+	ret := m.ctrl.Call(m, "GetDetails", ctx, id, from, to)
+	ret0, _ := ret[0].(*entity.ScheduleDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetails indicates an expected call of GetDetails.
+func (mr *MockScheduleRepositoryMockRecorder) GetDetails(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetails", reflect.TypeOf((*MockScheduleRepository)(nil).GetDetails), ctx, id, from, to)
+}
+
+// GetForDate mocks base method.
+func (m *MockScheduleRepository) GetForDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForDate", ctx, masterID, date)
+	ret0, _ := ret[0].([]*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForDate indicates an expected call of GetForDate.
+func (mr *MockScheduleRepositoryMockRecorder) GetForDate(ctx, masterID, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForDate", reflect.TypeOf((*MockScheduleRepository)(nil).GetForDate), ctx, masterID, date)
+}
+
+// GetOverlapping mocks base method.
+func (m *MockScheduleRepository) GetOverlapping(ctx context.Context, schedule *entity.Schedule) ([]*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlapping", ctx, schedule)
+	ret0, _ := ret[0].([]*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlapping indicates an expected call of GetOverlapping.
+func (mr *MockScheduleRepositoryMockRecorder) GetOverlapping(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlapping", reflect.TypeOf((*MockScheduleRepository)(nil).GetOverlapping), ctx, schedule)
+}
+
+// GetSlotByID mocks base method.
+func (m *MockScheduleRepository) GetSlotByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotByID", ctx, id)
+	ret0, _ := ret[0].(*entity.ScheduleSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotByID indicates an expected call of GetSlotByID.
+func (mr *MockScheduleRepositoryMockRecorder) GetSlotByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotByID", reflect.TypeOf((*MockScheduleRepository)(nil).GetSlotByID), ctx, id)
+}
+
+// GetSlotsByDate mocks base method.
+func (m *MockScheduleRepository) GetSlotsByDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.ScheduleSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotsByDate", ctx, masterID, date)
+	ret0, _ := ret[0].([]*entity.ScheduleSlot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -358,6 +826,21 @@ func (mr *MockScheduleRepositoryMockRecorder) List(ctx, offset, limit any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScheduleRepository)(nil).List), ctx, offset, limit)
 }
 
+// ListTemplates mocks base method.
+func (m *MockScheduleRepository) ListTemplates(ctx context.Context, offset, limit int) ([]*entity.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, offset, limit)
+	ret0, _ := ret[0].([]*entity.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockScheduleRepositoryMockRecorder) ListTemplates(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockScheduleRepository)(nil).ListTemplates), ctx, offset, limit)
+}
+
 // Update mocks base method.
 func (m *MockScheduleRepository) Update(ctx context.Context, schedule *entity.Schedule) error {
 	m.ctrl.T.Helper()
@@ -593,6 +1076,21 @@ func (mr *MockBookingRepositoryMockRecorder) GetByMasterID(ctx, masterID, from,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMasterID", reflect.TypeOf((*MockBookingRepository)(nil).GetByMasterID), ctx, masterID, from, to)
 }
 
+// Revenue mocks base method.
+func (m *MockBookingRepository) Revenue(ctx context.Context, q entity.RevenueQuery) ([]*entity.RevenueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revenue", ctx, q)
+	ret0, _ := ret[0].([]*entity.RevenueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revenue indicates an expected call of Revenue.
+func (mr *MockBookingRepositoryMockRecorder) Revenue(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revenue", reflect.TypeOf((*MockBookingRepository)(nil).Revenue), ctx, q)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockBookingRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.BookingStatus) error {
 	m.ctrl.T.Helper()
@@ -607,6 +1105,20 @@ func (mr *MockBookingRepositoryMockRecorder) UpdateStatus(ctx, id, status any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockBookingRepository)(nil).UpdateStatus), ctx, id, status)
 }
 
+// UpdateTime mocks base method.
+func (m *MockBookingRepository) UpdateTime(ctx context.Context, id uuid.UUID, start, end time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTime", ctx, id, start, end)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTime indicates an expected call of UpdateTime.
+func (mr *MockBookingRepositoryMockRecorder) UpdateTime(ctx, id, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTime", reflect.TypeOf((*MockBookingRepository)(nil).UpdateTime), ctx, id, start, end)
+}
+
 // MockClientRepository is a mock of ClientRepository interface.
 type MockClientRepository struct {
 	ctrl     *gomock.Controller
@@ -674,6 +1186,21 @@ func (mr *MockClientRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockClientRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByTelegramID mocks base method.
+func (m *MockClientRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTelegramID", ctx, telegramID)
+	ret0, _ := ret[0].(*entity.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTelegramID indicates an expected call of GetByTelegramID.
+func (mr *MockClientRepositoryMockRecorder) GetByTelegramID(ctx, telegramID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTelegramID", reflect.TypeOf((*MockClientRepository)(nil).GetByTelegramID), ctx, telegramID)
+}
+
 // List mocks base method.
 func (m *MockClientRepository) List(ctx context.Context, offset, limit int) ([]*entity.Client, error) {
 	m.ctrl.T.Helper()
@@ -702,3 +1229,927 @@ func (mr *MockClientRepositoryMockRecorder) Update(ctx, client any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClientRepository)(nil).Update), ctx, client)
 }
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockOutboxRepository) Add(ctx context.Context, event *entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockOutboxRepositoryMockRecorder) Add(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockOutboxRepository)(nil).Add), ctx, event)
+}
+
+// Claim mocks base method.
+func (m *MockOutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, lease)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOutboxRepositoryMockRecorder) Claim(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOutboxRepository)(nil).Claim), ctx, limit, lease)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockOutboxRepositoryMockRecorder) MarkDelivered(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockOutboxRepository)(nil).MarkDelivered), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, deliveredSinks []string, dead bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, nextAttemptAt, lastErr, deliveredSinks, dead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkFailed(ctx, id, nextAttemptAt, lastErr, deliveredSinks, dead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkFailed), ctx, id, nextAttemptAt, lastErr, deliveredSinks, dead)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(ctx context.Context, sub *entity.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), ctx, sub)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockWebhookRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookRepository)(nil).List), ctx)
+}
+
+// ListActive mocks base method.
+func (m *MockWebhookRepository) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx)
+	ret0, _ := ret[0].([]*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockWebhookRepositoryMockRecorder) ListActive(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockWebhookRepository)(nil).ListActive), ctx)
+}
+
+// Update mocks base method.
+func (m *MockWebhookRepository) Update(ctx context.Context, sub *entity.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookRepositoryMockRecorder) Update(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookRepository)(nil).Update), ctx, sub)
+}
+
+// MockWebhookDeliveryRepository is a mock of WebhookDeliveryRepository interface.
+type MockWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryRepository.
+type MockWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryRepository
+}
+
+// NewMockWebhookDeliveryRepository creates a new mock instance.
+func NewMockWebhookDeliveryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWebhookDeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDispatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, lease)
+	ret0, _ := ret[0].([]*entity.WebhookDispatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Claim(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Claim), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, d *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Create(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Create), ctx, d)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetByID), ctx, id)
+}
+
+// ListAttempts mocks base method.
+func (m *MockWebhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entity.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttempts", ctx, deliveryID)
+	ret0, _ := ret[0].([]*entity.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttempts indicates an expected call of ListAttempts.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListAttempts(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttempts", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListAttempts), ctx, deliveryID)
+}
+
+// ListBySubscription mocks base method.
+func (m *MockWebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySubscription", ctx, subscriptionID, offset, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySubscription indicates an expected call of ListBySubscription.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListBySubscription(ctx, subscriptionID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySubscription", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListBySubscription), ctx, subscriptionID, offset, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockWebhookDeliveryRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkDelivered(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkDelivered), ctx, id)
+}
+
+// MarkFailed mocks base method.
+func (m *MockWebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, nextAttemptAt, lastErr, dead)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkFailed(ctx, id, nextAttemptAt, lastErr, dead any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkFailed), ctx, id, nextAttemptAt, lastErr, dead)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookDeliveryRepository) RecordAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) RecordAttempt(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).RecordAttempt), ctx, attempt)
+}
+
+// Retry mocks base method.
+func (m *MockWebhookDeliveryRepository) Retry(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Retry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Retry), ctx, id)
+}
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelPending mocks base method.
+func (m *MockReminderRepository) CancelPending(ctx context.Context, bookingID uuid.UUID, keepOffsets []time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPending", ctx, bookingID, keepOffsets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPending indicates an expected call of CancelPending.
+func (mr *MockReminderRepositoryMockRecorder) CancelPending(ctx, bookingID, keepOffsets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPending", reflect.TypeOf((*MockReminderRepository)(nil).CancelPending), ctx, bookingID, keepOffsets)
+}
+
+// Claim mocks base method.
+func (m *MockReminderRepository) Claim(ctx context.Context, limit int) ([]*entity.ReminderDispatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit)
+	ret0, _ := ret[0].([]*entity.ReminderDispatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockReminderRepositoryMockRecorder) Claim(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockReminderRepository)(nil).Claim), ctx, limit)
+}
+
+// FailStale mocks base method.
+func (m *MockReminderRepository) FailStale(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailStale", ctx, claimedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailStale indicates an expected call of FailStale.
+func (mr *MockReminderRepositoryMockRecorder) FailStale(ctx, claimedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailStale", reflect.TypeOf((*MockReminderRepository)(nil).FailStale), ctx, claimedBefore)
+}
+
+// MarkFailed mocks base method.
+func (m *MockReminderRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, lastErr, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockReminderRepositoryMockRecorder) MarkFailed(ctx, id, lastErr, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockReminderRepository)(nil).MarkFailed), ctx, id, lastErr, retryAt)
+}
+
+// MarkSent mocks base method.
+func (m *MockReminderRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockReminderRepositoryMockRecorder) MarkSent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockReminderRepository)(nil).MarkSent), ctx, id)
+}
+
+// Upsert mocks base method.
+func (m *MockReminderRepository) Upsert(ctx context.Context, r *entity.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockReminderRepositoryMockRecorder) Upsert(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockReminderRepository)(nil).Upsert), ctx, r)
+}
+
+// MockCalendarFeedRepository is a mock of CalendarFeedRepository interface.
+type MockCalendarFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarFeedRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarFeedRepositoryMockRecorder is the mock recorder for MockCalendarFeedRepository.
+type MockCalendarFeedRepositoryMockRecorder struct {
+	mock *MockCalendarFeedRepository
+}
+
+// NewMockCalendarFeedRepository creates a new mock instance.
+func NewMockCalendarFeedRepository(ctrl *gomock.Controller) *MockCalendarFeedRepository {
+	mock := &MockCalendarFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarFeedRepository) EXPECT() *MockCalendarFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCalendarFeedRepository) Delete(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ownerRole, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCalendarFeedRepositoryMockRecorder) Delete(ctx, ownerRole, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCalendarFeedRepository)(nil).Delete), ctx, ownerRole, ownerID)
+}
+
+// GetByHash mocks base method.
+func (m *MockCalendarFeedRepository) GetByHash(ctx context.Context, hash string) (*entity.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockCalendarFeedRepositoryMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockCalendarFeedRepository)(nil).GetByHash), ctx, hash)
+}
+
+// Upsert mocks base method.
+func (m *MockCalendarFeedRepository) Upsert(ctx context.Context, feed *entity.CalendarFeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCalendarFeedRepositoryMockRecorder) Upsert(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCalendarFeedRepository)(nil).Upsert), ctx, feed)
+}
+
+// MockBusyBlockRepository is a mock of BusyBlockRepository interface.
+type MockBusyBlockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBusyBlockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBusyBlockRepositoryMockRecorder is the mock recorder for MockBusyBlockRepository.
+type MockBusyBlockRepositoryMockRecorder struct {
+	mock *MockBusyBlockRepository
+}
+
+// NewMockBusyBlockRepository creates a new mock instance.
+func NewMockBusyBlockRepository(ctrl *gomock.Controller) *MockBusyBlockRepository {
+	mock := &MockBusyBlockRepository{ctrl: ctrl}
+	mock.recorder = &MockBusyBlockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBusyBlockRepository) EXPECT() *MockBusyBlockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBusyBlockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBusyBlockRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBusyBlockRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteByUIDs mocks base method.
+func (m *MockBusyBlockRepository) DeleteByUIDs(ctx context.Context, masterID uuid.UUID, uids []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUIDs", ctx, masterID, uids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUIDs indicates an expected call of DeleteByUIDs.
+func (mr *MockBusyBlockRepositoryMockRecorder) DeleteByUIDs(ctx, masterID, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUIDs", reflect.TypeOf((*MockBusyBlockRepository)(nil).DeleteByUIDs), ctx, masterID, uids)
+}
+
+// DeleteStale mocks base method.
+func (m *MockBusyBlockRepository) DeleteStale(ctx context.Context, sourceID uuid.UUID, keepUIDs []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStale", ctx, sourceID, keepUIDs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStale indicates an expected call of DeleteStale.
+func (mr *MockBusyBlockRepositoryMockRecorder) DeleteStale(ctx, sourceID, keepUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStale", reflect.TypeOf((*MockBusyBlockRepository)(nil).DeleteStale), ctx, sourceID, keepUIDs)
+}
+
+// GetByHref mocks base method.
+func (m *MockBusyBlockRepository) GetByHref(ctx context.Context, masterID uuid.UUID, href string) (*entity.BusyBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHref", ctx, masterID, href)
+	ret0, _ := ret[0].(*entity.BusyBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHref indicates an expected call of GetByHref.
+func (mr *MockBusyBlockRepositoryMockRecorder) GetByHref(ctx, masterID, href any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHref", reflect.TypeOf((*MockBusyBlockRepository)(nil).GetByHref), ctx, masterID, href)
+}
+
+// GetByID mocks base method.
+func (m *MockBusyBlockRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BusyBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.BusyBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBusyBlockRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBusyBlockRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByMaster mocks base method.
+func (m *MockBusyBlockRepository) ListByMaster(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.BusyBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMaster", ctx, masterID, from, to)
+	ret0, _ := ret[0].([]*entity.BusyBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMaster indicates an expected call of ListByMaster.
+func (mr *MockBusyBlockRepositoryMockRecorder) ListByMaster(ctx, masterID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMaster", reflect.TypeOf((*MockBusyBlockRepository)(nil).ListByMaster), ctx, masterID, from, to)
+}
+
+// Upsert mocks base method.
+func (m *MockBusyBlockRepository) Upsert(ctx context.Context, block *entity.BusyBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockBusyBlockRepositoryMockRecorder) Upsert(ctx, block any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockBusyBlockRepository)(nil).Upsert), ctx, block)
+}
+
+// MockCalendarSourceRepository is a mock of CalendarSourceRepository interface.
+type MockCalendarSourceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarSourceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarSourceRepositoryMockRecorder is the mock recorder for MockCalendarSourceRepository.
+type MockCalendarSourceRepositoryMockRecorder struct {
+	mock *MockCalendarSourceRepository
+}
+
+// NewMockCalendarSourceRepository creates a new mock instance.
+func NewMockCalendarSourceRepository(ctrl *gomock.Controller) *MockCalendarSourceRepository {
+	mock := &MockCalendarSourceRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarSourceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarSourceRepository) EXPECT() *MockCalendarSourceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockCalendarSourceRepository) Claim(ctx context.Context, limit int, interval time.Duration) ([]*entity.CalendarSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, interval)
+	ret0, _ := ret[0].([]*entity.CalendarSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockCalendarSourceRepositoryMockRecorder) Claim(ctx, limit, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockCalendarSourceRepository)(nil).Claim), ctx, limit, interval)
+}
+
+// Create mocks base method.
+func (m *MockCalendarSourceRepository) Create(ctx context.Context, source *entity.CalendarSource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, source)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCalendarSourceRepositoryMockRecorder) Create(ctx, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCalendarSourceRepository)(nil).Create), ctx, source)
+}
+
+// Delete mocks base method.
+func (m *MockCalendarSourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCalendarSourceRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCalendarSourceRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCalendarSourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CalendarSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.CalendarSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCalendarSourceRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCalendarSourceRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByMaster mocks base method.
+func (m *MockCalendarSourceRepository) ListByMaster(ctx context.Context, masterID uuid.UUID) ([]*entity.CalendarSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByMaster", ctx, masterID)
+	ret0, _ := ret[0].([]*entity.CalendarSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByMaster indicates an expected call of ListByMaster.
+func (mr *MockCalendarSourceRepositoryMockRecorder) ListByMaster(ctx, masterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByMaster", reflect.TypeOf((*MockCalendarSourceRepository)(nil).ListByMaster), ctx, masterID)
+}
+
+// MarkSynced mocks base method.
+func (m *MockCalendarSourceRepository) MarkSynced(ctx context.Context, id uuid.UUID, lastErr *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSynced", ctx, id, lastErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSynced indicates an expected call of MarkSynced.
+func (mr *MockCalendarSourceRepositoryMockRecorder) MarkSynced(ctx, id, lastErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSynced", reflect.TypeOf((*MockCalendarSourceRepository)(nil).MarkSynced), ctx, id, lastErr)
+}
+
+// MockCalDAVAccountRepository is a mock of CalDAVAccountRepository interface.
+type MockCalDAVAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalDAVAccountRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalDAVAccountRepositoryMockRecorder is the mock recorder for MockCalDAVAccountRepository.
+type MockCalDAVAccountRepositoryMockRecorder struct {
+	mock *MockCalDAVAccountRepository
+}
+
+// NewMockCalDAVAccountRepository creates a new mock instance.
+func NewMockCalDAVAccountRepository(ctrl *gomock.Controller) *MockCalDAVAccountRepository {
+	mock := &MockCalDAVAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockCalDAVAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalDAVAccountRepository) EXPECT() *MockCalDAVAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCalDAVAccountRepository) Delete(ctx context.Context, masterID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, masterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCalDAVAccountRepositoryMockRecorder) Delete(ctx, masterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCalDAVAccountRepository)(nil).Delete), ctx, masterID)
+}
+
+// GetByHash mocks base method.
+func (m *MockCalDAVAccountRepository) GetByHash(ctx context.Context, hash string) (*entity.CalDAVAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(*entity.CalDAVAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockCalDAVAccountRepositoryMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockCalDAVAccountRepository)(nil).GetByHash), ctx, hash)
+}
+
+// Upsert mocks base method.
+func (m *MockCalDAVAccountRepository) Upsert(ctx context.Context, account *entity.CalDAVAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCalDAVAccountRepositoryMockRecorder) Upsert(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCalDAVAccountRepository)(nil).Upsert), ctx, account)
+}
+
+// MockTimeOffRepository is a mock of TimeOffRepository interface.
+type MockTimeOffRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeOffRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTimeOffRepositoryMockRecorder is the mock recorder for MockTimeOffRepository.
+type MockTimeOffRepositoryMockRecorder struct {
+	mock *MockTimeOffRepository
+}
+
+// NewMockTimeOffRepository creates a new mock instance.
+func NewMockTimeOffRepository(ctrl *gomock.Controller) *MockTimeOffRepository {
+	mock := &MockTimeOffRepository{ctrl: ctrl}
+	mock.recorder = &MockTimeOffRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeOffRepository) EXPECT() *MockTimeOffRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeOffRepository) Create(ctx context.Context, req *entity.TimeOffRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeOffRepositoryMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeOffRepository)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockTimeOffRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeOffRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeOffRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockTimeOffRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TimeOffRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.TimeOffRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTimeOffRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTimeOffRepository)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockTimeOffRepository) List(ctx context.Context, masterID uuid.UUID, status entity.TimeOffStatus, offset, limit int) ([]*entity.TimeOffRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, masterID, status, offset, limit)
+	ret0, _ := ret[0].([]*entity.TimeOffRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTimeOffRepositoryMockRecorder) List(ctx, masterID, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTimeOffRepository)(nil).List), ctx, masterID, status, offset, limit)
+}
+
+// Review mocks base method.
+func (m *MockTimeOffRepository) Review(ctx context.Context, req *entity.TimeOffRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Review", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Review indicates an expected call of Review.
+func (mr *MockTimeOffRepositoryMockRecorder) Review(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Review", reflect.TypeOf((*MockTimeOffRepository)(nil).Review), ctx, req)
+}
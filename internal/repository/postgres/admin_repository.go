@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AdminRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewAdminRepository(conn *pgxpool.Pool) *AdminRepository {
+	return &AdminRepository{conn: conn}
+}
+
+func (r *AdminRepository) Create(ctx context.Context, admin *entity.Admin) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO admins (organization_id, name, email, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id`
+
+	now := time.Now()
+	admin.OrganizationID = orgID
+	admin.CreatedAt = now
+	admin.UpdatedAt = now
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query, orgID, admin.Name, admin.Email, admin.PasswordHash, now).Scan(&admin.ID)
+}
+
+func (r *AdminRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, organization_id, name, email, password_hash, created_at, updated_at
+		FROM admins
+		WHERE id = $1 AND organization_id = $2`
+
+	return r.scanOne(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+}
+
+func (r *AdminRepository) GetByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, organization_id, name, email, password_hash, created_at, updated_at
+		FROM admins
+		WHERE email = $1 AND organization_id = $2`
+
+	return r.scanOne(querierFrom(ctx, r.conn).QueryRow(ctx, query, email, orgID))
+}
+
+func (r *AdminRepository) scanOne(row pgx.Row) (*entity.Admin, error) {
+	admin := &entity.Admin{}
+	err := row.Scan(
+		&admin.ID,
+		&admin.OrganizationID,
+		&admin.Name,
+		&admin.Email,
+		&admin.PasswordHash,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
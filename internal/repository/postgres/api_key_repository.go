@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lastUsedPrecision limits how often last_used_at is written for a busy key.
+const lastUsedPrecision = time.Minute
+
+type APIKeyRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewAPIKeyRepository(conn *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{conn: conn}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO api_keys (organization_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	key.OrganizationID = orgID
+	key.CreatedAt = time.Now()
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.ExpiresAt,
+		key.CreatedAt,
+	).Scan(&key.ID)
+}
+
+func (r *APIKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, organization_id, name, prefix, key_hash, scopes, last_used_at, revoked_at, expires_at, created_at
+		FROM api_keys
+		WHERE organization_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*entity.APIKey, 0)
+	for rows.Next() {
+		k := &entity.APIKey{}
+		if err := rows.Scan(
+			&k.ID,
+			&k.OrganizationID,
+			&k.Name,
+			&k.Prefix,
+			&k.KeyHash,
+			&k.Scopes,
+			&k.LastUsedAt,
+			&k.RevokedAt,
+			&k.ExpiresAt,
+			&k.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $1
+		WHERE id = $2 AND organization_id = $3 AND revoked_at IS NULL`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, organization_id, name, prefix, key_hash, scopes, last_used_at, revoked_at, expires_at, created_at
+		FROM api_keys
+		WHERE key_hash = $1`
+
+	k := &entity.APIKey{}
+	err := querierFrom(ctx, r.conn).QueryRow(ctx, query, hash).Scan(
+		&k.ID,
+		&k.OrganizationID,
+		&k.Name,
+		&k.Prefix,
+		&k.KeyHash,
+		&k.Scopes,
+		&k.LastUsedAt,
+		&k.RevokedAt,
+		&k.ExpiresAt,
+		&k.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// TouchLastUsed records the use of the key, at most once per lastUsedPrecision.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $1
+		WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)`
+
+	now := time.Now()
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, now, id, now.Add(-lastUsedPrecision))
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	"github.com/google/uuid"
@@ -22,41 +23,56 @@ func NewBookingRepository(conn *pgxpool.Pool) *BookingRepository {
 }
 
 func (r *BookingRepository) Create(ctx context.Context, booking *entity.Booking) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO bookings (master_id, client_id, service_id, start_time, end_time, status, created_at, updated_at)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$7)
+		INSERT INTO bookings (organization_id, master_id, client_id, service_id, start_time, end_time, status, price, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$9)
 		RETURNING id`
 
 	now := time.Now()
+	booking.OrganizationID = orgID
 	booking.CreatedAt = now
 	booking.UpdatedAt = now
 
-	return r.conn.QueryRow(ctx, query,
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
 		booking.MasterID,
 		booking.ClientID,
 		booking.ServiceID,
 		booking.StartTime,
 		booking.EndTime,
 		booking.Status,
+		booking.Price,
 		now,
 	).Scan(&booking.ID)
 }
 
 func (r *BookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, client_id, service_id, start_time, end_time, status, created_at, updated_at
+		SELECT id, organization_id, master_id, client_id, service_id, start_time, end_time, status, price, created_at, updated_at
 		FROM bookings
-		WHERE id = $1`
+		WHERE id = $1 AND organization_id = $2`
 
 	b := &entity.Booking{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
 		&b.ID,
+		&b.OrganizationID,
 		&b.MasterID,
 		&b.ClientID,
 		&b.ServiceID,
 		&b.StartTime,
 		&b.EndTime,
 		&b.Status,
+		&b.Price,
 		&b.CreatedAt,
 		&b.UpdatedAt,
 	)
@@ -70,13 +86,18 @@ func (r *BookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 }
 
 func (r *BookingRepository) GetByMasterID(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.Booking, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, client_id, service_id, start_time, end_time, status, created_at, updated_at
+		SELECT id, organization_id, master_id, client_id, service_id, start_time, end_time, status, price, created_at, updated_at
 		FROM bookings
-		WHERE master_id=$1 AND start_time >= $2 AND end_time <= $3
+		WHERE master_id=$1 AND start_time >= $2 AND end_time <= $3 AND organization_id=$4
 		ORDER BY start_time`
 
-	rows, err := r.conn.Query(ctx, query, masterID, from, to)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, from, to, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -87,12 +108,14 @@ func (r *BookingRepository) GetByMasterID(ctx context.Context, masterID uuid.UUI
 		b := &entity.Booking{}
 		if err := rows.Scan(
 			&b.ID,
+			&b.OrganizationID,
 			&b.MasterID,
 			&b.ClientID,
 			&b.ServiceID,
 			&b.StartTime,
 			&b.EndTime,
 			&b.Status,
+			&b.Price,
 			&b.CreatedAt,
 			&b.UpdatedAt,
 		); err != nil {
@@ -105,13 +128,18 @@ func (r *BookingRepository) GetByMasterID(ctx context.Context, masterID uuid.UUI
 }
 
 func (r *BookingRepository) GetByClientID(ctx context.Context, clientID uuid.UUID) ([]*entity.Booking, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, client_id, service_id, start_time, end_time, status, created_at, updated_at
+		SELECT id, organization_id, master_id, client_id, service_id, start_time, end_time, status, price, created_at, updated_at
 		FROM bookings
-		WHERE client_id=$1
+		WHERE client_id=$1 AND organization_id=$2
 		ORDER BY start_time`
 
-	rows, err := r.conn.Query(ctx, query, clientID)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, clientID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -122,12 +150,14 @@ func (r *BookingRepository) GetByClientID(ctx context.Context, clientID uuid.UUI
 		b := &entity.Booking{}
 		if err := rows.Scan(
 			&b.ID,
+			&b.OrganizationID,
 			&b.MasterID,
 			&b.ClientID,
 			&b.ServiceID,
 			&b.StartTime,
 			&b.EndTime,
 			&b.Status,
+			&b.Price,
 			&b.CreatedAt,
 			&b.UpdatedAt,
 		); err != nil {
@@ -140,12 +170,38 @@ func (r *BookingRepository) GetByClientID(ctx context.Context, clientID uuid.UUI
 }
 
 func (r *BookingRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.BookingStatus) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE bookings
 		SET status=$1, updated_at=$2
-		WHERE id=$3`
+		WHERE id=$3 AND organization_id=$4`
 
-	result, err := r.conn.Exec(ctx, query, status, time.Now(), id)
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, status, time.Now(), id, orgID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BookingRepository) UpdateTime(ctx context.Context, id uuid.UUID, start, end time.Time) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE bookings
+		SET start_time=$1, end_time=$2, updated_at=$3
+		WHERE id=$4 AND organization_id=$5`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, start, end, time.Now(), id, orgID)
 	if err != nil {
 		return err
 	}
@@ -156,8 +212,13 @@ func (r *BookingRepository) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 }
 
 func (r *BookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM bookings WHERE id=$1`
-	result, err := r.conn.Exec(ctx, query, id)
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM bookings WHERE id=$1 AND organization_id=$2`
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -166,3 +227,66 @@ func (r *BookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *BookingRepository) Revenue(ctx context.Context, q entity.RevenueQuery) ([]*entity.RevenueRow, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the groupings not asked for are NULL, so they collapse into one group
+	query := `
+		SELECT date_trunc($1, b.start_time AT TIME ZONE $2)::date,
+		       CASE WHEN $3::boolean THEN b.master_id END,
+		       CASE WHEN $3::boolean THEN COALESCE(m.name, '') ELSE '' END,
+		       CASE WHEN $4::boolean THEN b.service_id END,
+		       CASE WHEN $4::boolean THEN COALESCE(s.name, '') ELSE '' END,
+		       CASE WHEN $5::boolean THEN b.status::text ELSE '' END,
+		       COUNT(*),
+		       COALESCE(SUM(b.price), 0)
+		FROM bookings b
+		LEFT JOIN masters m ON m.id = b.master_id
+		LEFT JOIN services s ON s.id = b.service_id
+		WHERE b.organization_id = $6 AND b.start_time >= $7 AND b.start_time < $8
+		  AND ($9::uuid IS NULL OR b.master_id = $9)
+		  AND (cardinality($10::text[]) = 0 OR b.status::text = ANY ($10))
+		GROUP BY 1, 2, 3, 4, 5, 6
+		ORDER BY 1, 3, 2, 5, 4, 6`
+
+	var master *uuid.UUID
+	if q.MasterID != uuid.Nil {
+		master = &q.MasterID
+	}
+	statuses := make([]string, 0, len(q.Statuses))
+	for _, s := range q.Statuses {
+		statuses = append(statuses, string(s))
+	}
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query,
+		string(q.Period), q.Location, q.ByMaster, q.ByService, q.ByStatus,
+		orgID, q.From, q.To, master, statuses,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*entity.RevenueRow, 0)
+	for rows.Next() {
+		row := &entity.RevenueRow{}
+		if err := rows.Scan(
+			&row.Period,
+			&row.MasterID,
+			&row.MasterName,
+			&row.ServiceID,
+			&row.ServiceName,
+			&row.Status,
+			&row.Bookings,
+			&row.Revenue,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BusyBlockRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewBusyBlockRepository(conn *pgxpool.Pool) *BusyBlockRepository {
+	return &BusyBlockRepository{conn: conn}
+}
+
+const busyBlockColumns = `id, organization_id, master_id, source_id, uid, href, summary, start_time, end_time, created_at, updated_at`
+
+func scanBusyBlock(row pgx.Row) (*entity.BusyBlock, error) {
+	b := &entity.BusyBlock{}
+	err := row.Scan(
+		&b.ID,
+		&b.OrganizationID,
+		&b.MasterID,
+		&b.SourceID,
+		&b.UID,
+		&b.Href,
+		&b.Summary,
+		&b.StartTime,
+		&b.EndTime,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *BusyBlockRepository) Upsert(ctx context.Context, block *entity.BusyBlock) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO busy_blocks (organization_id, master_id, source_id, uid, href, summary, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (master_id, uid) DO UPDATE
+		SET source_id = EXCLUDED.source_id, href = COALESCE(EXCLUDED.href, busy_blocks.href), summary = EXCLUDED.summary,
+		    start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time, updated_at = now()
+		WHERE busy_blocks.organization_id = EXCLUDED.organization_id
+		RETURNING id, created_at, updated_at`
+
+	block.OrganizationID = orgID
+
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		block.MasterID,
+		block.SourceID,
+		block.UID,
+		block.Href,
+		block.Summary,
+		block.StartTime,
+		block.EndTime,
+	).Scan(&block.ID, &block.CreatedAt, &block.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apiErrors.ErrNotFound
+	}
+	return err
+}
+
+func (r *BusyBlockRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BusyBlock, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + busyBlockColumns + ` FROM busy_blocks WHERE id = $1 AND organization_id = $2`
+
+	b, err := scanBusyBlock(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	return b, err
+}
+
+func (r *BusyBlockRepository) GetByHref(ctx context.Context, masterID uuid.UUID, href string) (*entity.BusyBlock, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + busyBlockColumns + ` FROM busy_blocks WHERE master_id = $1 AND href = $2 AND organization_id = $3`
+
+	b, err := scanBusyBlock(querierFrom(ctx, r.conn).QueryRow(ctx, query, masterID, href, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	return b, err
+}
+
+func (r *BusyBlockRepository) ListByMaster(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.BusyBlock, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + busyBlockColumns + `
+		FROM busy_blocks
+		WHERE master_id = $1 AND start_time < $3 AND end_time > $2 AND organization_id = $4
+		ORDER BY start_time`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, from, to, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := make([]*entity.BusyBlock, 0)
+	for rows.Next() {
+		b, err := scanBusyBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+func (r *BusyBlockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx,
+		`DELETE FROM busy_blocks WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *BusyBlockRepository) DeleteByUIDs(ctx context.Context, masterID uuid.UUID, uids []string) (int, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx,
+		`DELETE FROM busy_blocks WHERE master_id = $1 AND uid = ANY($2) AND organization_id = $3`,
+		masterID, uids, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *BusyBlockRepository) DeleteStale(ctx context.Context, sourceID uuid.UUID, keepUIDs []string) (int, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx,
+		`DELETE FROM busy_blocks WHERE source_id = $1 AND NOT (uid = ANY($2)) AND organization_id = $3`,
+		sourceID, keepUIDs, orgID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalDAVAccountRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewCalDAVAccountRepository(conn *pgxpool.Pool) *CalDAVAccountRepository {
+	return &CalDAVAccountRepository{conn: conn}
+}
+
+func (r *CalDAVAccountRepository) Upsert(ctx context.Context, account *entity.CalDAVAccount) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO caldav_accounts (organization_id, master_id, token_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (master_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+		WHERE caldav_accounts.organization_id = EXCLUDED.organization_id
+		RETURNING id`
+
+	account.OrganizationID = orgID
+	account.CreatedAt = time.Now()
+
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		account.MasterID,
+		account.TokenHash,
+		account.CreatedAt,
+	).Scan(&account.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the master belongs to another organization
+		return apiErrors.ErrNotFound
+	}
+	return err
+}
+
+func (r *CalDAVAccountRepository) Delete(ctx context.Context, masterID uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM caldav_accounts WHERE master_id = $1 AND organization_id = $2`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, masterID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CalDAVAccountRepository) GetByHash(ctx context.Context, hash string) (*entity.CalDAVAccount, error) {
+	query := `
+		SELECT id, organization_id, master_id, token_hash, created_at
+		FROM caldav_accounts
+		WHERE token_hash = $1`
+
+	a := &entity.CalDAVAccount{}
+	err := querierFrom(ctx, r.conn).QueryRow(ctx, query, hash).Scan(
+		&a.ID,
+		&a.OrganizationID,
+		&a.MasterID,
+		&a.TokenHash,
+		&a.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarFeedRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewCalendarFeedRepository(conn *pgxpool.Pool) *CalendarFeedRepository {
+	return &CalendarFeedRepository{conn: conn}
+}
+
+func (r *CalendarFeedRepository) Upsert(ctx context.Context, feed *entity.CalendarFeed) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO calendar_feeds (organization_id, owner_role, owner_id, token_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner_role, owner_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+		WHERE calendar_feeds.organization_id = EXCLUDED.organization_id
+		RETURNING id`
+
+	feed.OrganizationID = orgID
+	feed.CreatedAt = time.Now()
+
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		feed.OwnerRole,
+		feed.OwnerID,
+		feed.TokenHash,
+		feed.CreatedAt,
+	).Scan(&feed.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the owner belongs to another organization
+		return apiErrors.ErrNotFound
+	}
+	return err
+}
+
+func (r *CalendarFeedRepository) Delete(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM calendar_feeds WHERE owner_role = $1 AND owner_id = $2 AND organization_id = $3`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, ownerRole, ownerID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CalendarFeedRepository) GetByHash(ctx context.Context, hash string) (*entity.CalendarFeed, error) {
+	query := `
+		SELECT id, organization_id, owner_role, owner_id, token_hash, created_at
+		FROM calendar_feeds
+		WHERE token_hash = $1`
+
+	f := &entity.CalendarFeed{}
+	err := querierFrom(ctx, r.conn).QueryRow(ctx, query, hash).Scan(
+		&f.ID,
+		&f.OrganizationID,
+		&f.OwnerRole,
+		&f.OwnerID,
+		&f.TokenHash,
+		&f.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
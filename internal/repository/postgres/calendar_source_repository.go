@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarSourceRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewCalendarSourceRepository(conn *pgxpool.Pool) *CalendarSourceRepository {
+	return &CalendarSourceRepository{conn: conn}
+}
+
+const calendarSourceColumns = `id, organization_id, master_id, name, url, next_sync_at, last_synced_at, last_error, created_at, updated_at`
+
+func scanCalendarSource(row pgx.Row) (*entity.CalendarSource, error) {
+	s := &entity.CalendarSource{}
+	err := row.Scan(
+		&s.ID,
+		&s.OrganizationID,
+		&s.MasterID,
+		&s.Name,
+		&s.URL,
+		&s.NextSyncAt,
+		&s.LastSyncedAt,
+		&s.LastError,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *CalendarSourceRepository) Create(ctx context.Context, source *entity.CalendarSource) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO calendar_sources (organization_id, master_id, name, url)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, next_sync_at, created_at, updated_at`
+
+	source.OrganizationID = orgID
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		source.MasterID,
+		source.Name,
+		source.URL,
+	).Scan(&source.ID, &source.NextSyncAt, &source.CreatedAt, &source.UpdatedAt)
+}
+
+func (r *CalendarSourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CalendarSource, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + calendarSourceColumns + ` FROM calendar_sources WHERE id = $1 AND organization_id = $2`
+
+	s, err := scanCalendarSource(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	return s, err
+}
+
+func (r *CalendarSourceRepository) ListByMaster(ctx context.Context, masterID uuid.UUID) ([]*entity.CalendarSource, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + calendarSourceColumns + `
+		FROM calendar_sources
+		WHERE master_id = $1 AND organization_id = $2
+		ORDER BY created_at`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources := make([]*entity.CalendarSource, 0)
+	for rows.Next() {
+		s, err := scanCalendarSource(rows)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+func (r *CalendarSourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx,
+		`DELETE FROM calendar_sources WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *CalendarSourceRepository) MarkSynced(ctx context.Context, id uuid.UUID, lastErr *string) error {
+	query := `
+		UPDATE calendar_sources
+		SET last_synced_at = CASE WHEN $1::text IS NULL THEN now() ELSE last_synced_at END,
+		    last_error = $1, updated_at = now()
+		WHERE id = $2`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, lastErr, id)
+	return err
+}
+
+func (r *CalendarSourceRepository) Claim(ctx context.Context, limit int, interval time.Duration) ([]*entity.CalendarSource, error) {
+	query := `
+		UPDATE calendar_sources
+		SET next_sync_at = $1
+		WHERE id IN (
+			SELECT id FROM calendar_sources
+			WHERE next_sync_at <= $2
+			ORDER BY next_sync_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + calendarSourceColumns
+
+	now := time.Now()
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, now.Add(interval), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sources := make([]*entity.CalendarSource, 0)
+	for rows.Next() {
+		s, err := scanCalendarSource(rows)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
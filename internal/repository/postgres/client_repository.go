@@ -7,6 +7,7 @@ import (
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,19 +22,26 @@ func NewClientRepository(conn *pgxpool.Pool) *ClientRepository {
 }
 
 func (r *ClientRepository) Create(ctx context.Context, client *entity.Client) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO clients (
-			name, email, phone, telegram_id, telegram_username,
+			organization_id, name, email, phone, telegram_id, telegram_username,
 			city, timezone, language, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
 		RETURNING id`
 
 	now := time.Now()
+	client.OrganizationID = orgID
 	client.CreatedAt = now
 	client.UpdatedAt = now
 
-	err := r.conn.QueryRow(ctx, query,
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
 		client.Name,
 		client.Email,
 		client.Phone,
@@ -49,16 +57,59 @@ func (r *ClientRepository) Create(ctx context.Context, client *entity.Client) er
 }
 
 func (r *ClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Client, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
-			id, name, email, phone, telegram_id, telegram_username,
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
 			city, timezone, language, created_at, updated_at
 		FROM clients
-		WHERE id = $1`
+		WHERE id = $1 AND organization_id = $2`
 
 	client := &entity.Client{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
 		&client.ID,
+		&client.OrganizationID,
+		&client.Name,
+		&client.Email,
+		&client.Phone,
+		&client.TelegramID,
+		&client.TelegramUsername,
+		&client.City,
+		&client.Timezone,
+		&client.Language,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiErrors.ErrNotFound
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+func (r *ClientRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Client, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT 
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
+			city, timezone, language, created_at, updated_at
+		FROM clients
+		WHERE telegram_id = $1 AND organization_id = $2`
+
+	client := &entity.Client{}
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, telegramID, orgID).Scan(
+		&client.ID,
+		&client.OrganizationID,
 		&client.Name,
 		&client.Email,
 		&client.Phone,
@@ -80,6 +131,11 @@ func (r *ClientRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.C
 }
 
 func (r *ClientRepository) Update(ctx context.Context, client *entity.Client) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE clients
 		SET 
@@ -92,9 +148,9 @@ func (r *ClientRepository) Update(ctx context.Context, client *entity.Client) er
 			timezone = $7,
 			language = $8,
 			updated_at = $9
-		WHERE id = $10`
+		WHERE id = $10 AND organization_id = $11`
 
-	result, err := r.conn.Exec(ctx, query,
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
 		client.Name,
 		client.Email,
 		client.Phone,
@@ -105,6 +161,7 @@ func (r *ClientRepository) Update(ctx context.Context, client *entity.Client) er
 		client.Language,
 		time.Now(),
 		client.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -117,9 +174,14 @@ func (r *ClientRepository) Update(ctx context.Context, client *entity.Client) er
 }
 
 func (r *ClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM clients WHERE id = $1`
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM clients WHERE id = $1 AND organization_id = $2`
 
-	result, err := r.conn.Exec(ctx, query, id)
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -131,15 +193,21 @@ func (r *ClientRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *ClientRepository) List(ctx context.Context, offset, limit int) ([]*entity.Client, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
-			id, name, email, phone, telegram_id, telegram_username,
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
 			city, timezone, language, created_at, updated_at
 		FROM clients
+		WHERE organization_id = $3
 		ORDER BY id
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(ctx, query, limit, offset)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, limit, offset, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +218,7 @@ func (r *ClientRepository) List(ctx context.Context, offset, limit int) ([]*enti
 		client := &entity.Client{}
 		if err := rows.Scan(
 			&client.ID,
+			&client.OrganizationID,
 			&client.Name,
 			&client.Email,
 			&client.Phone,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,19 +22,26 @@ func NewMasterRepository(conn *pgxpool.Pool) *MasterRepository {
 }
 
 func (r *MasterRepository) Create(ctx context.Context, master *entity.Master) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO masters (
-			name, email, phone, telegram_id, telegram_username,
+			organization_id, name, email, phone, telegram_id, telegram_username,
 			description, city, timezone, language, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
 		RETURNING id`
 
 	now := time.Now()
+	master.OrganizationID = orgID
 	master.CreatedAt = now
 	master.UpdatedAt = now
 
-	err := r.conn.QueryRow(ctx, query,
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
 		master.Name,
 		master.Email,
 		master.Phone,
@@ -54,16 +62,62 @@ func (r *MasterRepository) Create(ctx context.Context, master *entity.Master) er
 }
 
 func (r *MasterRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Master, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT 
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
+			description, city, timezone, language, created_at, updated_at
+		FROM masters
+		WHERE id = $1 AND organization_id = $2`
+
+	master := &entity.Master{}
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
+		&master.ID,
+		&master.OrganizationID,
+		&master.Name,
+		&master.Email,
+		&master.Phone,
+		&master.TelegramID,
+		&master.TelegramUsername,
+		&master.Description,
+		&master.City,
+		&master.Timezone,
+		&master.Language,
+		&master.CreatedAt,
+		&master.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return master, nil
+}
+
+func (r *MasterRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Master, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
-			id, name, email, phone, telegram_id, telegram_username,
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
 			description, city, timezone, language, created_at, updated_at
 		FROM masters
-		WHERE id = $1`
+		WHERE telegram_id = $1 AND organization_id = $2`
 
 	master := &entity.Master{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, telegramID, orgID).Scan(
 		&master.ID,
+		&master.OrganizationID,
 		&master.Name,
 		&master.Email,
 		&master.Phone,
@@ -88,6 +142,11 @@ func (r *MasterRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.M
 }
 
 func (r *MasterRepository) Update(ctx context.Context, master *entity.Master) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE masters 
 		SET 
@@ -101,9 +160,9 @@ func (r *MasterRepository) Update(ctx context.Context, master *entity.Master) er
 			timezone = $8,
 			language = $9,
 			updated_at = $10
-		WHERE id = $11`
+		WHERE id = $11 AND organization_id = $12`
 
-	result, err := r.conn.Exec(ctx, query,
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
 		master.Name,
 		master.Email,
 		master.Phone,
@@ -115,6 +174,7 @@ func (r *MasterRepository) Update(ctx context.Context, master *entity.Master) er
 		master.Language,
 		time.Now(),
 		master.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -128,9 +188,14 @@ func (r *MasterRepository) Update(ctx context.Context, master *entity.Master) er
 }
 
 func (r *MasterRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM masters WHERE id = $1`
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM masters WHERE id = $1 AND organization_id = $2`
 
-	result, err := r.conn.Exec(ctx, query, id)
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -143,15 +208,21 @@ func (r *MasterRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *MasterRepository) List(ctx context.Context, offset, limit int) ([]*entity.Master, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT 
-			id, name, email, phone, telegram_id, telegram_username,
+			id, organization_id, name, email, phone, telegram_id, telegram_username,
 			description, city, timezone, language, created_at, updated_at
 		FROM masters
+		WHERE organization_id = $3
 		ORDER BY id
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(ctx, query, limit, offset)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, limit, offset, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +233,7 @@ func (r *MasterRepository) List(ctx context.Context, offset, limit int) ([]*enti
 		master := &entity.Master{}
 		err := rows.Scan(
 			&master.ID,
+			&master.OrganizationID,
 			&master.Name,
 			&master.Email,
 			&master.Phone,
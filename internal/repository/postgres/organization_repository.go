@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OrganizationRepository is the only repository that is not tenant-scoped:
+// organizations are the tenants themselves.
+type OrganizationRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewOrganizationRepository(conn *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{conn: conn}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, org *entity.Organization) error {
+	query := `
+		INSERT INTO organizations (name, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		RETURNING id`
+
+	now := time.Now()
+	org.CreatedAt = now
+	org.UpdatedAt = now
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query, org.Name, org.Timezone, now).Scan(&org.ID)
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	query := `
+		SELECT id, name, timezone, created_at, updated_at
+		FROM organizations
+		WHERE id = $1`
+
+	org := &entity.Organization{}
+	err := querierFrom(ctx, r.conn).QueryRow(ctx, query, id).Scan(
+		&org.ID,
+		&org.Name,
+		&org.Timezone,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, org *entity.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $1, timezone = $2, updated_at = $3
+		WHERE id = $4`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, org.Name, org.Timezone, time.Now(), org.ID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+
+	return nil
+}
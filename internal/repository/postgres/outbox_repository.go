@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxRepository stores domain events. Add is called by usecases within
+// their transaction; the remaining methods are used by the dispatcher across
+// all organizations.
+type OutboxRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewOutboxRepository(conn *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{conn: conn}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, event *entity.Event) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO outbox (organization_id, event_type, aggregate_id, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id`
+
+	event.OrganizationID = orgID
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		event.Type,
+		event.AggregateID,
+		event.Payload,
+		event.OccurredAt,
+	).Scan(&event.ID)
+}
+
+// Claim leases up to limit due events for the given duration. Concurrent
+// dispatchers skip each other's rows; an event whose lease expires without
+// being marked is claimed again, which gives at-least-once delivery.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.Event, error) {
+	query := `
+		UPDATE outbox
+		SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE delivered_at IS NULL AND dead_at IS NULL AND next_attempt_at <= $2
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, organization_id, event_type, aggregate_id, payload, occurred_at, attempts, delivered_sinks`
+
+	now := time.Now()
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*entity.Event, 0)
+	for rows.Next() {
+		e := &entity.Event{}
+		if err := rows.Scan(
+			&e.ID,
+			&e.OrganizationID,
+			&e.Type,
+			&e.AggregateID,
+			&e.Payload,
+			&e.OccurredAt,
+			&e.Attempts,
+			&e.DeliveredSinks,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE outbox SET delivered_at = $1, last_error = NULL WHERE id = $2`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id)
+	return err
+}
+
+// MarkFailed records the failed attempt and the sinks that accepted the
+// event, and schedules the next attempt unless the event is dead.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, deliveredSinks []string, dead bool) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2, delivered_sinks = $3,
+			dead_at = CASE WHEN $4 THEN now() END
+		WHERE id = $5`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, nextAttemptAt, lastErr, deliveredSinks, dead, id)
+	return err
+}
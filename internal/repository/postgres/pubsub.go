@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PubSub broadcasts messages to every API instance over Postgres
+// LISTEN/NOTIFY. Notifications sent within a transaction are delivered when
+// it commits.
+type PubSub struct {
+	conn *pgxpool.Pool
+}
+
+func NewPubSub(conn *pgxpool.Pool) *PubSub {
+	return &PubSub{conn: conn}
+}
+
+func (p *PubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	_, err := querierFrom(ctx, p.conn).Exec(ctx, `SELECT pg_notify($1, $2)`, channel, string(payload))
+	return err
+}
+
+// Listen holds a connection listening on the channel and passes every
+// notification to handle. It returns when the context is cancelled or the
+// connection fails.
+func (p *PubSub) Listen(ctx context.Context, channel string, handle func(payload []byte)) error {
+	pooled, err := p.conn.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection stays subscribed, so it must not return to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle([]byte(n.Payload))
+	}
+}
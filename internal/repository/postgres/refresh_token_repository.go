@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RefreshTokenRepository is looked up by token hash before the caller's
+// organization is known, so it is not scoped by the tenant in the context.
+type RefreshTokenRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewRefreshTokenRepository(conn *pgxpool.Pool) *RefreshTokenRepository {
+	return &RefreshTokenRepository{conn: conn}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (organization_id, subject_id, role, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	token.CreatedAt = time.Now()
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		token.OrganizationID,
+		token.SubjectID,
+		token.Role,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	).Scan(&token.ID)
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	query := `
+		SELECT id, organization_id, subject_id, role, family_id, token_hash, expires_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1`
+
+	t := &entity.RefreshToken{}
+	err := querierFrom(ctx, r.conn).QueryRow(ctx, query, hash).Scan(
+		&t.ID,
+		&t.OrganizationID,
+		&t.SubjectID,
+		&t.Role,
+		&t.FamilyID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&t.RevokedAt,
+		&t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Revoke marks the token as used. It returns ErrTokenRevoked if the token had
+// already been revoked, which makes concurrent refreshes of one token fail.
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrTokenRevoked
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), familyID)
+	return err
+}
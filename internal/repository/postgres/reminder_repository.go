@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReminderRepository stores booking reminders. Upsert and CancelPending are
+// called by the planner for the current organization; the remaining methods
+// are used by the scheduler across all organizations.
+type ReminderRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewReminderRepository(conn *pgxpool.Pool) *ReminderRepository {
+	return &ReminderRepository{conn: conn}
+}
+
+// Upsert plans the reminder. An existing reminder for the same booking and
+// offset is left alone unless its send time changed, so a replayed event
+// does not resend a reminder that went out already.
+func (r *ReminderRepository) Upsert(ctx context.Context, rem *entity.Reminder) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO reminders (organization_id, booking_id, offset_minutes, send_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (booking_id, offset_minutes) DO UPDATE
+		SET send_at = EXCLUDED.send_at, status = 'pending', attempts = 0,
+		    claimed_at = NULL, sent_at = NULL, last_error = NULL, updated_at = now()
+		WHERE reminders.organization_id = EXCLUDED.organization_id
+		  AND (reminders.send_at <> EXCLUDED.send_at OR reminders.status = 'cancelled')`
+
+	rem.OrganizationID = orgID
+
+	_, err = querierFrom(ctx, r.conn).Exec(ctx, query,
+		orgID,
+		rem.BookingID,
+		int(rem.Offset/time.Minute),
+		rem.SendAt,
+	)
+	return err
+}
+
+func (r *ReminderRepository) CancelPending(ctx context.Context, bookingID uuid.UUID, keepOffsets []time.Duration) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	keep := make([]int32, 0, len(keepOffsets))
+	for _, o := range keepOffsets {
+		keep = append(keep, int32(o/time.Minute))
+	}
+
+	query := `
+		UPDATE reminders
+		SET status = 'cancelled', updated_at = now()
+		WHERE booking_id = $1 AND organization_id = $2
+		  AND status = 'pending' AND NOT (offset_minutes = ANY($3))`
+
+	_, err = querierFrom(ctx, r.conn).Exec(ctx, query, bookingID, orgID, keep)
+	return err
+}
+
+// Claim moves up to limit due reminders of confirmed bookings to sending.
+// Concurrent schedulers skip each other's rows, and a claimed reminder is
+// never picked up again, so a reminder is sent at most once.
+func (r *ReminderRepository) Claim(ctx context.Context, limit int) ([]*entity.ReminderDispatch, error) {
+	query := `
+		WITH due AS (
+			SELECT rm.id FROM reminders rm
+			JOIN bookings b ON b.id = rm.booking_id
+			WHERE rm.status = 'pending' AND rm.send_at <= $1
+			  AND b.status = 'confirmed' AND b.start_time > $1
+			ORDER BY rm.send_at, rm.id
+			LIMIT $2
+			FOR UPDATE OF rm SKIP LOCKED
+		)
+		UPDATE reminders rm
+		SET status = 'sending', claimed_at = $1, attempts = rm.attempts + 1, updated_at = now()
+		FROM due, bookings b
+		WHERE rm.id = due.id AND b.id = rm.booking_id
+		RETURNING rm.id, rm.organization_id, rm.booking_id, rm.offset_minutes, rm.send_at, rm.attempts,
+		          rm.created_at, rm.updated_at,
+		          b.id, b.organization_id, b.master_id, b.client_id, b.service_id,
+		          b.start_time, b.end_time, b.status, b.created_at, b.updated_at`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dispatches := make([]*entity.ReminderDispatch, 0)
+	for rows.Next() {
+		rem := &entity.Reminder{Status: entity.ReminderStatusSending}
+		b := &entity.Booking{}
+		var offsetMinutes int
+		if err := rows.Scan(
+			&rem.ID,
+			&rem.OrganizationID,
+			&rem.BookingID,
+			&offsetMinutes,
+			&rem.SendAt,
+			&rem.Attempts,
+			&rem.CreatedAt,
+			&rem.UpdatedAt,
+			&b.ID,
+			&b.OrganizationID,
+			&b.MasterID,
+			&b.ClientID,
+			&b.ServiceID,
+			&b.StartTime,
+			&b.EndTime,
+			&b.Status,
+			&b.CreatedAt,
+			&b.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rem.Offset = time.Duration(offsetMinutes) * time.Minute
+		dispatches = append(dispatches, &entity.ReminderDispatch{Reminder: rem, Booking: b})
+	}
+	return dispatches, rows.Err()
+}
+
+func (r *ReminderRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE reminders
+		SET status = 'sent', sent_at = $1, last_error = NULL, updated_at = now()
+		WHERE id = $2`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id)
+	return err
+}
+
+// MarkFailed records the error. The reminder is pending again if retryAt is
+// set and failed otherwise.
+func (r *ReminderRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt *time.Time) error {
+	query := `
+		UPDATE reminders
+		SET status = CASE WHEN $1::timestamptz IS NULL THEN 'failed' ELSE 'pending' END::reminder_status,
+		    send_at = COALESCE($1, send_at), claimed_at = NULL, last_error = $2, updated_at = now()
+		WHERE id = $3`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, retryAt, lastErr, id)
+	return err
+}
+
+// FailStale fails reminders left in sending by a scheduler that stopped
+// mid-send. They are not retried as the notification may have gone out.
+func (r *ReminderRepository) FailStale(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	query := `
+		UPDATE reminders
+		SET status = 'failed', last_error = 'interrupted while sending', updated_at = now()
+		WHERE status = 'sending' AND claimed_at < $1`
+
+	tag, err := querierFrom(ctx, r.conn).Exec(ctx, query, claimedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,37 +21,72 @@ func NewScheduleRepository(conn *pgxpool.Pool) *ScheduleRepository {
 	return &ScheduleRepository{conn: conn}
 }
 
+const scheduleColumns = `id, organization_id, master_id, name, type, start_date, end_date, priority, is_template, created_at, updated_at`
+
+func scanSchedule(row pgx.Row) (*entity.Schedule, error) {
+	s := &entity.Schedule{}
+	var masterID *uuid.UUID
+	err := row.Scan(
+		&s.ID,
+		&s.OrganizationID,
+		&masterID,
+		&s.Name,
+		&s.Type,
+		&s.StartDate,
+		&s.EndDate,
+		&s.Priority,
+		&s.IsTemplate,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if masterID != nil {
+		s.MasterID = *masterID
+	}
+	return s, nil
+}
+
+// scheduleMasterID is the master_id column of the schedule, NULL for templates.
+func scheduleMasterID(s *entity.Schedule) *uuid.UUID {
+	if s.IsTemplate {
+		return nil
+	}
+	return &s.MasterID
+}
+
 func (r *ScheduleRepository) Create(ctx context.Context, s *entity.Schedule) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO schedules (master_id, name, type, start_date, end_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		INSERT INTO schedules (organization_id, master_id, name, type, start_date, end_date, priority, is_template, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
 		RETURNING id`
 
 	now := time.Now()
+	s.OrganizationID = orgID
 	s.CreatedAt = now
 	s.UpdatedAt = now
 
-	return r.conn.QueryRow(ctx, query, s.MasterID, s.Name, s.Type, s.StartDate, s.EndDate, now).Scan(&s.ID)
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query, orgID, scheduleMasterID(s), s.Name, s.Type, s.StartDate, s.EndDate, s.Priority, s.IsTemplate, now).Scan(&s.ID)
 }
 
 func (r *ScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, type, start_date, end_date, created_at, updated_at
+		SELECT ` + scheduleColumns + `
 		FROM schedules
-		WHERE id = $1`
-
-	schedule := &entity.Schedule{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
-		&schedule.ID,
-		&schedule.MasterID,
-		&schedule.Name,
-		&schedule.Type,
-		&schedule.StartDate,
-		&schedule.EndDate,
-		&schedule.CreatedAt,
-		&schedule.UpdatedAt,
-	)
+		WHERE id = $1 AND organization_id = $2`
 
+	schedule, err := scanSchedule(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, apiErrors.ErrNotFound
 	}
@@ -62,13 +98,18 @@ func (r *ScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 }
 
 func (r *ScheduleRepository) GetByMasterID(ctx context.Context, masterID uuid.UUID) ([]*entity.Schedule, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, type, start_date, end_date, created_at, updated_at
+		SELECT ` + scheduleColumns + `
 		FROM schedules
-		WHERE master_id = $1
+		WHERE master_id = $1 AND organization_id = $2
 		ORDER BY created_at DESC`
 
-	rows, err := r.conn.Query(ctx, query, masterID)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -76,8 +117,8 @@ func (r *ScheduleRepository) GetByMasterID(ctx context.Context, masterID uuid.UU
 
 	var schedules []*entity.Schedule
 	for rows.Next() {
-		s := &entity.Schedule{}
-		if err := rows.Scan(&s.ID, &s.MasterID, &s.Name, &s.Type, &s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		s, err := scanSchedule(rows)
+		if err != nil {
 			return nil, err
 		}
 		schedules = append(schedules, s)
@@ -86,13 +127,18 @@ func (r *ScheduleRepository) GetByMasterID(ctx context.Context, masterID uuid.UU
 }
 
 func (r *ScheduleRepository) GetForDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.Schedule, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, type, start_date, end_date, created_at, updated_at
+		SELECT ` + scheduleColumns + `
 		FROM schedules
-		WHERE master_id = $1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $2)
-		ORDER BY created_at DESC`
+		WHERE master_id = $1 AND start_date <= $2 AND (end_date IS NULL OR end_date >= $2) AND organization_id = $3
+		ORDER BY priority DESC, created_at DESC`
 
-	rows, err := r.conn.Query(ctx, query, masterID, date)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, date, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +146,8 @@ func (r *ScheduleRepository) GetForDate(ctx context.Context, masterID uuid.UUID,
 
 	var schedules []*entity.Schedule
 	for rows.Next() {
-		s := &entity.Schedule{}
-		if err := rows.Scan(&s.ID, &s.MasterID, &s.Name, &s.Type, &s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		s, err := scanSchedule(rows)
+		if err != nil {
 			return nil, err
 		}
 		schedules = append(schedules, s)
@@ -109,20 +155,62 @@ func (r *ScheduleRepository) GetForDate(ctx context.Context, masterID uuid.UUID,
 	return schedules, nil
 }
 
+// GetOverlapping returns the other schedules of the master with the same
+// priority whose periods overlap the one of the schedule.
+func (r *ScheduleRepository) GetOverlapping(ctx context.Context, schedule *entity.Schedule) ([]*entity.Schedule, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + scheduleColumns + `
+		FROM schedules
+		WHERE master_id = $1 AND priority = $2 AND id <> $3 AND NOT is_template
+			AND start_date <= COALESCE($5::date, 'infinity') AND COALESCE(end_date, 'infinity') >= $4
+			AND organization_id = $6
+		ORDER BY start_date`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, schedule.MasterID, schedule.Priority, schedule.ID, schedule.StartDate, schedule.EndDate, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*entity.Schedule
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+	return schedules, rows.Err()
+}
+
 func (r *ScheduleRepository) Update(ctx context.Context, schedule *entity.Schedule) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE schedules 
-		SET master_id = $1, name = $2, type = $3, start_date = $4, end_date = $5, updated_at = $6
-		WHERE id = $7`
+		SET master_id = $1, name = $2, type = $3, start_date = $4, end_date = $5, priority = $6, updated_at = $7
+		WHERE id = $8 AND organization_id = $9`
 
-	result, err := r.conn.Exec(ctx, query,
-		schedule.MasterID,
+	schedule.UpdatedAt = time.Now()
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
+		scheduleMasterID(schedule),
 		schedule.Name,
 		schedule.Type,
 		schedule.StartDate,
 		schedule.EndDate,
-		time.Now(),
+		schedule.Priority,
+		schedule.UpdatedAt,
 		schedule.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -136,9 +224,14 @@ func (r *ScheduleRepository) Update(ctx context.Context, schedule *entity.Schedu
 }
 
 func (r *ScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM schedules WHERE id = $1`
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
 
-	result, err := r.conn.Exec(ctx, query, id)
+	query := `DELETE FROM schedules WHERE id = $1 AND organization_id = $2`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -150,14 +243,29 @@ func (r *ScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// List returns the schedules of masters; templates are listed by ListTemplates.
 func (r *ScheduleRepository) List(ctx context.Context, offset, limit int) ([]*entity.Schedule, error) {
+	return r.list(ctx, false, offset, limit)
+}
+
+func (r *ScheduleRepository) ListTemplates(ctx context.Context, offset, limit int) ([]*entity.Schedule, error) {
+	return r.list(ctx, true, offset, limit)
+}
+
+func (r *ScheduleRepository) list(ctx context.Context, templates bool, offset, limit int) ([]*entity.Schedule, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, type, start_date, end_date, created_at, updated_at
+		SELECT ` + scheduleColumns + `
 		FROM schedules
+		WHERE organization_id = $3 AND is_template = $4
 		ORDER BY id
 		LIMIT $1 OFFSET $2`
 
-	rows, err := r.conn.Query(ctx, query, limit, offset)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, limit, offset, orgID, templates)
 	if err != nil {
 		return nil, err
 	}
@@ -165,17 +273,7 @@ func (r *ScheduleRepository) List(ctx context.Context, offset, limit int) ([]*en
 
 	var schedules []*entity.Schedule
 	for rows.Next() {
-		schedule := &entity.Schedule{}
-		err := rows.Scan(
-			schedule.ID,
-			schedule.MasterID,
-			schedule.Name,
-			schedule.Type,
-			schedule.StartDate,
-			schedule.EndDate,
-			schedule.CreatedAt,
-			schedule.UpdatedAt,
-		)
+		schedule, err := scanSchedule(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -190,26 +288,42 @@ func (r *ScheduleRepository) List(ctx context.Context, offset, limit int) ([]*en
 }
 
 func (r *ScheduleRepository) AddDay(ctx context.Context, day *entity.ScheduleDay) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO schedule_days (schedule_id, weekday, day_index, start_time, end_time, is_day_off, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $7
+		WHERE EXISTS (SELECT 1 FROM schedules WHERE id = $1 AND organization_id = $8)
 		RETURNING id`
 
 	now := time.Now()
 	day.CreatedAt = now
 	day.UpdatedAt = now
 
-	return r.conn.QueryRow(ctx, query, day.ScheduleID, day.Weekday, day.DayIndex, day.StartTime, day.EndTime, day.IsDayOff, now).Scan(&day.ID)
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, day.ScheduleID, day.Weekday, day.DayIndex, day.StartTime, day.EndTime, day.IsDayOff, now, orgID).Scan(&day.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apiErrors.ErrNotFound
+	}
+	return err
 }
 
 func (r *ScheduleRepository) GetDayByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleDay, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, schedule_id, weekday, day_index, start_time, end_time, is_day_off, created_at, updated_at
-		FROM schedule_days
-		WHERE id = $1`
+		SELECT d.id, d.schedule_id, d.weekday, d.day_index, d.start_time, d.end_time, d.is_day_off, d.created_at, d.updated_at
+		FROM schedule_days d
+		JOIN schedules w ON d.schedule_id = w.id
+		WHERE d.id = $1 AND w.organization_id = $2`
 
 	day := &entity.ScheduleDay{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
 		&day.ID,
 		&day.ScheduleID,
 		&day.Weekday,
@@ -232,13 +346,19 @@ func (r *ScheduleRepository) GetDayByID(ctx context.Context, id uuid.UUID) (*ent
 }
 
 func (r *ScheduleRepository) GetDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]*entity.ScheduleDay, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, schedule_id, weekday, day_index, start_time, end_time, is_day_off, created_at, updated_at
-		FROM schedule_days
-		WHERE schedule_id = $1
-		ORDER BY created_at DESC`
+		SELECT d.id, d.schedule_id, d.weekday, d.day_index, d.start_time, d.end_time, d.is_day_off, d.created_at, d.updated_at
+		FROM schedule_days d
+		JOIN schedules w ON d.schedule_id = w.id
+		WHERE d.schedule_id = $1 AND w.organization_id = $2
+		ORDER BY d.created_at DESC`
 
-	rows, err := r.conn.Query(ctx, query, scheduleID)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, scheduleID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -265,15 +385,20 @@ func (r *ScheduleRepository) GetDaysByScheduleID(ctx context.Context, scheduleID
 	return days, nil
 }
 
-func (r *ScheduleRepository) GetDaysByWeekday(ctx context.Context, masterID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
+func (r *ScheduleRepository) GetDaysByWeekday(ctx context.Context, scheduleID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT d.id, d.schedule_id, d.weekday, d.day_index, d.start_time, d.end_time, d.is_day_off, d.created_at, d.updated_at
 		FROM schedule_days d
 		JOIN schedules w ON d.schedule_id = w.id
-		WHERE w.master_id = $1 AND d.weekday = $2
-		ORDER BY w.start_date DESC`
+		WHERE d.schedule_id = $1 AND d.weekday = $2 AND w.organization_id = $3
+		ORDER BY d.start_time`
 
-	rows, err := r.conn.Query(ctx, query, masterID, weekday)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, scheduleID, weekday, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -290,15 +415,20 @@ func (r *ScheduleRepository) GetDaysByWeekday(ctx context.Context, masterID uuid
 	return days, nil
 }
 
-func (r *ScheduleRepository) GetDaysByDayIndex(ctx context.Context, masterID uuid.UUID, dayIndex int) ([]*entity.ScheduleDay, error) {
+func (r *ScheduleRepository) GetDaysByDayIndex(ctx context.Context, scheduleID uuid.UUID, dayIndex int) ([]*entity.ScheduleDay, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT d.id, d.schedule_id, d.weekday, d.day_index, d.start_time, d.end_time, d.is_day_off, d.created_at, d.updated_at
 		FROM schedule_days d
 		JOIN schedules w ON d.schedule_id = w.id
-		WHERE w.master_id = $1 AND d.day_index = $2
-		ORDER BY w.start_date DESC`
+		WHERE d.schedule_id = $1 AND d.day_index = $2 AND w.organization_id = $3
+		ORDER BY d.start_time`
 
-	rows, err := r.conn.Query(ctx, query, masterID, dayIndex)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, scheduleID, dayIndex, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -316,12 +446,19 @@ func (r *ScheduleRepository) GetDaysByDayIndex(ctx context.Context, masterID uui
 }
 
 func (r *ScheduleRepository) UpdateDay(ctx context.Context, day *entity.ScheduleDay) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		UPDATE schedule_days 
+		UPDATE schedule_days d
 		SET schedule_id = $1, weekday = $2, day_index = $3, start_time = $4, end_time = $5, is_day_off = $6, updated_at = $7
-		WHERE id = $8`
+		WHERE d.id = $8
+			AND EXISTS (SELECT 1 FROM schedules w WHERE w.id = d.schedule_id AND w.organization_id = $9)
+			AND EXISTS (SELECT 1 FROM schedules w WHERE w.id = $1 AND w.organization_id = $9)`
 
-	result, err := r.conn.Exec(ctx, query,
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
 		day.ScheduleID,
 		day.Weekday,
 		day.DayIndex,
@@ -330,6 +467,7 @@ func (r *ScheduleRepository) UpdateDay(ctx context.Context, day *entity.Schedule
 		day.IsDayOff,
 		time.Now(),
 		day.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -343,9 +481,17 @@ func (r *ScheduleRepository) UpdateDay(ctx context.Context, day *entity.Schedule
 }
 
 func (r *ScheduleRepository) DeleteDay(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM schedule_days WHERE id = $1`
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM schedule_days d
+		USING schedules w
+		WHERE d.id = $1 AND w.id = d.schedule_id AND w.organization_id = $2`
 
-	result, err := r.conn.Exec(ctx, query, id)
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -357,15 +503,36 @@ func (r *ScheduleRepository) DeleteDay(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+// DeleteDaysByScheduleID deletes all days of the schedule.
+func (r *ScheduleRepository) DeleteDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM schedule_days d
+		USING schedules w
+		WHERE d.schedule_id = $1 AND w.id = d.schedule_id AND w.organization_id = $2`
+
+	_, err = querierFrom(ctx, r.conn).Exec(ctx, query, scheduleID, orgID)
+	return err
+}
+
 func (r *ScheduleRepository) GetDaysCount(ctx context.Context, scheduleID uuid.UUID) (int, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	query := `
 		SELECT COUNT(*)
-		FROM schedule_days
-		WHERE schedule_id = $1
-		ORDER BY created_at DESC`
+		FROM schedule_days d
+		JOIN schedules w ON d.schedule_id = w.id
+		WHERE d.schedule_id = $1 AND w.organization_id = $2`
 
 	var count int
-	err := r.conn.QueryRow(ctx, query, scheduleID).Scan(&count)
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, scheduleID, orgID).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -373,26 +540,59 @@ func (r *ScheduleRepository) GetDaysCount(ctx context.Context, scheduleID uuid.U
 }
 
 func (r *ScheduleRepository) AddSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO schedule_slots (schedule_id, date, start_time, end_time, is_day_off, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		SELECT $1, $2, $3, $4, $5, $6, $6
+		WHERE EXISTS (SELECT 1 FROM schedules WHERE id = $1 AND organization_id = $7)
 		RETURNING id`
 
 	now := time.Now()
 	slot.CreatedAt = now
 	slot.UpdatedAt = now
 
-	return r.conn.QueryRow(ctx, query, slot.ScheduleID, slot.Date, slot.StartTime, slot.EndTime, slot.IsDayOff, now).Scan(&slot.ID)
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, slot.ScheduleID, slot.Date, slot.StartTime, slot.EndTime, slot.IsDayOff, now, orgID).Scan(&slot.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apiErrors.ErrNotFound
+	}
+	return err
+}
+
+// DeleteSlotsInRange deletes the date overrides of the schedule between from
+// and to, inclusive.
+func (r *ScheduleRepository) DeleteSlotsInRange(ctx context.Context, scheduleID uuid.UUID, from, to time.Time) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM schedule_slots s
+		USING schedules w
+		WHERE s.schedule_id = $1 AND s.date BETWEEN $2 AND $3 AND w.id = s.schedule_id AND w.organization_id = $4`
+
+	_, err = querierFrom(ctx, r.conn).Exec(ctx, query, scheduleID, from, to, orgID)
+	return err
 }
 
 func (r *ScheduleRepository) GetSlotByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, schedule_id, date, start_time, end_time, is_day_off, created_at, updated_at
-		FROM schedule_slots
-		WHERE id = $1`
+		SELECT s.id, s.schedule_id, s.date, s.start_time, s.end_time, s.is_day_off, s.created_at, s.updated_at
+		FROM schedule_slots s
+		JOIN schedules w ON s.schedule_id = w.id
+		WHERE s.id = $1 AND w.organization_id = $2`
 
 	slot := &entity.ScheduleSlot{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
 		&slot.ID,
 		&slot.ScheduleID,
 		&slot.Date,
@@ -414,13 +614,19 @@ func (r *ScheduleRepository) GetSlotByID(ctx context.Context, id uuid.UUID) (*en
 }
 
 func (r *ScheduleRepository) GetSlotsByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]*entity.ScheduleSlot, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, schedule_id, date, start_time, end_time, is_day_off, created_at, updated_at
-		FROM schedule_slots
-		WHERE schedule_id = $1
-		ORDER BY created_at DESC`
+		SELECT s.id, s.schedule_id, s.date, s.start_time, s.end_time, s.is_day_off, s.created_at, s.updated_at
+		FROM schedule_slots s
+		JOIN schedules w ON s.schedule_id = w.id
+		WHERE s.schedule_id = $1 AND w.organization_id = $2
+		ORDER BY s.created_at DESC`
 
-	rows, err := r.conn.Query(ctx, query, scheduleID)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, scheduleID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -430,14 +636,14 @@ func (r *ScheduleRepository) GetSlotsByScheduleID(ctx context.Context, scheduleI
 	for rows.Next() {
 		slot := &entity.ScheduleSlot{}
 		if err := rows.Scan(
-			slot.ID,
-			slot.ScheduleID,
-			slot.Date,
-			slot.StartTime,
-			slot.EndTime,
-			slot.IsDayOff,
-			slot.CreatedAt,
-			slot.UpdatedAt,
+			&slot.ID,
+			&slot.ScheduleID,
+			&slot.Date,
+			&slot.StartTime,
+			&slot.EndTime,
+			&slot.IsDayOff,
+			&slot.CreatedAt,
+			&slot.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -446,14 +652,85 @@ func (r *ScheduleRepository) GetSlotsByScheduleID(ctx context.Context, scheduleI
 	return slots, nil
 }
 
+// GetDetails returns the schedule with its days and date overrides. The
+// overrides are limited to the dates between from and to, if given.
+func (r *ScheduleRepository) GetDetails(ctx context.Context, id uuid.UUID, from, to *time.Time) (*entity.ScheduleDetails, error) {
+	schedule, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT d.id, d.schedule_id, d.weekday, d.day_index, d.start_time, d.end_time, d.is_day_off, d.created_at, d.updated_at
+		FROM schedule_days d
+		JOIN schedules w ON d.schedule_id = w.id
+		WHERE d.schedule_id = $1 AND w.organization_id = $2
+		ORDER BY d.weekday NULLS LAST, d.day_index NULLS LAST, d.start_time`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	details := &entity.ScheduleDetails{Schedule: schedule, Days: []*entity.ScheduleDay{}, Slots: []*entity.ScheduleSlot{}}
+	for rows.Next() {
+		day := &entity.ScheduleDay{}
+		if err := rows.Scan(&day.ID, &day.ScheduleID, &day.Weekday, &day.DayIndex, &day.StartTime, &day.EndTime, &day.IsDayOff, &day.CreatedAt, &day.UpdatedAt); err != nil {
+			return nil, err
+		}
+		details.Days = append(details.Days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT s.id, s.schedule_id, s.date, s.start_time, s.end_time, s.is_day_off, s.created_at, s.updated_at
+		FROM schedule_slots s
+		JOIN schedules w ON s.schedule_id = w.id
+		WHERE s.schedule_id = $1 AND w.organization_id = $2
+		  AND ($3::date IS NULL OR s.date >= $3) AND ($4::date IS NULL OR s.date <= $4)
+		ORDER BY s.date, s.start_time`
+
+	slotRows, err := querierFrom(ctx, r.conn).Query(ctx, query, id, orgID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer slotRows.Close()
+
+	for slotRows.Next() {
+		slot := &entity.ScheduleSlot{}
+		if err := slotRows.Scan(&slot.ID, &slot.ScheduleID, &slot.Date, &slot.StartTime, &slot.EndTime, &slot.IsDayOff, &slot.CreatedAt, &slot.UpdatedAt); err != nil {
+			return nil, err
+		}
+		details.Slots = append(details.Slots, slot)
+	}
+	if err := slotRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
 func (r *ScheduleRepository) GetSlotsByDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.ScheduleSlot, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT s.id, s.schedule_id, s.date, s.start_time, s.end_time, s.is_day_off, s.created_at, s.updated_at
 		FROM schedule_slots s
 		JOIN schedules w ON s.schedule_id = w.id
-		WHERE w.master_id = $1 AND s.date = $2`
+		WHERE w.master_id = $1 AND s.date = $2 AND w.organization_id = $3`
 
-	rows, err := r.conn.Query(ctx, query, masterID, date)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, date, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -472,12 +749,19 @@ func (r *ScheduleRepository) GetSlotsByDate(ctx context.Context, masterID uuid.U
 }
 
 func (r *ScheduleRepository) UpdateSlot(ctx context.Context, day *entity.ScheduleSlot) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		UPDATE schedule_slots 
+		UPDATE schedule_slots s
 		SET schedule_id = $1, date = $2, start_time = $3, end_time = $4, is_day_off = $5, updated_at = $6
-		WHERE id = $7`
+		WHERE s.id = $7
+			AND EXISTS (SELECT 1 FROM schedules w WHERE w.id = s.schedule_id AND w.organization_id = $8)
+			AND EXISTS (SELECT 1 FROM schedules w WHERE w.id = $1 AND w.organization_id = $8)`
 
-	result, err := r.conn.Exec(ctx, query,
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
 		day.ScheduleID,
 		day.Date,
 		day.StartTime,
@@ -485,6 +769,7 @@ func (r *ScheduleRepository) UpdateSlot(ctx context.Context, day *entity.Schedul
 		day.IsDayOff,
 		time.Now(),
 		day.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -498,9 +783,17 @@ func (r *ScheduleRepository) UpdateSlot(ctx context.Context, day *entity.Schedul
 }
 
 func (r *ScheduleRepository) DeleteSlot(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM schedule_slots WHERE id = $1`
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM schedule_slots s
+		USING schedules w
+		WHERE s.id = $1 AND w.id = s.schedule_id AND w.organization_id = $2`
 
-	result, err := r.conn.Exec(ctx, query, id)
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,15 +22,22 @@ func NewServiceRepository(conn *pgxpool.Pool) *ServiceRepository {
 }
 
 func (r *ServiceRepository) Create(ctx context.Context, service *entity.Service) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO services (master_id, name, description, duration, price, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		INSERT INTO services (organization_id, master_id, name, description, duration, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
 		RETURNING id`
 	now := time.Now()
+	service.OrganizationID = orgID
 	service.CreatedAt = now
 	service.UpdatedAt = now
 
-	return r.conn.QueryRow(ctx, query,
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
 		service.MasterID,
 		service.Name,
 		service.Description,
@@ -40,14 +48,20 @@ func (r *ServiceRepository) Create(ctx context.Context, service *entity.Service)
 }
 
 func (r *ServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Service, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, description, duration, price, created_at, updated_at
+		SELECT id, organization_id, master_id, name, description, duration, price, created_at, updated_at
 		FROM services
-		WHERE id = $1`
+		WHERE id = $1 AND organization_id = $2`
 
 	service := &entity.Service{}
-	err := r.conn.QueryRow(ctx, query, id).Scan(
+	err = querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID).Scan(
 		&service.ID,
+		&service.OrganizationID,
 		&service.MasterID,
 		&service.Name,
 		&service.Description,
@@ -66,13 +80,18 @@ func (r *ServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 }
 
 func (r *ServiceRepository) GetByMasterID(ctx context.Context, masterID uuid.UUID) ([]*entity.Service, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, master_id, name, description, duration, price, created_at, updated_at
+		SELECT id, organization_id, master_id, name, description, duration, price, created_at, updated_at
 		FROM services
-		WHERE master_id = $1
+		WHERE master_id = $1 AND organization_id = $2
 		ORDER BY name`
 
-	rows, err := r.conn.Query(ctx, query, masterID)
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, masterID, orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +102,7 @@ func (r *ServiceRepository) GetByMasterID(ctx context.Context, masterID uuid.UUI
 		s := &entity.Service{}
 		if err := rows.Scan(
 			&s.ID,
+			&s.OrganizationID,
 			&s.MasterID,
 			&s.Name,
 			&s.Description,
@@ -100,18 +120,24 @@ func (r *ServiceRepository) GetByMasterID(ctx context.Context, masterID uuid.UUI
 }
 
 func (r *ServiceRepository) Update(ctx context.Context, service *entity.Service) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE services
 		SET name=$1, description=$2, duration=$3, price=$4, updated_at=$5
-		WHERE id=$6`
+		WHERE id=$6 AND organization_id=$7`
 
-	result, err := r.conn.Exec(ctx, query,
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
 		service.Name,
 		service.Description,
 		service.Duration,
 		service.Price,
 		time.Now(),
 		service.ID,
+		orgID,
 	)
 	if err != nil {
 		return err
@@ -123,8 +149,13 @@ func (r *ServiceRepository) Update(ctx context.Context, service *entity.Service)
 }
 
 func (r *ServiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM services WHERE id=$1`
-	result, err := r.conn.Exec(ctx, query, id)
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM services WHERE id=$1 AND organization_id=$2`
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
 	if err != nil {
 		return err
 	}
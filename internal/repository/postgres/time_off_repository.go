@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TimeOffRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewTimeOffRepository(conn *pgxpool.Pool) *TimeOffRepository {
+	return &TimeOffRepository{conn: conn}
+}
+
+const timeOffColumns = `id, organization_id, master_id, start_date, end_date, reason, status, reviewed_by, reviewed_at, review_comment, created_at, updated_at`
+
+func scanTimeOff(row pgx.Row) (*entity.TimeOffRequest, error) {
+	r := &entity.TimeOffRequest{}
+	err := row.Scan(
+		&r.ID,
+		&r.OrganizationID,
+		&r.MasterID,
+		&r.StartDate,
+		&r.EndDate,
+		&r.Reason,
+		&r.Status,
+		&r.ReviewedBy,
+		&r.ReviewedAt,
+		&r.ReviewComment,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *TimeOffRepository) Create(ctx context.Context, req *entity.TimeOffRequest) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO time_off_requests (organization_id, master_id, start_date, end_date, reason, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		RETURNING id`
+
+	now := time.Now()
+	req.OrganizationID = orgID
+	req.CreatedAt = now
+	req.UpdatedAt = now
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query, orgID, req.MasterID, req.StartDate, req.EndDate, req.Reason, req.Status, now).Scan(&req.ID)
+}
+
+func (r *TimeOffRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.TimeOffRequest, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + timeOffColumns + ` FROM time_off_requests WHERE id = $1 AND organization_id = $2`
+
+	req, err := scanTimeOff(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	return req, err
+}
+
+func (r *TimeOffRepository) List(ctx context.Context, masterID uuid.UUID, status entity.TimeOffStatus, offset, limit int) ([]*entity.TimeOffRequest, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + timeOffColumns + `
+		FROM time_off_requests
+		WHERE ($1::uuid IS NULL OR master_id = $1) AND ($2::text = '' OR status::text = $2) AND organization_id = $3
+		ORDER BY start_date DESC, created_at DESC
+		LIMIT $4 OFFSET $5`
+
+	var master *uuid.UUID
+	if masterID != uuid.Nil {
+		master = &masterID
+	}
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, master, string(status), orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]*entity.TimeOffRequest, 0)
+	for rows.Next() {
+		req, err := scanTimeOff(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, rows.Err()
+}
+
+func (r *TimeOffRepository) Review(ctx context.Context, req *entity.TimeOffRequest) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE time_off_requests
+		SET status = $1, reviewed_by = $2, reviewed_at = $3, review_comment = $4, updated_at = $3
+		WHERE id = $5 AND status = 'pending' AND organization_id = $6`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, req.Status, req.ReviewedBy, req.ReviewedAt, req.ReviewComment, req.ID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrTimeOffReviewed
+	}
+	req.UpdatedAt = *req.ReviewedAt
+	return nil
+}
+
+func (r *TimeOffRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx,
+		`DELETE FROM time_off_requests WHERE id = $1 AND status = 'pending' AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrTimeOffReviewed
+	}
+	return nil
+}
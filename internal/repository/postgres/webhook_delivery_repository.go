@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookDeliveryRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(conn *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{conn: conn}
+}
+
+const webhookDeliveryColumns = `
+	id, organization_id, subscription_id, event_id, event_type, body, status,
+	attempts, next_attempt_at, last_error, delivered_at, created_at`
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, d *entity.WebhookDelivery) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (organization_id, subscription_id, event_id, event_type, body, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	now := time.Now()
+	d.OrganizationID = orgID
+	d.Status = entity.WebhookDeliveryPending
+	d.CreatedAt = now
+	d.NextAttemptAt = now
+
+	_, err = querierFrom(ctx, r.conn).Exec(ctx, query,
+		orgID,
+		d.SubscriptionID,
+		d.EventID,
+		d.EventType,
+		d.Body,
+		now,
+	)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = $1 AND organization_id = $2`
+
+	d, err := scanWebhookDelivery(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*entity.WebhookDelivery, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND organization_id = $2
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, subscriptionID, orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entity.WebhookAttempt, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT a.id, a.delivery_id, a.attempted_at, a.status_code, a.error, a.duration_ms
+		FROM webhook_delivery_attempts a
+		JOIN webhook_deliveries d ON d.id = a.delivery_id
+		WHERE a.delivery_id = $1 AND d.organization_id = $2
+		ORDER BY a.attempted_at`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, deliveryID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := make([]*entity.WebhookAttempt, 0)
+	for rows.Next() {
+		a := &entity.WebhookAttempt{}
+		if err := rows.Scan(
+			&a.ID,
+			&a.DeliveryID,
+			&a.AttemptedAt,
+			&a.StatusCode,
+			&a.Error,
+			&a.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
+
+// Retry returns a dead delivery to the queue with a fresh attempt budget.
+func (r *WebhookDeliveryRepository) Retry(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = $1
+		WHERE id = $2 AND organization_id = $3 AND status = 'dead'`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+// Claim leases up to limit due deliveries of active subscriptions, see
+// OutboxRepository.Claim.
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDispatch, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $2 AND s.active
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $1
+		FROM due, webhook_subscriptions s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING d.id, d.organization_id, d.subscription_id, d.event_id, d.event_type, d.body, d.status,
+			d.attempts, d.next_attempt_at, d.last_error, d.delivered_at, d.created_at, s.url, s.secret`
+
+	now := time.Now()
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dispatches := make([]*entity.WebhookDispatch, 0)
+	for rows.Next() {
+		d := &entity.WebhookDelivery{}
+		dispatch := &entity.WebhookDispatch{Delivery: d}
+		if err := rows.Scan(
+			&d.ID,
+			&d.OrganizationID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Body,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.LastError,
+			&d.DeliveredAt,
+			&d.CreatedAt,
+			&dispatch.URL,
+			&dispatch.Secret,
+		); err != nil {
+			return nil, err
+		}
+		dispatches = append(dispatches, dispatch)
+	}
+	return dispatches, rows.Err()
+}
+
+func (r *WebhookDeliveryRepository) RecordAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error {
+	query := `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		attempt.DeliveryID,
+		attempt.AttemptedAt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.DurationMs,
+	).Scan(&attempt.ID)
+}
+
+func (r *WebhookDeliveryRepository) MarkDelivered(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, delivered_at = $1, last_error = NULL
+		WHERE id = $2`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, time.Now(), id)
+	return err
+}
+
+// MarkFailed records the failed attempt; dead deliveries are not retried.
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			next_attempt_at = $1,
+			last_error = $2,
+			status = CASE WHEN $3 THEN 'dead'::webhook_delivery_status ELSE status END
+		WHERE id = $4`
+
+	_, err := querierFrom(ctx, r.conn).Exec(ctx, query, nextAttemptAt, lastErr, dead, id)
+	return err
+}
+
+func scanWebhookDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	d := &entity.WebhookDelivery{}
+	err := row.Scan(
+		&d.ID,
+		&d.OrganizationID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&d.Body,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastError,
+		&d.DeliveredAt,
+		&d.CreatedAt,
+	)
+	return d, err
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewWebhookRepository(conn *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{conn: conn}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, sub *entity.WebhookSubscription) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_subscriptions (organization_id, url, secret, event_types, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id`
+
+	now := time.Now()
+	sub.OrganizationID = orgID
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	return querierFrom(ctx, r.conn).QueryRow(ctx, query,
+		orgID,
+		sub.URL,
+		sub.Secret,
+		eventTypesToStrings(sub.EventTypes),
+		sub.Active,
+		now,
+	).Scan(&sub.ID)
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, organization_id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		WHERE id = $1 AND organization_id = $2`
+
+	sub, err := scanWebhook(querierFrom(ctx, r.conn).QueryRow(ctx, query, id, orgID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apiErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (r *WebhookRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return r.list(ctx, false)
+}
+
+// ListActive returns the subscriptions that receive events.
+func (r *WebhookRepository) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return r.list(ctx, true)
+}
+
+func (r *WebhookRepository) list(ctx context.Context, activeOnly bool) ([]*entity.WebhookSubscription, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, organization_id, url, secret, event_types, active, created_at, updated_at
+		FROM webhook_subscriptions
+		WHERE organization_id = $1 AND (active OR NOT $2)
+		ORDER BY created_at`
+
+	rows, err := querierFrom(ctx, r.conn).Query(ctx, query, orgID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := make([]*entity.WebhookSubscription, 0)
+	for rows.Next() {
+		sub, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, sub *entity.WebhookSubscription) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $1, event_types = $2, active = $3, updated_at = $4
+		WHERE id = $5 AND organization_id = $6`
+
+	sub.UpdatedAt = time.Now()
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query,
+		sub.URL,
+		eventTypesToStrings(sub.EventTypes),
+		sub.Active,
+		sub.UpdatedAt,
+		sub.ID,
+		orgID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1 AND organization_id = $2`
+
+	result, err := querierFrom(ctx, r.conn).Exec(ctx, query, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apiErrors.ErrNotFound
+	}
+	return nil
+}
+
+func scanWebhook(row pgx.Row) (*entity.WebhookSubscription, error) {
+	sub := &entity.WebhookSubscription{}
+	var eventTypes []string
+	if err := row.Scan(
+		&sub.ID,
+		&sub.OrganizationID,
+		&sub.URL,
+		&sub.Secret,
+		&eventTypes,
+		&sub.Active,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	sub.EventTypes = make([]entity.EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = entity.EventType(t)
+	}
+	return sub, nil
+}
+
+func eventTypesToStrings(types []entity.EventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
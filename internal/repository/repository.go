@@ -8,11 +8,46 @@ import (
 	"github.com/google/uuid"
 )
 
-//go:generate mockgen -destination=mock/mock_repository.go -package=mock github.com/curserio/chrono-api/internal/repository MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository
+//go:generate mockgen -destination=mock/mock_repository.go -package=mock github.com/curserio/chrono-api/internal/repository TxManager,OrganizationRepository,AdminRepository,RefreshTokenRepository,APIKeyRepository,MasterRepository,ScheduleRepository,ServiceRepository,BookingRepository,ClientRepository,OutboxRepository,WebhookRepository,WebhookDeliveryRepository,ReminderRepository,CalendarFeedRepository,BusyBlockRepository,CalendarSourceRepository,CalDAVAccountRepository,TimeOffRepository
+
+// TxManager runs fn in a transaction carried by its context. Repository
+// calls made with that context take part in the transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *entity.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+	Update(ctx context.Context, org *entity.Organization) error
+}
+
+type AdminRepository interface {
+	Create(ctx context.Context, admin *entity.Admin) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error)
+	GetByEmail(ctx context.Context, email string) (*entity.Admin, error)
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// GetByHash is not scoped by tenant: the key itself identifies the organization
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	TouchLastUsed(ctx context.Context, id uuid.UUID) error
+}
 
 type MasterRepository interface {
 	Create(ctx context.Context, master *entity.Master) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Master, error)
+	GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Master, error)
 	Update(ctx context.Context, master *entity.Master) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int) ([]*entity.Master, error)
@@ -23,24 +58,29 @@ type ScheduleRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error)
 	GetByMasterID(ctx context.Context, masterID uuid.UUID) ([]*entity.Schedule, error)
 	GetForDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.Schedule, error)
+	GetOverlapping(ctx context.Context, schedule *entity.Schedule) ([]*entity.Schedule, error)
 	Update(ctx context.Context, schedule *entity.Schedule) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int) ([]*entity.Schedule, error)
+	ListTemplates(ctx context.Context, offset, limit int) ([]*entity.Schedule, error)
 
 	AddDay(ctx context.Context, day *entity.ScheduleDay) error
 	GetDayByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleDay, error)
 	GetDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]*entity.ScheduleDay, error)
-	GetDaysByWeekday(ctx context.Context, masterID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error)
-	GetDaysByDayIndex(ctx context.Context, masterID uuid.UUID, dayIndex int) ([]*entity.ScheduleDay, error)
+	GetDaysByWeekday(ctx context.Context, scheduleID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error)
+	GetDaysByDayIndex(ctx context.Context, scheduleID uuid.UUID, dayIndex int) ([]*entity.ScheduleDay, error)
 	UpdateDay(ctx context.Context, day *entity.ScheduleDay) error
 	DeleteDay(ctx context.Context, id uuid.UUID) error
+	DeleteDaysByScheduleID(ctx context.Context, scheduleID uuid.UUID) error
 
 	GetDaysCount(ctx context.Context, scheduleID uuid.UUID) (int, error)
 
 	AddSlot(ctx context.Context, slot *entity.ScheduleSlot) error
+	DeleteSlotsInRange(ctx context.Context, scheduleID uuid.UUID, from, to time.Time) error
 	GetSlotByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error)
 	GetSlotsByScheduleID(ctx context.Context, scheduleID uuid.UUID) ([]*entity.ScheduleSlot, error)
 	GetSlotsByDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]*entity.ScheduleSlot, error)
+	GetDetails(ctx context.Context, id uuid.UUID, from, to *time.Time) (*entity.ScheduleDetails, error)
 	UpdateSlot(ctx context.Context, day *entity.ScheduleSlot) error
 	DeleteSlot(ctx context.Context, id uuid.UUID) error
 }
@@ -59,13 +99,120 @@ type BookingRepository interface {
 	GetByMasterID(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.Booking, error)
 	GetByClientID(ctx context.Context, clientID uuid.UUID) ([]*entity.Booking, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.BookingStatus) error
+	UpdateTime(ctx context.Context, id uuid.UUID, start, end time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Revenue sums the booking prices per group, ordered by period
+	Revenue(ctx context.Context, q entity.RevenueQuery) ([]*entity.RevenueRow, error)
 }
 
 type ClientRepository interface {
 	Create(ctx context.Context, client *entity.Client) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Client, error)
+	GetByTelegramID(ctx context.Context, telegramID int64) (*entity.Client, error)
 	Update(ctx context.Context, client *entity.Client) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int) ([]*entity.Client, error)
 }
+
+type OutboxRepository interface {
+	Add(ctx context.Context, event *entity.Event) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.Event, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	// MarkFailed records the sinks that accepted the event so far; dead
+	// events are not retried
+	MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, deliveredSinks []string, dead bool) error
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, sub *entity.WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	Update(ctx context.Context, sub *entity.WebhookSubscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// Create ignores a repeated delivery of the same event to the same subscription
+	Create(ctx context.Context, d *entity.WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*entity.WebhookDelivery, error)
+	ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entity.WebhookAttempt, error)
+	Retry(ctx context.Context, id uuid.UUID) error
+
+	// The methods below are used by the delivery worker across all organizations
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDispatch, error)
+	RecordAttempt(ctx context.Context, attempt *entity.WebhookAttempt) error
+	MarkDelivered(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastErr string, dead bool) error
+}
+
+type ReminderRepository interface {
+	// Upsert plans the reminder; an existing one is re-planned only if its send time changed
+	Upsert(ctx context.Context, r *entity.Reminder) error
+	// CancelPending cancels pending reminders of the booking except those with the kept offsets
+	CancelPending(ctx context.Context, bookingID uuid.UUID, keepOffsets []time.Duration) error
+
+	// The methods below are used by the scheduler across all organizations
+	Claim(ctx context.Context, limit int) ([]*entity.ReminderDispatch, error)
+	MarkSent(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastErr string, retryAt *time.Time) error
+	FailStale(ctx context.Context, claimedBefore time.Time) (int64, error)
+}
+
+type CalendarFeedRepository interface {
+	// Upsert creates the feed of the owner or rotates its token
+	Upsert(ctx context.Context, feed *entity.CalendarFeed) error
+	Delete(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) error
+	// GetByHash looks up a feed across all organizations
+	GetByHash(ctx context.Context, hash string) (*entity.CalendarFeed, error)
+}
+
+type BusyBlockRepository interface {
+	// Upsert creates the block or updates the one with the same master and
+	// UID. A nil Href keeps the stored one.
+	Upsert(ctx context.Context, block *entity.BusyBlock) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.BusyBlock, error)
+	GetByHref(ctx context.Context, masterID uuid.UUID, href string) (*entity.BusyBlock, error)
+	// ListByMaster returns the blocks overlapping [from, to)
+	ListByMaster(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.BusyBlock, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByUIDs deletes blocks of the master, e.g. for cancelled events
+	DeleteByUIDs(ctx context.Context, masterID uuid.UUID, uids []string) (int, error)
+	// DeleteStale deletes the blocks of the source that are not in keepUIDs
+	DeleteStale(ctx context.Context, sourceID uuid.UUID, keepUIDs []string) (int, error)
+}
+
+type CalendarSourceRepository interface {
+	Create(ctx context.Context, source *entity.CalendarSource) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.CalendarSource, error)
+	ListByMaster(ctx context.Context, masterID uuid.UUID) ([]*entity.CalendarSource, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// MarkSynced records the outcome of a sync; lastErr is nil on success
+	MarkSynced(ctx context.Context, id uuid.UUID, lastErr *string) error
+
+	// Claim is used by the syncer across all organizations. It postpones the
+	// claimed sources by interval, so other replicas skip them.
+	Claim(ctx context.Context, limit int, interval time.Duration) ([]*entity.CalendarSource, error)
+}
+
+type CalDAVAccountRepository interface {
+	// Upsert creates the account of the master or rotates its password
+	Upsert(ctx context.Context, account *entity.CalDAVAccount) error
+	Delete(ctx context.Context, masterID uuid.UUID) error
+	// GetByHash looks up an account across all organizations
+	GetByHash(ctx context.Context, hash string) (*entity.CalDAVAccount, error)
+}
+
+type TimeOffRepository interface {
+	Create(ctx context.Context, req *entity.TimeOffRequest) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.TimeOffRequest, error)
+	// List returns the requests of the master with the status, newest first;
+	// uuid.Nil and an empty status match any
+	List(ctx context.Context, masterID uuid.UUID, status entity.TimeOffStatus, offset, limit int) ([]*entity.TimeOffRequest, error)
+	// Review stores the status and review of a pending request. It returns
+	// ErrTimeOffReviewed for requests reviewed in the meantime.
+	Review(ctx context.Context, req *entity.TimeOffRequest) error
+	// Delete withdraws a pending request
+	Delete(ctx context.Context, id uuid.UUID) error
+}
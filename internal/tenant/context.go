@@ -0,0 +1,25 @@
+package tenant
+
+import (
+	"context"
+
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/google/uuid"
+)
+
+type organizationKey struct{}
+
+// WithOrganizationID returns a copy of ctx scoped to the given organization.
+func WithOrganizationID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationKey{}, id)
+}
+
+// OrganizationID returns the organization the request is scoped to.
+// Repositories use it to filter every query, so a missing tenant is an error.
+func OrganizationID(ctx context.Context) (uuid.UUID, error) {
+	id, ok := ctx.Value(organizationKey{}).(uuid.UUID)
+	if !ok || id == uuid.Nil {
+		return uuid.Nil, apiErrors.ErrOrganizationRequired
+	}
+	return id, nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+)
+
+type APIKeyUseCase struct {
+	repo repository.APIKeyRepository
+}
+
+func NewAPIKeyUseCase(repo repository.APIKeyRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{repo: repo}
+}
+
+// CreateAPIKey creates a key for the organization in the context and returns
+// it together with the plain key, which is not stored and cannot be shown again.
+// A nil expiresAt creates a key that does not expire.
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, name string, scopes []string, expiresAt *time.Time) (*entity.APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", &apiErrors.ValidationError{Field: "expires_at", Err: apiErrors.ErrAPIKeyExpiry}
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate api key: %w", err)
+	}
+
+	apiKey := &entity.APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := uc.repo.Create(ctx, apiKey); err != nil {
+		return nil, "", fmt.Errorf("create api key: %w", err)
+	}
+
+	return apiKey, key, nil
+}
+
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	return uc.repo.Revoke(ctx, id)
+}
+
+// AuthenticateAPIKey returns the integration principal of a valid key.
+// Unknown, revoked and expired keys are unauthorized.
+func (uc *APIKeyUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	apiKey, err := uc.repo.GetByHash(ctx, auth.HashToken(key))
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, apiErrors.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !time.Now().Before(*apiKey.ExpiresAt)) {
+		return nil, apiErrors.ErrUnauthorized
+	}
+
+	// Usage tracking must not fail the request
+	if err := uc.repo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		logger.FromContext(ctx).Warn("failed to update api key last use", "api_key_id", apiKey.ID, "error", err)
+	}
+
+	return &auth.Principal{
+		SubjectID:      apiKey.ID,
+		OrganizationID: apiKey.OrganizationID,
+		Role:           entity.RoleIntegration,
+		Scopes:         apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockAPIKeyRepository(ctrl)
+
+	useCase := NewAPIKeyUseCase(repo)
+	ctx := context.Background()
+
+	t.Run("only the hash of a new key is stored", func(t *testing.T) {
+		var stored *entity.APIKey
+		repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, k *entity.APIKey) error {
+			stored = k
+			return nil
+		})
+
+		apiKey, key, err := useCase.CreateAPIKey(ctx, "CRM", []string{"bookings:read"}, nil)
+
+		assert.NoError(t, err)
+		assert.Same(t, stored, apiKey)
+		assert.True(t, strings.HasPrefix(key, apiKey.Prefix+"_"))
+		assert.Equal(t, auth.HashToken(key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, key)
+	})
+
+	t.Run("keys do not expire in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Minute)
+
+		_, _, err := useCase.CreateAPIKey(ctx, "CRM", []string{"bookings:read"}, &past)
+
+		assert.ErrorIs(t, err, errors.ErrAPIKeyExpiry)
+	})
+
+	key, _, hash, _ := auth.NewAPIKey()
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+
+	t.Run("a valid key authenticates the integration and records its use", func(t *testing.T) {
+		apiKey := &entity.APIKey{ID: uuid.New(), OrganizationID: uuid.New(), KeyHash: hash, Scopes: []string{"bookings:read"}, ExpiresAt: &future}
+		repo.EXPECT().GetByHash(ctx, hash).Return(apiKey, nil)
+		repo.EXPECT().TouchLastUsed(ctx, apiKey.ID).Return(nil)
+
+		p, err := useCase.AuthenticateAPIKey(ctx, key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RoleIntegration, p.Role)
+		assert.Equal(t, apiKey.OrganizationID, p.OrganizationID)
+		assert.Equal(t, apiKey.Scopes, p.Scopes)
+	})
+
+	tests := []struct {
+		name string
+		key  *entity.APIKey
+		err  error
+	}{
+		{"unknown key", nil, errors.ErrNotFound},
+		{"revoked key", &entity.APIKey{ID: uuid.New(), KeyHash: hash, RevokedAt: &past}, nil},
+		{"expired key", &entity.APIKey{ID: uuid.New(), KeyHash: hash, ExpiresAt: &past}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" is unauthorized", func(t *testing.T) {
+			repo.EXPECT().GetByHash(ctx, hash).Return(tt.key, tt.err)
+
+			_, err := useCase.AuthenticateAPIKey(ctx, key)
+
+			assert.ErrorIs(t, err, errors.ErrUnauthorized)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+type AuthUseCase struct {
+	tokens      *auth.TokenManager
+	telegram    *auth.TelegramVerifier
+	refreshRepo repository.RefreshTokenRepository
+	adminRepo   repository.AdminRepository
+	masterRepo  repository.MasterRepository
+	clientRepo  repository.ClientRepository
+	tx          repository.TxManager
+}
+
+func NewAuthUseCase(
+	tokens *auth.TokenManager,
+	telegram *auth.TelegramVerifier,
+	rr repository.RefreshTokenRepository,
+	ar repository.AdminRepository,
+	mr repository.MasterRepository,
+	cr repository.ClientRepository,
+	tx repository.TxManager,
+) *AuthUseCase {
+	return &AuthUseCase{
+		tokens:      tokens,
+		telegram:    telegram,
+		refreshRepo: rr,
+		adminRepo:   ar,
+		masterRepo:  mr,
+		clientRepo:  cr,
+		tx:          tx,
+	}
+}
+
+// LoginAdmin authenticates an admin of the organization in the context by email and password.
+func (uc *AuthUseCase) LoginAdmin(ctx context.Context, email, password string) (*auth.TokenPair, error) {
+	admin, err := uc.adminRepo.GetByEmail(ctx, email)
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, apiErrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get admin: %w", err)
+	}
+
+	if !auth.CheckPassword(admin.PasswordHash, password) {
+		return nil, apiErrors.ErrInvalidCredentials
+	}
+
+	return uc.IssueTokens(ctx, auth.Principal{
+		SubjectID:      admin.ID,
+		OrganizationID: admin.OrganizationID,
+		Role:           entity.RoleAdmin,
+	})
+}
+
+// LoginTelegramWidget authenticates a Telegram Login Widget payload.
+func (uc *AuthUseCase) LoginTelegramWidget(ctx context.Context, fields map[string]string) (*auth.TokenPair, error) {
+	user, err := uc.telegram.VerifyLoginWidget(fields)
+	if err != nil {
+		return nil, err
+	}
+	return uc.loginTelegram(ctx, user)
+}
+
+// LoginTelegramWebApp authenticates the initData of a Telegram Mini App.
+func (uc *AuthUseCase) LoginTelegramWebApp(ctx context.Context, initData string) (*auth.TokenPair, error) {
+	user, err := uc.telegram.VerifyInitData(initData)
+	if err != nil {
+		return nil, err
+	}
+	return uc.loginTelegram(ctx, user)
+}
+
+// loginTelegram signs in the master with the given Telegram account, or the
+// client, registering a new client on first login. Masters are never created
+// here: an admin links their Telegram account beforehand.
+func (uc *AuthUseCase) loginTelegram(ctx context.Context, user *auth.TelegramUser) (*auth.TokenPair, error) {
+	master, err := uc.masterRepo.GetByTelegramID(ctx, user.ID)
+	if err == nil {
+		return uc.IssueTokens(ctx, auth.Principal{
+			SubjectID:      master.ID,
+			OrganizationID: master.OrganizationID,
+			Role:           entity.RoleMaster,
+		})
+	}
+	if !errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, fmt.Errorf("get master: %w", err)
+	}
+
+	client, err := uc.clientRepo.GetByTelegramID(ctx, user.ID)
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		client, err = uc.registerTelegramClient(ctx, user)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get client: %w", err)
+	}
+
+	return uc.IssueTokens(ctx, auth.Principal{
+		SubjectID:      client.ID,
+		OrganizationID: client.OrganizationID,
+		Role:           entity.RoleClient,
+	})
+}
+
+func (uc *AuthUseCase) registerTelegramClient(ctx context.Context, user *auth.TelegramUser) (*entity.Client, error) {
+	client := &entity.Client{
+		Name:       user.Name(),
+		TelegramID: &user.ID,
+		Timezone:   "UTC",
+		Language:   "en",
+	}
+	if user.Username != "" {
+		client.TelegramUsername = &user.Username
+	}
+	if len(user.LanguageCode) >= 2 {
+		client.Language = user.LanguageCode[:2]
+	}
+
+	if err := uc.clientRepo.Create(ctx, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// IssueTokens starts a new session for an already authenticated principal.
+func (uc *AuthUseCase) IssueTokens(ctx context.Context, p auth.Principal) (*auth.TokenPair, error) {
+	return uc.issue(ctx, p, uuid.New())
+}
+
+// Refresh exchanges a refresh token for a new token pair. Every refresh token
+// is single-use: presenting a revoked one is treated as theft and revokes the
+// whole session family.
+func (uc *AuthUseCase) Refresh(ctx context.Context, refreshToken string) (*auth.TokenPair, error) {
+	stored, err := uc.refreshRepo.GetByHash(ctx, auth.HashToken(refreshToken))
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, apiErrors.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token: %w", err)
+	}
+
+	if stored.RevokedAt != nil {
+		return nil, uc.revokeReused(ctx, stored.FamilyID)
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, apiErrors.ErrUnauthorized
+	}
+
+	// the old token stays valid if the new one cannot be stored
+	var pair *auth.TokenPair
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.refreshRepo.Revoke(ctx, stored.ID); err != nil {
+			return err
+		}
+		var err error
+		pair, err = uc.issue(ctx, auth.Principal{
+			SubjectID:      stored.SubjectID,
+			OrganizationID: stored.OrganizationID,
+			Role:           stored.Role,
+		}, stored.FamilyID)
+		return err
+	})
+	if errors.Is(err, apiErrors.ErrTokenRevoked) {
+		return nil, uc.revokeReused(ctx, stored.FamilyID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	return pair, nil
+}
+
+// Logout revokes the session the refresh token belongs to.
+func (uc *AuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := uc.refreshRepo.GetByHash(ctx, auth.HashToken(refreshToken))
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		return apiErrors.ErrUnauthorized
+	}
+	if err != nil {
+		return fmt.Errorf("get refresh token: %w", err)
+	}
+
+	if err := uc.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func (uc *AuthUseCase) issue(ctx context.Context, p auth.Principal, familyID uuid.UUID) (*auth.TokenPair, error) {
+	access, accessExp, err := uc.tokens.IssueAccessToken(p)
+	if err != nil {
+		return nil, fmt.Errorf("issue access token: %w", err)
+	}
+
+	refresh, hash, refreshExp, err := uc.tokens.NewRefreshToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	if err := uc.refreshRepo.Create(ctx, &entity.RefreshToken{
+		OrganizationID: p.OrganizationID,
+		SubjectID:      p.SubjectID,
+		Role:           p.Role,
+		FamilyID:       familyID,
+		TokenHash:      hash,
+		ExpiresAt:      refreshExp,
+	}); err != nil {
+		return nil, fmt.Errorf("store refresh token: %w", err)
+	}
+
+	return &auth.TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExp,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExp,
+	}, nil
+}
+
+func (uc *AuthUseCase) revokeReused(ctx context.Context, familyID uuid.UUID) error {
+	if err := uc.refreshRepo.RevokeFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("revoke refresh tokens: %w", err)
+	}
+	return apiErrors.ErrTokenRevoked
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthUseCase_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	refreshRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+
+	tokens := auth.NewTokenManager(config.AuthConfig{JWTSecret: "secret", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+	useCase := NewAuthUseCase(tokens, nil, refreshRepo, nil, nil, nil, tx)
+
+	ctx := context.Background()
+	stored := &entity.RefreshToken{
+		ID:        uuid.New(),
+		SubjectID: uuid.New(),
+		Role:      entity.RoleClient,
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("rotates the token in one transaction", func(t *testing.T) {
+		refreshRepo.EXPECT().GetByHash(ctx, auth.HashToken("old")).Return(stored, nil)
+		tx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			refreshRepo.EXPECT().Revoke(ctx, stored.ID).Return(nil)
+			refreshRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *entity.RefreshToken) error {
+				assert.Equal(t, stored.FamilyID, token.FamilyID)
+				return nil
+			})
+			return fn(ctx)
+		})
+
+		pair, err := useCase.Refresh(ctx, "old")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, pair.RefreshToken)
+	})
+
+	t.Run("reuse revokes the family outside the rolled back transaction", func(t *testing.T) {
+		refreshRepo.EXPECT().GetByHash(ctx, auth.HashToken("old")).Return(stored, nil)
+		tx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			refreshRepo.EXPECT().Revoke(ctx, stored.ID).Return(errors.ErrTokenRevoked)
+			return fn(ctx)
+		})
+		refreshRepo.EXPECT().RevokeFamily(ctx, stored.FamilyID).Return(nil)
+
+		_, err := useCase.Refresh(ctx, "old")
+
+		assert.ErrorIs(t, err, errors.ErrTokenRevoked)
+	})
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/timeutil"
+	"github.com/google/uuid"
+)
+
+type AvailabilityUseCase struct {
+	schedules *ScheduleUseCase
+	masters   repository.MasterRepository
+	bookings  repository.BookingRepository
+}
+
+func NewAvailabilityUseCase(schedules *ScheduleUseCase, masters repository.MasterRepository, bookings repository.BookingRepository) *AvailabilityUseCase {
+	return &AvailabilityUseCase{
+		schedules: schedules,
+		masters:   masters,
+		bookings:  bookings,
+	}
+}
+
+// GetAvailability returns, for every date of the inclusive range, the
+// schedule of the master together with the periods taken by bookings. Only
+// the times of the bookings are exposed, so any client may see them.
+func (uc *AvailabilityUseCase) GetAvailability(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*dto.AvailabilityResponse, error) {
+	from, to = timeutil.NormalizeDate(from), timeutil.NormalizeDate(to)
+
+	if _, err := uc.masters.GetByID(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	bookings, err := uc.bookings.GetByMasterID(ctx, masterID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("list bookings: %w", err)
+	}
+	booked := make(map[string][]dto.Period)
+	for _, b := range bookings {
+		if b.Status == entity.BookingStatusCancelled {
+			continue
+		}
+		date := b.StartTime.UTC().Format(time.DateOnly)
+		booked[date] = append(booked[date], dto.Period{StartTime: b.StartTime, EndTime: b.EndTime})
+	}
+
+	var out []*dto.AvailabilityResponse
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		schedule, err := uc.schedules.GetScheduleForDate(ctx, masterID, date)
+		if err != nil {
+			return nil, err
+		}
+
+		key := date.Format(time.DateOnly)
+		out = append(out, &dto.AvailabilityResponse{
+			MasterID: masterID,
+			Date:     key,
+			Schedule: schedule,
+			Booked:   append([]dto.Period{}, booked[key]...),
+		})
+	}
+	return out, nil
+}
@@ -2,30 +2,75 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/curserio/chrono-api/internal/auth"
 	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/repository"
 	"github.com/google/uuid"
 )
 
 type BookingUseCase struct {
 	bookingRepo repository.BookingRepository
+	services    repository.ServiceRepository
+	busyRepo    repository.BusyBlockRepository
+	tx          repository.TxManager
+	outbox      repository.OutboxRepository
 }
 
-func NewBookingUseCase(repo repository.BookingRepository) *BookingUseCase {
-	return &BookingUseCase{bookingRepo: repo}
+func NewBookingUseCase(repo repository.BookingRepository, services repository.ServiceRepository, busyRepo repository.BusyBlockRepository, tx repository.TxManager, outbox repository.OutboxRepository) *BookingUseCase {
+	return &BookingUseCase{
+		bookingRepo: repo,
+		services:    services,
+		busyRepo:    busyRepo,
+		tx:          tx,
+		outbox:      outbox,
+	}
 }
 
+// CreateBooking stores the booking with the current price of its service, so
+// later price changes leave the revenue of the booking as it was. The service
+// must be one of the master's.
 func (uc *BookingUseCase) CreateBooking(ctx context.Context, booking *entity.Booking) (*entity.Booking, error) {
-	if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+	if err := auth.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	service, err := uc.services.GetByID(ctx, booking.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if service.MasterID != booking.MasterID {
+		return nil, &apiErrors.ValidationError{Field: "service_id", Err: apiErrors.ErrServiceMaster}
+	}
+	booking.Price = service.Price
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkBusy(ctx, booking.MasterID, booking.StartTime, booking.EndTime); err != nil {
+			return err
+		}
+		if err := uc.bookingRepo.Create(ctx, booking); err != nil {
+			return err
+		}
+		return publish(ctx, uc.outbox, entity.EventBookingCreated, booking.ID, booking)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return booking, nil
 }
 
 func (uc *BookingUseCase) GetBookingByID(ctx context.Context, id uuid.UUID) (*entity.Booking, error) {
-	return uc.bookingRepo.GetByID(ctx, id)
+	booking, err := uc.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+	return booking, nil
 }
 
 func (uc *BookingUseCase) GetBookingsByMaster(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.Booking, error) {
@@ -37,9 +82,73 @@ func (uc *BookingUseCase) GetBookingsByClient(ctx context.Context, clientID uuid
 }
 
 func (uc *BookingUseCase) UpdateBookingStatus(ctx context.Context, id uuid.UUID, status entity.BookingStatus) error {
-	return uc.bookingRepo.UpdateStatus(ctx, id, status)
+	booking, err := uc.GetBookingByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Clients may only cancel their own bookings
+	if auth.HasRole(ctx, entity.RoleClient) && status != entity.BookingStatusCancelled {
+		return apiErrors.ErrForbidden
+	}
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.bookingRepo.UpdateStatus(ctx, booking.ID, status); err != nil {
+			return err
+		}
+
+		event, ok := entity.BookingStatusEvent(status)
+		if !ok || booking.Status == status {
+			return nil
+		}
+		booking.Status = status
+		return publish(ctx, uc.outbox, event, booking.ID, booking)
+	})
+}
+
+// RescheduleBooking moves a pending or confirmed booking to a new time.
+func (uc *BookingUseCase) RescheduleBooking(ctx context.Context, id uuid.UUID, start, end time.Time) (*entity.Booking, error) {
+	if !end.After(start) {
+		return nil, apiErrors.ErrEndTimeBeforeStartTime
+	}
+	booking, err := uc.GetBookingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != entity.BookingStatusPending && booking.Status != entity.BookingStatusConfirmed {
+		return nil, apiErrors.ErrBookingClosed
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkBusy(ctx, booking.MasterID, start, end); err != nil {
+			return err
+		}
+		if err := uc.bookingRepo.UpdateTime(ctx, booking.ID, start, end); err != nil {
+			return err
+		}
+
+		booking.StartTime = start
+		booking.EndTime = end
+		return publish(ctx, uc.outbox, entity.EventBookingRescheduled, booking.ID, booking)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
 }
 
 func (uc *BookingUseCase) DeleteBooking(ctx context.Context, id uuid.UUID) error {
 	return uc.bookingRepo.Delete(ctx, id)
 }
+
+// checkBusy rejects times overlapping busy blocks of the master.
+func (uc *BookingUseCase) checkBusy(ctx context.Context, masterID uuid.UUID, start, end time.Time) error {
+	blocks, err := uc.busyRepo.ListByMaster(ctx, masterID, start, end)
+	if err != nil {
+		return fmt.Errorf("list busy blocks: %w", err)
+	}
+	if len(blocks) > 0 {
+		return apiErrors.ErrSlotUnavailable
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBookingUseCase_CreateBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookingRepo := mock.NewMockBookingRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	blockRepo := mock.NewMockBusyBlockRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewBookingUseCase(bookingRepo, services, blockRepo, tx, outbox)
+	ctx := context.Background()
+
+	masterID := uuid.New()
+	haircut := &entity.Service{ID: uuid.New(), MasterID: masterID, Name: "Haircut", Price: 25}
+	start := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	booking := func(serviceID uuid.UUID) *entity.Booking {
+		return &entity.Booking{MasterID: masterID, ClientID: uuid.New(), ServiceID: serviceID, StartTime: start, EndTime: start.Add(time.Hour)}
+	}
+
+	t.Run("price of the service", func(t *testing.T) {
+		services.EXPECT().GetByID(ctx, haircut.ID).Return(haircut, nil)
+		blockRepo.EXPECT().ListByMaster(ctx, masterID, start, start.Add(time.Hour)).Return(nil, nil)
+		bookingRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		created, err := useCase.CreateBooking(ctx, booking(haircut.ID))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 25.0, created.Price)
+	})
+
+	t.Run("service of another master", func(t *testing.T) {
+		colour := &entity.Service{ID: uuid.New(), MasterID: uuid.New(), Name: "Colour"}
+		services.EXPECT().GetByID(ctx, colour.ID).Return(colour, nil)
+
+		_, err := useCase.CreateBooking(ctx, booking(colour.ID))
+
+		var validationErr *errors.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, "service_id", validationErr.Field)
+		}
+		assert.ErrorIs(t, err, errors.ErrServiceMaster)
+	})
+}
+
+func TestBookingUseCase_RescheduleBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookingRepo := mock.NewMockBookingRepository(ctrl)
+	blockRepo := mock.NewMockBusyBlockRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewBookingUseCase(bookingRepo, nil, blockRepo, tx, outbox)
+	ctx := context.Background()
+
+	start := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	booking := func(status entity.BookingStatus) *entity.Booking {
+		return &entity.Booking{ID: uuid.New(), MasterID: uuid.New(), StartTime: start.AddDate(0, 0, -1), EndTime: end.AddDate(0, 0, -1), Status: status}
+	}
+
+	t.Run("confirmed booking", func(t *testing.T) {
+		b := booking(entity.BookingStatusConfirmed)
+		bookingRepo.EXPECT().GetByID(ctx, b.ID).Return(b, nil)
+		blockRepo.EXPECT().ListByMaster(ctx, b.MasterID, start, end).Return(nil, nil)
+		bookingRepo.EXPECT().UpdateTime(ctx, b.ID, start, end).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.Event) error {
+			assert.Equal(t, entity.EventBookingRescheduled, e.Type)
+			return nil
+		})
+
+		moved, err := useCase.RescheduleBooking(ctx, b.ID, start, end)
+
+		assert.NoError(t, err)
+		assert.Equal(t, start, moved.StartTime)
+	})
+
+	for _, status := range []entity.BookingStatus{entity.BookingStatusCancelled, entity.BookingStatusCompleted} {
+		t.Run(string(status)+" booking", func(t *testing.T) {
+			b := booking(status)
+			bookingRepo.EXPECT().GetByID(ctx, b.ID).Return(b, nil)
+
+			_, err := useCase.RescheduleBooking(ctx, b.ID, start, end)
+
+			assert.ErrorIs(t, err, errors.ErrBookingClosed)
+		})
+	}
+
+	t.Run("end before start", func(t *testing.T) {
+		_, err := useCase.RescheduleBooking(ctx, uuid.New(), end, start)
+		assert.ErrorIs(t, err, errors.ErrEndTimeBeforeStartTime)
+
+		_, err = useCase.RescheduleBooking(ctx, uuid.New(), start, start)
+		assert.ErrorIs(t, err, errors.ErrEndTimeBeforeStartTime)
+	})
+}
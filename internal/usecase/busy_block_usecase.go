@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/google/uuid"
+)
+
+// Recurring events are expanded into blocks within this window around the
+// current time. Sources are re-synced, so the window moves along.
+const (
+	importPast  = 24 * time.Hour
+	importAhead = 180 * 24 * time.Hour
+)
+
+// CalendarFetcher downloads an ICS calendar.
+type CalendarFetcher interface {
+	// Check rejects URLs that Fetch refuses, such as internal addresses.
+	Check(url string) error
+	Fetch(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+type BusyBlockUseCase struct {
+	blocks  repository.BusyBlockRepository
+	sources repository.CalendarSourceRepository
+	masters repository.MasterRepository
+	tx      repository.TxManager
+	fetcher CalendarFetcher
+}
+
+func NewBusyBlockUseCase(
+	blocks repository.BusyBlockRepository,
+	sources repository.CalendarSourceRepository,
+	masters repository.MasterRepository,
+	tx repository.TxManager,
+	fetcher CalendarFetcher,
+) *BusyBlockUseCase {
+	return &BusyBlockUseCase{
+		blocks:  blocks,
+		sources: sources,
+		masters: masters,
+		tx:      tx,
+		fetcher: fetcher,
+	}
+}
+
+// ImportICS stores the events of an uploaded calendar as busy blocks of the
+// master. Importing the same calendar again updates the blocks in place.
+func (uc *BusyBlockUseCase) ImportICS(ctx context.Context, masterID uuid.UUID, r io.Reader) (*entity.ImportResult, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.masters.GetByID(ctx, masterID); err != nil {
+		return nil, err
+	}
+	return uc.importCalendar(ctx, masterID, nil, r)
+}
+
+func (uc *BusyBlockUseCase) ListBlocks(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.BusyBlock, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+	return uc.blocks.ListByMaster(ctx, masterID, from, to)
+}
+
+// SaveBlock creates the block or updates the one with the same UID, e.g. for
+// an event created in a calendar client.
+func (uc *BusyBlockUseCase) SaveBlock(ctx context.Context, block *entity.BusyBlock) error {
+	if err := auth.AuthorizeMaster(ctx, block.MasterID); err != nil {
+		return err
+	}
+	if !block.EndTime.After(block.StartTime) {
+		return apiErrors.ErrEndTimeBeforeStartTime
+	}
+
+	if err := uc.blocks.Upsert(ctx, block); err != nil {
+		return fmt.Errorf("save busy block: %w", err)
+	}
+	return nil
+}
+
+func (uc *BusyBlockUseCase) DeleteBlock(ctx context.Context, id uuid.UUID) error {
+	block, err := uc.blocks.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := auth.AuthorizeMaster(ctx, block.MasterID); err != nil {
+		return err
+	}
+	return uc.blocks.Delete(ctx, id)
+}
+
+// CreateSource registers an ICS calendar of the master. The syncer picks it
+// up right away and re-syncs it periodically.
+func (uc *BusyBlockUseCase) CreateSource(ctx context.Context, source *entity.CalendarSource) (*entity.CalendarSource, error) {
+	if err := auth.AuthorizeMaster(ctx, source.MasterID); err != nil {
+		return nil, err
+	}
+	u, err := normalizeCalendarURL(source.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.fetcher.Check(u); err != nil {
+		return nil, apiErrors.ErrCalendarURLInternal
+	}
+	source.URL = u
+	if _, err := uc.masters.GetByID(ctx, source.MasterID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sources.Create(ctx, source); err != nil {
+		return nil, fmt.Errorf("create calendar source: %w", err)
+	}
+	return source, nil
+}
+
+func (uc *BusyBlockUseCase) ListSources(ctx context.Context, masterID uuid.UUID) ([]*entity.CalendarSource, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+	return uc.sources.ListByMaster(ctx, masterID)
+}
+
+// DeleteSource deletes the source together with its blocks.
+func (uc *BusyBlockUseCase) DeleteSource(ctx context.Context, id uuid.UUID) error {
+	if _, err := uc.authorizeSource(ctx, id); err != nil {
+		return err
+	}
+	return uc.sources.Delete(ctx, id)
+}
+
+// SyncSource syncs the source now instead of waiting for the syncer.
+func (uc *BusyBlockUseCase) SyncSource(ctx context.Context, id uuid.UUID) (*entity.ImportResult, error) {
+	source, err := uc.authorizeSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Sync(ctx, source)
+}
+
+// Sync fetches the calendar of the source and replaces its blocks. The
+// outcome is recorded on the source.
+func (uc *BusyBlockUseCase) Sync(ctx context.Context, source *entity.CalendarSource) (*entity.ImportResult, error) {
+	result, err := uc.sync(ctx, source)
+
+	var lastErr *string
+	if err != nil {
+		msg := err.Error()
+		lastErr = &msg
+	}
+	if markErr := uc.sources.MarkSynced(ctx, source.ID, lastErr); markErr != nil && err == nil {
+		err = fmt.Errorf("mark calendar source synced: %w", markErr)
+	}
+	return result, err
+}
+
+func (uc *BusyBlockUseCase) sync(ctx context.Context, source *entity.CalendarSource) (*entity.ImportResult, error) {
+	body, err := uc.fetcher.Fetch(ctx, source.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apiErrors.ErrCalendarFetchFailed, err)
+	}
+	defer body.Close()
+
+	return uc.importCalendar(ctx, source.MasterID, &source.ID, body)
+}
+
+// importCalendar turns the events of the calendar into blocks keyed by UID,
+// with the original start appended for instances of recurring events.
+// Transparent events and unsupported rules are skipped, cancelled events
+// remove their blocks. For a source, blocks of events that are gone from the
+// calendar are removed as well.
+func (uc *BusyBlockUseCase) importCalendar(ctx context.Context, masterID uuid.UUID, sourceID *uuid.UUID, r io.Reader) (*entity.ImportResult, error) {
+	events, err := ical.Parse(r, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apiErrors.ErrCalendarInvalid, err)
+	}
+
+	// modified instances override the instances of their recurring event
+	slices.SortStableFunc(events, func(a, b ical.Event) int {
+		switch {
+		case a.RecurrenceID == nil && b.RecurrenceID != nil:
+			return -1
+		case a.RecurrenceID != nil && b.RecurrenceID == nil:
+			return 1
+		}
+		return 0
+	})
+
+	now := time.Now()
+	from, to := now.Add(-importPast), now.Add(importAhead)
+
+	result := &entity.ImportResult{}
+	blocks := make(map[string]*entity.BusyBlock)
+	var cancelled []string
+
+	for _, ev := range events {
+		if ev.UID == "" {
+			result.Skipped++
+			continue
+		}
+		if ev.RecurrenceID != nil {
+			// the instance may have been moved out of the window
+			delete(blocks, blockUID(ev, ev))
+		}
+		instances, err := ev.Occurrences(from, to)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+
+		for _, in := range instances {
+			uid := blockUID(ev, in)
+			delete(blocks, uid)
+			if in.Status == ical.StatusCancelled {
+				cancelled = append(cancelled, uid)
+				continue
+			}
+			if in.Transparent {
+				result.Skipped++
+				continue
+			}
+			blocks[uid] = &entity.BusyBlock{
+				MasterID:  masterID,
+				SourceID:  sourceID,
+				UID:       uid,
+				Summary:   in.Summary,
+				StartTime: in.Start,
+				EndTime:   in.End,
+			}
+		}
+	}
+
+	uids := make([]string, 0, len(blocks))
+	for uid := range blocks {
+		uids = append(uids, uid)
+	}
+	slices.Sort(uids)
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, uid := range uids {
+			if err := uc.blocks.Upsert(ctx, blocks[uid]); err != nil {
+				return fmt.Errorf("upsert busy block: %w", err)
+			}
+		}
+
+		if len(cancelled) > 0 {
+			n, err := uc.blocks.DeleteByUIDs(ctx, masterID, cancelled)
+			if err != nil {
+				return fmt.Errorf("delete cancelled busy blocks: %w", err)
+			}
+			result.Removed += n
+		}
+
+		if sourceID != nil {
+			n, err := uc.blocks.DeleteStale(ctx, *sourceID, uids)
+			if err != nil {
+				return fmt.Errorf("delete stale busy blocks: %w", err)
+			}
+			result.Removed += n
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(uids)
+	return result, nil
+}
+
+// blockUID identifies an instance: a single event by its UID, an instance of
+// a recurring event also by its original start.
+func blockUID(ev, instance ical.Event) string {
+	if ev.RRule == "" && ev.RecurrenceID == nil {
+		return ev.UID
+	}
+	return ev.UID + "/" + instance.RecurrenceID.UTC().Format("20060102T150405Z")
+}
+
+func (uc *BusyBlockUseCase) authorizeSource(ctx context.Context, id uuid.UUID) (*entity.CalendarSource, error) {
+	source, err := uc.sources.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeMaster(ctx, source.MasterID); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// normalizeCalendarURL accepts http(s) URLs and webcal URLs, which calendar
+// apps use for the same feeds served over https.
+func normalizeCalendarURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", apiErrors.ErrCalendarURLInvalid
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "webcal":
+		u.Scheme = "https"
+	default:
+		return "", apiErrors.ErrCalendarURLInvalid
+	}
+	return u.String(), nil
+}
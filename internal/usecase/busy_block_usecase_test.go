@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/calendarsync"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type stringFetcher string
+
+func (f stringFetcher) Check(string) error {
+	return nil
+}
+
+func (f stringFetcher) Fetch(context.Context, string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(f))), nil
+}
+
+func TestBusyBlockUseCase_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blocks := mock.NewMockBusyBlockRepository(ctrl)
+	sources := mock.NewMockCalendarSourceRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	// a weekly event with its second instance moved by an hour, a cancelled
+	// event and a transparent one
+	first := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1).Add(9 * time.Hour)
+	second := first.AddDate(0, 0, 7)
+	stamp := func(t time.Time) string { return t.Format("20060102T150405Z") }
+	cal := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT", "UID:gym", "SUMMARY:Gym", "DTSTART:" + stamp(first), "DTEND:" + stamp(first.Add(time.Hour)),
+		"RRULE:FREQ=WEEKLY;COUNT=2", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:gym", "RECURRENCE-ID:" + stamp(second), "SUMMARY:Gym",
+		"DTSTART:" + stamp(second.Add(time.Hour)), "DTEND:" + stamp(second.Add(2*time.Hour)), "END:VEVENT",
+		"BEGIN:VEVENT", "UID:dentist", "STATUS:CANCELLED", "DTSTART:" + stamp(first), "DTEND:" + stamp(first.Add(time.Hour)), "END:VEVENT",
+		"BEGIN:VEVENT", "UID:reminder", "TRANSP:TRANSPARENT", "DTSTART:" + stamp(first), "DTEND:" + stamp(first.Add(time.Hour)), "END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	useCase := NewBusyBlockUseCase(blocks, sources, nil, tx, stringFetcher(cal))
+	source := &entity.CalendarSource{ID: uuid.New(), MasterID: uuid.New()}
+
+	firstUID, secondUID := "gym/"+stamp(first), "gym/"+stamp(second)
+	var upserted []*entity.BusyBlock
+	blocks.EXPECT().Upsert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, b *entity.BusyBlock) error {
+			upserted = append(upserted, b)
+			return nil
+		}).Times(2)
+	blocks.EXPECT().DeleteByUIDs(gomock.Any(), source.MasterID, []string{"dentist"}).Return(1, nil)
+	blocks.EXPECT().DeleteStale(gomock.Any(), source.ID, []string{firstUID, secondUID}).Return(3, nil)
+	sources.EXPECT().MarkSynced(gomock.Any(), source.ID, nil).Return(nil)
+
+	result, err := useCase.Sync(context.Background(), source)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.ImportResult{Imported: 2, Removed: 4, Skipped: 1}, result)
+	if assert.Len(t, upserted, 2) {
+		assert.Equal(t, firstUID, upserted[0].UID)
+		assert.Equal(t, first, upserted[0].StartTime)
+		assert.Equal(t, secondUID, upserted[1].UID)
+		assert.Equal(t, second.Add(time.Hour), upserted[1].StartTime)
+		assert.Equal(t, &source.ID, upserted[1].SourceID)
+	}
+}
+
+func TestNormalizeCalendarURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"webcal://example.com/cal.ics": "https://example.com/cal.ics",
+		"http://example.com/cal.ics":   "http://example.com/cal.ics",
+		"file:///etc/passwd":           "",
+		"/relative.ics":                "",
+	} {
+		got, err := normalizeCalendarURL(in)
+		assert.Equal(t, want, got, in)
+		assert.Equal(t, want == "", err != nil, fmt.Sprintf("error for %s", in))
+	}
+}
+
+func TestBusyBlockUseCase_CreateSource(t *testing.T) {
+	fetcher := calendarsync.NewFetcher(config.CalendarSyncConfig{Timeout: time.Second, MaxSize: 1 << 20})
+	useCase := NewBusyBlockUseCase(nil, nil, nil, nil, fetcher)
+
+	for _, url := range []string{
+		"http://calendar.example.com/cal.ics",
+		"https://127.0.0.1:5432/cal.ics",
+		"webcal://localhost/cal.ics",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[fe80::1]/cal.ics",
+	} {
+		_, err := useCase.CreateSource(context.Background(), &entity.CalendarSource{MasterID: uuid.New(), URL: url})
+		assert.ErrorIs(t, err, errors.ErrCalendarURLInternal, url)
+	}
+}
@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/google/uuid"
+)
+
+// Resource names of bookings and of blocks not created by a calendar client
+const (
+	bookingObjectPrefix = "booking-"
+	blockObjectPrefix   = "block-"
+	objectSuffix        = ".ics"
+)
+
+// CalendarObject is an event of a master's CalDAV calendar: a booking or a
+// busy block.
+type CalendarObject struct {
+	Name  string
+	ETag  string
+	Event ical.Event
+
+	booking *entity.Booking
+	block   *entity.BusyBlock
+}
+
+// Precondition holds the If-Match and If-None-Match headers of a write.
+type Precondition struct {
+	IfMatch     string
+	IfNoneMatch string
+}
+
+func (p Precondition) check(current *CalendarObject) error {
+	if p.IfNoneMatch == "*" && current != nil {
+		return apiErrors.ErrPreconditionFailed
+	}
+	if p.IfMatch != "" && (current == nil || p.IfMatch != "*" && p.IfMatch != current.ETag) {
+		return apiErrors.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// CalDAVUseCase serves the calendar of a master to CalDAV clients. Bookings
+// can be rescheduled or cancelled from the client, other events are stored
+// as busy blocks. Changes go through the booking and busy block usecases.
+type CalDAVUseCase struct {
+	accounts    repository.CalDAVAccountRepository
+	bookingRepo repository.BookingRepository
+	blockRepo   repository.BusyBlockRepository
+	masters     repository.MasterRepository
+	clients     repository.ClientRepository
+	services    repository.ServiceRepository
+	bookings    *BookingUseCase
+	blocks      *BusyBlockUseCase
+}
+
+func NewCalDAVUseCase(
+	accounts repository.CalDAVAccountRepository,
+	bookingRepo repository.BookingRepository,
+	blockRepo repository.BusyBlockRepository,
+	masters repository.MasterRepository,
+	clients repository.ClientRepository,
+	services repository.ServiceRepository,
+	bookings *BookingUseCase,
+	blocks *BusyBlockUseCase,
+) *CalDAVUseCase {
+	return &CalDAVUseCase{
+		accounts:    accounts,
+		bookingRepo: bookingRepo,
+		blockRepo:   blockRepo,
+		masters:     masters,
+		clients:     clients,
+		services:    services,
+		bookings:    bookings,
+		blocks:      blocks,
+	}
+}
+
+// CreateAccount creates the CalDAV account of the master, or rotates its
+// password, and returns the password, which is returned only here.
+func (uc *CalDAVUseCase) CreateAccount(ctx context.Context, masterID uuid.UUID) (*entity.CalDAVAccount, string, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, "", err
+	}
+	if _, err := uc.masters.GetByID(ctx, masterID); err != nil {
+		return nil, "", err
+	}
+
+	password, hash, err := auth.NewAppPassword()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate app password: %w", err)
+	}
+
+	account := &entity.CalDAVAccount{MasterID: masterID, TokenHash: hash}
+	if err := uc.accounts.Upsert(ctx, account); err != nil {
+		return nil, "", fmt.Errorf("create caldav account: %w", err)
+	}
+	return account, password, nil
+}
+
+func (uc *CalDAVUseCase) RevokeAccount(ctx context.Context, masterID uuid.UUID) error {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return err
+	}
+	return uc.accounts.Delete(ctx, masterID)
+}
+
+// Authenticate resolves the Basic credentials of a calendar client: the
+// master ID and the app password.
+func (uc *CalDAVUseCase) Authenticate(ctx context.Context, username, password string) (*auth.Principal, error) {
+	account, err := uc.accounts.GetByHash(ctx, auth.HashToken(password))
+	if errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, apiErrors.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if account.MasterID.String() != username {
+		return nil, apiErrors.ErrUnauthorized
+	}
+
+	return &auth.Principal{
+		SubjectID:      account.MasterID,
+		OrganizationID: account.OrganizationID,
+		Role:           entity.RoleMaster,
+	}, nil
+}
+
+// CalendarName returns the display name of the master's calendar.
+func (uc *CalDAVUseCase) CalendarName(ctx context.Context, masterID uuid.UUID) (string, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return "", err
+	}
+	master, err := uc.masters.GetByID(ctx, masterID)
+	if err != nil {
+		return "", err
+	}
+	return master.Name, nil
+}
+
+// ListObjects returns the events overlapping [from, to); zero bounds default
+// to the window of the calendar feeds. Cancelled bookings are left out.
+func (uc *CalDAVUseCase) ListObjects(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*CalendarObject, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if from.IsZero() {
+		from = now.Add(-feedPast)
+	}
+	if to.IsZero() {
+		to = now.Add(feedAhead)
+	}
+
+	bookings, err := uc.bookingRepo.GetByMasterID(ctx, masterID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list bookings: %w", err)
+	}
+	blocks, err := uc.blockRepo.ListByMaster(ctx, masterID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list busy blocks: %w", err)
+	}
+
+	names := newFeedNames(uc.masters, uc.clients, uc.services)
+	objects := make([]*CalendarObject, 0, len(bookings)+len(blocks))
+	for _, b := range bookings {
+		if b.Status == entity.BookingStatusCancelled {
+			continue
+		}
+		obj, err := bookingObject(ctx, names, b)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+	for _, b := range blocks {
+		objects = append(objects, blockObject(b))
+	}
+	return objects, nil
+}
+
+// GetObject returns the event stored under the resource name.
+func (uc *CalDAVUseCase) GetObject(ctx context.Context, masterID uuid.UUID, name string) (*CalendarObject, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	if id, ok := objectID(name, bookingObjectPrefix); ok {
+		b, err := uc.bookingRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if b.MasterID != masterID || b.Status == entity.BookingStatusCancelled {
+			return nil, apiErrors.ErrNotFound
+		}
+		return bookingObject(ctx, newFeedNames(uc.masters, uc.clients, uc.services), b)
+	}
+
+	var (
+		block *entity.BusyBlock
+		err   error
+	)
+	if id, ok := objectID(name, blockObjectPrefix); ok {
+		block, err = uc.blockRepo.GetByID(ctx, id)
+		if err == nil && block.MasterID != masterID {
+			err = apiErrors.ErrNotFound
+		}
+	} else {
+		block, err = uc.blockRepo.GetByHref(ctx, masterID, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return blockObject(block), nil
+}
+
+// PutObject applies an event uploaded by the client. A booking is cancelled
+// or rescheduled; any other event is stored as a busy block. It returns the
+// stored object, if it still exists, and whether it was created.
+func (uc *CalDAVUseCase) PutObject(ctx context.Context, masterID uuid.UUID, name string, r io.Reader, cond Precondition) (*CalendarObject, bool, error) {
+	current, err := uc.GetObject(ctx, masterID, name)
+	if err != nil && !errors.Is(err, apiErrors.ErrNotFound) {
+		return nil, false, err
+	}
+	if err := cond.check(current); err != nil {
+		return nil, false, err
+	}
+
+	ev, err := parseObject(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, ok := objectID(name, bookingObjectPrefix); ok {
+		// bookings are made by clients, not in calendar apps
+		if current == nil {
+			return nil, false, apiErrors.ErrForbidden
+		}
+		return uc.putBooking(ctx, current, ev)
+	}
+
+	if current != nil && current.Event.UID != ev.UID {
+		return nil, false, fmt.Errorf("%w: the UID of an event cannot change", apiErrors.ErrCalendarInvalid)
+	}
+	if ev.Status == ical.StatusCancelled {
+		if current != nil {
+			if err := uc.blocks.DeleteBlock(ctx, current.block.ID); err != nil {
+				return nil, false, err
+			}
+		}
+		return nil, false, nil
+	}
+
+	block := &entity.BusyBlock{
+		MasterID:  masterID,
+		UID:       ev.UID,
+		Href:      &name,
+		Summary:   ev.Summary,
+		StartTime: ev.Start,
+		EndTime:   ev.End,
+	}
+	if err := uc.blocks.SaveBlock(ctx, block); err != nil {
+		return nil, false, err
+	}
+	return blockObject(block), current == nil, nil
+}
+
+func (uc *CalDAVUseCase) putBooking(ctx context.Context, current *CalendarObject, ev *ical.Event) (*CalendarObject, bool, error) {
+	b := current.booking
+	switch {
+	case ev.Status == ical.StatusCancelled:
+		if err := uc.bookings.UpdateBookingStatus(ctx, b.ID, entity.BookingStatusCancelled); err != nil {
+			return nil, false, err
+		}
+		return nil, false, nil
+	case !ev.Start.Equal(b.StartTime) || !ev.End.Equal(b.EndTime):
+		if _, err := uc.bookings.RescheduleBooking(ctx, b.ID, ev.Start, ev.End); err != nil {
+			return nil, false, err
+		}
+	default:
+		// other changes, e.g. of the summary, are not stored
+		return current, false, nil
+	}
+
+	obj, err := uc.GetObject(ctx, b.MasterID, current.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	return obj, false, nil
+}
+
+// DeleteObject cancels a booking or deletes a busy block.
+func (uc *CalDAVUseCase) DeleteObject(ctx context.Context, masterID uuid.UUID, name string, cond Precondition) error {
+	current, err := uc.GetObject(ctx, masterID, name)
+	if err != nil {
+		return err
+	}
+	if err := cond.check(current); err != nil {
+		return err
+	}
+
+	if current.booking != nil {
+		return uc.bookings.UpdateBookingStatus(ctx, current.booking.ID, entity.BookingStatusCancelled)
+	}
+	return uc.blocks.DeleteBlock(ctx, current.block.ID)
+}
+
+func bookingObject(ctx context.Context, names *feedNames, b *entity.Booking) (*CalendarObject, error) {
+	service, err := names.get(ctx, names.services, b.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := names.get(ctx, names.clients, b.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CalendarObject{
+		Name: bookingObjectPrefix + b.ID.String() + objectSuffix,
+		ETag: etag(b.UpdatedAt),
+		Event: ical.Event{
+			UID:         b.CalendarUID(),
+			Sequence:    int(b.UpdatedAt.Unix()),
+			Stamp:       b.UpdatedAt,
+			Start:       b.StartTime,
+			End:         b.EndTime,
+			Summary:     fmt.Sprintf("%s — %s", service, client),
+			Description: fmt.Sprintf("Status: %s", b.Status),
+			Status:      calendarStatus(b.Status),
+		},
+		booking: b,
+	}, nil
+}
+
+func blockObject(b *entity.BusyBlock) *CalendarObject {
+	name := blockObjectPrefix + b.ID.String() + objectSuffix
+	if b.Href != nil {
+		name = *b.Href
+	}
+
+	return &CalendarObject{
+		Name: name,
+		ETag: etag(b.UpdatedAt),
+		Event: ical.Event{
+			UID:     b.UID,
+			Stamp:   b.UpdatedAt,
+			Start:   b.StartTime,
+			End:     b.EndTime,
+			Summary: b.Summary,
+		},
+		block: b,
+	}
+}
+
+// objectID parses resource names such as "booking-<id>.ics".
+func objectID(name, prefix string) (uuid.UUID, bool) {
+	s, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(strings.TrimSuffix(s, objectSuffix))
+	return id, err == nil
+}
+
+// parseObject returns the single event of a calendar object resource.
+func parseObject(r io.Reader) (*ical.Event, error) {
+	events, err := ical.Parse(r, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apiErrors.ErrCalendarInvalid, err)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: no event", apiErrors.ErrCalendarInvalid)
+	}
+	ev := events[0]
+	if len(events) > 1 || ev.RRule != "" || ev.RecurrenceID != nil {
+		return nil, apiErrors.ErrCalendarRecurring
+	}
+	if ev.UID == "" {
+		return nil, fmt.Errorf("%w: missing UID", apiErrors.ErrCalendarInvalid)
+	}
+	return &ev, nil
+}
+
+func etag(updatedAt time.Time) string {
+	return fmt.Sprintf(`"%x"`, updatedAt.UnixNano())
+}
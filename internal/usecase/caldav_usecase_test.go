@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalDAVUseCase_PutObject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bookingRepo := mock.NewMockBookingRepository(ctrl)
+	blockRepo := mock.NewMockBusyBlockRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	clients := mock.NewMockClientRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	bookings := NewBookingUseCase(bookingRepo, services, blockRepo, tx, outbox)
+	blocks := NewBusyBlockUseCase(blockRepo, nil, nil, tx, nil)
+	useCase := NewCalDAVUseCase(nil, bookingRepo, blockRepo, nil, clients, services, bookings, blocks)
+
+	ctx := context.Background()
+	masterID := uuid.New()
+	event := func(extra ...string) string {
+		return strings.Join(append([]string{
+			"BEGIN:VCALENDAR", "BEGIN:VEVENT", "UID:lunch@client",
+			"DTSTART:20250310T120000Z", "DTEND:20250310T130000Z", "SUMMARY:Lunch",
+		}, append(extra, "END:VEVENT", "END:VCALENDAR")...), "\r\n")
+	}
+
+	t.Run("new event becomes a busy block", func(t *testing.T) {
+		blockRepo.EXPECT().GetByHref(ctx, masterID, "lunch.ics").Return(nil, errors.ErrNotFound)
+		blockRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, b *entity.BusyBlock) error {
+			assert.Equal(t, "lunch@client", b.UID)
+			assert.Equal(t, "lunch.ics", *b.Href)
+			assert.Equal(t, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), b.StartTime)
+			b.UpdatedAt = time.Unix(1, 0)
+			return nil
+		})
+
+		obj, created, err := useCase.PutObject(ctx, masterID, "lunch.ics", strings.NewReader(event()), Precondition{IfNoneMatch: "*"})
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "lunch.ics", obj.Name)
+		assert.Equal(t, `"3b9aca00"`, obj.ETag)
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		href := "lunch.ics"
+		blockRepo.EXPECT().GetByHref(ctx, masterID, href).
+			Return(&entity.BusyBlock{MasterID: masterID, UID: "lunch@client", Href: &href, UpdatedAt: time.Unix(2, 0)}, nil)
+
+		_, _, err := useCase.PutObject(ctx, masterID, href, strings.NewReader(event()), Precondition{IfMatch: `"3b9aca00"`})
+
+		assert.ErrorIs(t, err, errors.ErrPreconditionFailed)
+	})
+
+	t.Run("cancelling a booking", func(t *testing.T) {
+		b := &entity.Booking{ID: uuid.New(), MasterID: masterID, ClientID: uuid.New(), ServiceID: uuid.New(), Status: entity.BookingStatusConfirmed}
+		name := bookingObjectPrefix + b.ID.String() + objectSuffix
+
+		services.EXPECT().GetByID(ctx, b.ServiceID).Return(&entity.Service{Name: "Haircut"}, nil)
+		clients.EXPECT().GetByID(ctx, b.ClientID).Return(&entity.Client{Name: "Bob"}, nil)
+		bookingRepo.EXPECT().GetByID(ctx, b.ID).Return(b, nil).Times(2)
+		bookingRepo.EXPECT().UpdateStatus(ctx, b.ID, entity.BookingStatusCancelled).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		obj, created, err := useCase.PutObject(ctx, masterID, name, strings.NewReader(event("STATUS:CANCELLED")), Precondition{})
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Nil(t, obj)
+	})
+
+	t.Run("recurring events are rejected", func(t *testing.T) {
+		blockRepo.EXPECT().GetByHref(ctx, masterID, "gym.ics").Return(nil, errors.ErrNotFound)
+
+		_, _, err := useCase.PutObject(ctx, masterID, "gym.ics", strings.NewReader(event("RRULE:FREQ=WEEKLY")), Precondition{})
+
+		assert.ErrorIs(t, err, errors.ErrCalendarRecurring)
+	})
+}
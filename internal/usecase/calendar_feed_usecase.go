@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/google/uuid"
+)
+
+// The feed of a master covers this window around the current time
+const (
+	feedPast  = 90 * 24 * time.Hour
+	feedAhead = 365 * 24 * time.Hour
+)
+
+type CalendarFeedUseCase struct {
+	feeds    repository.CalendarFeedRepository
+	bookings repository.BookingRepository
+	masters  repository.MasterRepository
+	clients  repository.ClientRepository
+	services repository.ServiceRepository
+}
+
+func NewCalendarFeedUseCase(
+	feeds repository.CalendarFeedRepository,
+	bookings repository.BookingRepository,
+	masters repository.MasterRepository,
+	clients repository.ClientRepository,
+	services repository.ServiceRepository,
+) *CalendarFeedUseCase {
+	return &CalendarFeedUseCase{
+		feeds:    feeds,
+		bookings: bookings,
+		masters:  masters,
+		clients:  clients,
+		services: services,
+	}
+}
+
+// CreateFeed creates the feed of a master or client, or rotates its token,
+// and returns the token, which is returned only here.
+func (uc *CalendarFeedUseCase) CreateFeed(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) (*entity.CalendarFeed, string, error) {
+	if err := authorizeOwner(ctx, ownerRole, ownerID); err != nil {
+		return nil, "", err
+	}
+	// the owner must exist in the organization
+	if _, err := uc.ownerName(ctx, ownerRole, ownerID); err != nil {
+		return nil, "", err
+	}
+
+	token, hash, err := auth.NewFeedToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate feed token: %w", err)
+	}
+
+	feed := &entity.CalendarFeed{
+		OwnerRole: ownerRole,
+		OwnerID:   ownerID,
+		TokenHash: hash,
+	}
+	if err := uc.feeds.Upsert(ctx, feed); err != nil {
+		return nil, "", fmt.Errorf("create calendar feed: %w", err)
+	}
+	return feed, token, nil
+}
+
+func (uc *CalendarFeedUseCase) RevokeFeed(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) error {
+	if err := authorizeOwner(ctx, ownerRole, ownerID); err != nil {
+		return err
+	}
+	return uc.feeds.Delete(ctx, ownerRole, ownerID)
+}
+
+// Feed renders the bookings of the feed's owner. The token is the only
+// credential: an unknown token is reported as not found, and a principal of
+// the caller, if any, is not checked.
+func (uc *CalendarFeedUseCase) Feed(ctx context.Context, token string) (*ical.Calendar, error) {
+	feed, err := uc.feeds.GetByHash(ctx, auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrganizationID(ctx, feed.OrganizationID)
+
+	name, err := uc.ownerName(ctx, feed.OwnerRole, feed.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bookings []*entity.Booking
+	if feed.OwnerRole == entity.RoleMaster {
+		now := time.Now()
+		bookings, err = uc.bookings.GetByMasterID(ctx, feed.OwnerID, now.Add(-feedPast), now.Add(feedAhead))
+	} else {
+		bookings, err = uc.bookings.GetByClientID(ctx, feed.OwnerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list bookings: %w", err)
+	}
+
+	cal := &ical.Calendar{
+		ProdID: "-//Chrono//Feed//EN",
+		Method: ical.MethodPublish,
+		Name:   name,
+		Events: make([]ical.Event, 0, len(bookings)),
+	}
+
+	// the other party of each booking is named in the summary
+	names := uc.newNames()
+	for _, b := range bookings {
+		service, err := names.get(ctx, names.services, b.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		var with string
+		if feed.OwnerRole == entity.RoleMaster {
+			with, err = names.get(ctx, names.clients, b.ClientID)
+		} else {
+			with, err = names.get(ctx, names.masters, b.MasterID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         b.CalendarUID(),
+			Sequence:    int(b.UpdatedAt.Unix()),
+			Stamp:       b.UpdatedAt,
+			Start:       b.StartTime,
+			End:         b.EndTime,
+			Summary:     fmt.Sprintf("%s — %s", service, with),
+			Description: fmt.Sprintf("Status: %s", b.Status),
+			Status:      calendarStatus(b.Status),
+		})
+	}
+	return cal, nil
+}
+
+func (uc *CalendarFeedUseCase) ownerName(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) (string, error) {
+	names := uc.newNames()
+	if ownerRole == entity.RoleMaster {
+		return names.get(ctx, names.masters, ownerID)
+	}
+	return names.get(ctx, names.clients, ownerID)
+}
+
+// feedNames looks up and caches names of masters, clients and services.
+type feedNames struct {
+	masters  nameSource
+	clients  nameSource
+	services nameSource
+	cache    map[uuid.UUID]string
+}
+
+type nameSource func(ctx context.Context, id uuid.UUID) (string, error)
+
+func (uc *CalendarFeedUseCase) newNames() *feedNames {
+	return newFeedNames(uc.masters, uc.clients, uc.services)
+}
+
+func newFeedNames(masters repository.MasterRepository, clients repository.ClientRepository, services repository.ServiceRepository) *feedNames {
+	return &feedNames{
+		masters: func(ctx context.Context, id uuid.UUID) (string, error) {
+			m, err := masters.GetByID(ctx, id)
+			if err != nil {
+				return "", fmt.Errorf("get master: %w", err)
+			}
+			return m.Name, nil
+		},
+		clients: func(ctx context.Context, id uuid.UUID) (string, error) {
+			c, err := clients.GetByID(ctx, id)
+			if err != nil {
+				return "", fmt.Errorf("get client: %w", err)
+			}
+			return c.Name, nil
+		},
+		services: func(ctx context.Context, id uuid.UUID) (string, error) {
+			s, err := services.GetByID(ctx, id)
+			if err != nil {
+				return "", fmt.Errorf("get service: %w", err)
+			}
+			return s.Name, nil
+		},
+		cache: make(map[uuid.UUID]string),
+	}
+}
+
+func (n *feedNames) get(ctx context.Context, source nameSource, id uuid.UUID) (string, error) {
+	if name, ok := n.cache[id]; ok {
+		return name, nil
+	}
+	name, err := source(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	n.cache[id] = name
+	return name, nil
+}
+
+func authorizeOwner(ctx context.Context, ownerRole entity.Role, ownerID uuid.UUID) error {
+	switch ownerRole {
+	case entity.RoleMaster:
+		return auth.AuthorizeMaster(ctx, ownerID)
+	case entity.RoleClient:
+		return auth.AuthorizeClient(ctx, ownerID)
+	default:
+		// only masters and clients have feeds
+		return apiErrors.ErrForbidden
+	}
+}
+
+// calendarStatus maps the booking status to the event status. Cancelled
+// bookings stay in the feed, so subscribed calendars drop them.
+func calendarStatus(status entity.BookingStatus) string {
+	switch status {
+	case entity.BookingStatusPending:
+		return ical.StatusTentative
+	case entity.BookingStatusCancelled:
+		return ical.StatusCancelled
+	default:
+		return ical.StatusConfirmed
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/ical"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalendarFeedUseCase_Feed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	feeds := mock.NewMockCalendarFeedRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+	masters := mock.NewMockMasterRepository(ctrl)
+	clients := mock.NewMockClientRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	useCase := NewCalendarFeedUseCase(feeds, bookings, masters, clients, services)
+
+	t.Run("client feed keeps cancelled bookings", func(t *testing.T) {
+		ctx := context.Background()
+		feed := &entity.CalendarFeed{OrganizationID: uuid.New(), OwnerRole: entity.RoleClient, OwnerID: uuid.New()}
+		masterID, serviceID := uuid.New(), uuid.New()
+		start := time.Now().Add(24 * time.Hour)
+
+		booked := []*entity.Booking{
+			{ID: uuid.New(), MasterID: masterID, ServiceID: serviceID, StartTime: start, EndTime: start.Add(time.Hour), Status: entity.BookingStatusConfirmed},
+			{ID: uuid.New(), MasterID: masterID, ServiceID: serviceID, StartTime: start, EndTime: start.Add(time.Hour), Status: entity.BookingStatusCancelled},
+		}
+
+		feeds.EXPECT().GetByHash(ctx, auth.HashToken("secret")).Return(feed, nil)
+		clients.EXPECT().GetByID(gomock.Any(), feed.OwnerID).Return(&entity.Client{Name: "Bob"}, nil)
+		bookings.EXPECT().GetByClientID(gomock.Any(), feed.OwnerID).Return(booked, nil)
+		// names are looked up once per feed
+		services.EXPECT().GetByID(gomock.Any(), serviceID).Return(&entity.Service{Name: "Haircut"}, nil)
+		masters.EXPECT().GetByID(gomock.Any(), masterID).Return(&entity.Master{Name: "Anna"}, nil)
+
+		cal, err := useCase.Feed(ctx, "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bob", cal.Name)
+		assert.Len(t, cal.Events, 2)
+		assert.Equal(t, booked[0].CalendarUID(), cal.Events[0].UID)
+		assert.Equal(t, "Haircut — Anna", cal.Events[0].Summary)
+		assert.Equal(t, ical.StatusConfirmed, cal.Events[0].Status)
+		assert.Equal(t, ical.StatusCancelled, cal.Events[1].Status)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		ctx := context.Background()
+		feeds.EXPECT().GetByHash(ctx, auth.HashToken("nope")).Return(nil, errors.ErrNotFound)
+
+		_, err := useCase.Feed(ctx, "nope")
+
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/google/uuid"
+)
+
+// publish adds a domain event to the outbox. Call it within the transaction
+// of the state change, so the event is stored if and only if the change is.
+func publish(ctx context.Context, outbox repository.OutboxRepository, t entity.EventType, aggregateID uuid.UUID, data any) error {
+	event, err := entity.NewEvent(t, aggregateID, data)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", t, err)
+	}
+	if err := outbox.Add(ctx, event); err != nil {
+		return fmt.Errorf("add %s event: %w", t, err)
+	}
+	return nil
+}
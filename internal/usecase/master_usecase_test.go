@@ -19,7 +19,7 @@ func TestMasterUseCase_GetByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockMasterRepository(ctrl)
-	useCase := NewMasterUseCase(mockRepo)
+	useCase := NewMasterUseCase(mockRepo, mock.NewMockScheduleRepository(ctrl))
 
 	t.Run("success", func(t *testing.T) {
 		ctx := context.Background()
@@ -27,8 +27,8 @@ func TestMasterUseCase_GetByID(t *testing.T) {
 		expectedMaster := &entity.Master{
 			ID:        id,
 			Name:      "John Doe",
-			Email:     "john@example.com",
-			Phone:     "+1234567890",
+			Email:     ptr("john@example.com"),
+			Phone:     ptr("+1234567890"),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		}
@@ -64,15 +64,14 @@ func TestMasterUseCase_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockMasterRepository(ctrl)
-	useCase := NewMasterUseCase(mockRepo)
+	useCase := NewMasterUseCase(mockRepo, mock.NewMockScheduleRepository(ctrl))
 
 	t.Run("success", func(t *testing.T) {
 		ctx := context.Background()
 		req := &dto.CreateMasterRequest{
-			Name:     "John Doe",
-			Email:    "john@example.com",
-			Phone:    "+1234567890",
-			Password: "123456",
+			Name:  "John Doe",
+			Email: ptr("john@example.com"),
+			Phone: ptr("+1234567890"),
 		}
 
 		createdMaster := &entity.Master{
@@ -89,9 +88,17 @@ func TestMasterUseCase_Create(t *testing.T) {
 				return nil
 			})
 
-		master, err := useCase.CreateMaster(ctx, req)
+		master, err := useCase.CreateMaster(ctx, &entity.Master{
+			Name:  req.Name,
+			Email: req.Email,
+			Phone: req.Phone,
+		})
 
 		assert.NoError(t, err)
 		assert.Equal(t, createdMaster, master)
 	})
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
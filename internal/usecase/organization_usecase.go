@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+)
+
+type OrganizationUseCase struct {
+	orgRepo   repository.OrganizationRepository
+	adminRepo repository.AdminRepository
+	tx        repository.TxManager
+}
+
+func NewOrganizationUseCase(or repository.OrganizationRepository, ar repository.AdminRepository, tx repository.TxManager) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		orgRepo:   or,
+		adminRepo: ar,
+		tx:        tx,
+	}
+}
+
+// CreateOrganization creates the organization together with its first admin,
+// who can then log in and manage it.
+func (uc *OrganizationUseCase) CreateOrganization(ctx context.Context, org *entity.Organization, admin *entity.Admin, password string) (*entity.Organization, error) {
+	if org.Timezone == "" {
+		org.Timezone = "UTC"
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+	admin.PasswordHash = hash
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.orgRepo.Create(ctx, org); err != nil {
+			return fmt.Errorf("create organization: %w", err)
+		}
+		if err := uc.adminRepo.Create(tenant.WithOrganizationID(ctx, org.ID), admin); err != nil {
+			return fmt.Errorf("create admin: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (uc *OrganizationUseCase) GetOrganizationByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	return uc.orgRepo.GetByID(ctx, id)
+}
+
+func (uc *OrganizationUseCase) UpdateOrganization(ctx context.Context, org *entity.Organization) error {
+	return uc.orgRepo.Update(ctx, org)
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/dto"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/curserio/chrono-api/pkg/timeutil"
+	"github.com/google/uuid"
+)
+
+// reportMastersPage is the number of masters loaded at once for reports
+// covering all masters.
+const reportMastersPage = 100
+
+// ReportUseCase aggregates bookings and schedules for managers.
+type ReportUseCase struct {
+	schedules *ScheduleUseCase
+	orgs      repository.OrganizationRepository
+	masters   repository.MasterRepository
+	services  repository.ServiceRepository
+	bookings  repository.BookingRepository
+}
+
+func NewReportUseCase(schedules *ScheduleUseCase, orgs repository.OrganizationRepository, masters repository.MasterRepository, services repository.ServiceRepository, bookings repository.BookingRepository) *ReportUseCase {
+	return &ReportUseCase{
+		schedules: schedules,
+		orgs:      orgs,
+		masters:   masters,
+		services:  services,
+		bookings:  bookings,
+	}
+}
+
+// RevenueOptions split a revenue report into periods and, optionally, by
+// master, service and booking status. Without Statuses, only completed
+// bookings count unless the report is split by status.
+type RevenueOptions struct {
+	Period    entity.ReportPeriod
+	ByMaster  bool
+	ByService bool
+	ByStatus  bool
+	Statuses  []entity.BookingStatus
+}
+
+// Utilization compares the working minutes of the master, or of all masters
+// for a zero masterID, with the minutes booked, per day and service, for the
+// dates from to to, inclusive. Cancelled bookings are left out. Masters get
+// their own report only.
+func (uc *ReportUseCase) Utilization(ctx context.Context, masterID uuid.UUID, from, to time.Time) (*dto.UtilizationReport, error) {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.Role == entity.RoleMaster && masterID == uuid.Nil {
+		masterID = p.SubjectID
+	}
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	from, to = timeutil.NormalizeDate(from), timeutil.NormalizeDate(to)
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	masters, err := uc.reportMasters(ctx, masterID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.UtilizationReport{
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Masters: make([]dto.MasterUtilization, 0, len(masters)),
+	}
+	for _, m := range masters {
+		mu, err := uc.masterUtilization(ctx, m, from, to)
+		if err != nil {
+			return nil, err
+		}
+		report.Masters = append(report.Masters, *mu)
+	}
+	return report, nil
+}
+
+// Revenue sums the prices of the bookings of the master, or of all masters
+// for a zero masterID, starting on the dates from to to, inclusive. By
+// default only completed bookings, the revenue actually earned, count. Dates
+// and periods are in the organization's timezone. Masters get their own report
+// only.
+func (uc *ReportUseCase) Revenue(ctx context.Context, masterID uuid.UUID, from, to time.Time, opts RevenueOptions) (*dto.RevenueReport, error) {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.Role == entity.RoleMaster && masterID == uuid.Nil {
+		masterID = p.SubjectID
+	}
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	from, to = timeutil.NormalizeDate(from), timeutil.NormalizeDate(to)
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	loc, err := uc.location(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := entity.RevenueQuery{
+		// midnights of the dates in the organization's timezone
+		From:      time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc),
+		To:        time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc),
+		Location:  loc.String(),
+		Period:    opts.Period,
+		MasterID:  masterID,
+		Statuses:  opts.Statuses,
+		ByMaster:  opts.ByMaster,
+		ByService: opts.ByService,
+		ByStatus:  opts.ByStatus,
+	}
+	if q.Period == "" {
+		q.Period = entity.ReportPeriodDay
+	}
+	if len(q.Statuses) == 0 && !q.ByStatus {
+		q.Statuses = []entity.BookingStatus{entity.BookingStatusCompleted}
+	}
+
+	rows, err := uc.bookings.Revenue(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sum revenue: %w", err)
+	}
+
+	report := &dto.RevenueReport{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Timezone: q.Location,
+		Period:   string(q.Period),
+		Rows:     make([]dto.RevenueRow, 0, len(rows)),
+	}
+	for _, r := range rows {
+		report.Bookings += r.Bookings
+		report.Revenue += r.Revenue
+		report.Rows = append(report.Rows, dto.RevenueRow{
+			Period:      r.Period.Format(time.DateOnly),
+			MasterID:    r.MasterID,
+			MasterName:  r.MasterName,
+			ServiceID:   r.ServiceID,
+			ServiceName: r.ServiceName,
+			Status:      string(r.Status),
+			Bookings:    r.Bookings,
+			Revenue:     r.Revenue,
+		})
+	}
+	// prices have two decimals; rounding drops the float error of the sum
+	report.Revenue = math.Round(report.Revenue*100) / 100
+	return report, nil
+}
+
+// location returns the timezone of the organization, UTC if it has none.
+func (uc *ReportUseCase) location(ctx context.Context) (*time.Location, error) {
+	orgID, err := tenant.OrganizationID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	org, err := uc.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get organization: %w", err)
+	}
+	loc, err := time.LoadLocation(org.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load timezone %q: %w", org.Timezone, err)
+	}
+	return loc, nil
+}
+
+func (uc *ReportUseCase) masterUtilization(ctx context.Context, m *entity.Master, from, to time.Time) (*dto.MasterUtilization, error) {
+	services, err := uc.services.GetByMasterID(ctx, m.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(services))
+	for _, s := range services {
+		names[s.ID] = s.Name
+	}
+
+	bookings, err := uc.bookings.GetByMasterID(ctx, m.ID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("list bookings: %w", err)
+	}
+	active := make([]*entity.Booking, 0, len(bookings))
+	byDate := make(map[string][]*entity.Booking)
+	for _, b := range bookings {
+		if b.Status == entity.BookingStatusCancelled {
+			continue
+		}
+		active = append(active, b)
+		// dates are UTC, as in the schedule engine
+		key := b.StartTime.UTC().Format(time.DateOnly)
+		byDate[key] = append(byDate[key], b)
+	}
+
+	out := &dto.MasterUtilization{
+		MasterID:   m.ID,
+		MasterName: m.Name,
+		Days:       make([]dto.DayUtilization, 0, daysBetween(from, to)+1),
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		hours, err := uc.schedules.scheduleForDate(ctx, m.ID, date)
+		if err != nil {
+			return nil, err
+		}
+
+		key := date.Format(time.DateOnly)
+		day := dto.DayUtilization{Date: key}
+		day.WorkingMinutes = workingMinutes(hours)
+		day.Services, day.BookedMinutes = serviceUtilization(byDate[key], names)
+		day.Rate = utilizationRate(day.WorkingMinutes, day.BookedMinutes)
+
+		out.WorkingMinutes += day.WorkingMinutes
+		out.Days = append(out.Days, day)
+	}
+	out.Services, out.BookedMinutes = serviceUtilization(active, names)
+	out.Rate = utilizationRate(out.WorkingMinutes, out.BookedMinutes)
+	return out, nil
+}
+
+// reportMasters returns the master, or all masters for uuid.Nil.
+func (uc *ReportUseCase) reportMasters(ctx context.Context, masterID uuid.UUID) ([]*entity.Master, error) {
+	if masterID != uuid.Nil {
+		m, err := uc.masters.GetByID(ctx, masterID)
+		if err != nil {
+			return nil, err
+		}
+		return []*entity.Master{m}, nil
+	}
+
+	var masters []*entity.Master
+	for offset := 0; ; offset += reportMastersPage {
+		page, err := uc.masters.List(ctx, offset, reportMastersPage)
+		if err != nil {
+			return nil, fmt.Errorf("list masters: %w", err)
+		}
+		masters = append(masters, page...)
+		if len(page) < reportMastersPage {
+			return masters, nil
+		}
+	}
+}
+
+// serviceUtilization sums the bookings per service, most booked first, and
+// returns the total minutes booked.
+func serviceUtilization(bookings []*entity.Booking, names map[uuid.UUID]string) ([]dto.ServiceUtilization, int) {
+	out := make([]dto.ServiceUtilization, 0)
+	index := make(map[uuid.UUID]int)
+	total := 0
+	for _, b := range bookings {
+		minutes := int(b.EndTime.Sub(b.StartTime).Minutes())
+		i, ok := index[b.ServiceID]
+		if !ok {
+			i = len(out)
+			index[b.ServiceID] = i
+			out = append(out, dto.ServiceUtilization{ServiceID: b.ServiceID, ServiceName: names[b.ServiceID]})
+		}
+		out[i].Bookings++
+		out[i].BookedMinutes += minutes
+		total += minutes
+	}
+	slices.SortStableFunc(out, func(a, b dto.ServiceUtilization) int {
+		return cmp.Compare(b.BookedMinutes, a.BookedMinutes)
+	})
+	return out, total
+}
+
+// workingMinutes sums the working periods of a date.
+func workingMinutes(hours []dto.ScheduleForDateResponse) int {
+	total := 0
+	for _, h := range hours {
+		if h.IsDayOff || h.StartTime == nil || h.EndTime == nil {
+			continue
+		}
+		start, err1 := time.Parse("15:04", *h.StartTime)
+		end, err2 := time.Parse("15:04", *h.EndTime)
+		if err1 != nil || err2 != nil || !start.Before(end) {
+			continue
+		}
+		total += int(end.Sub(start).Minutes())
+	}
+	return total
+}
+
+// utilizationRate is the booked share of the working time, rounded to three
+// decimals.
+func utilizationRate(working, booked int) float64 {
+	if working == 0 {
+		return 0
+	}
+	return math.Round(float64(booked)/float64(working)*1000) / 1000
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/internal/tenant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportUseCase_Utilization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scheduleRepo := mock.NewMockScheduleRepository(ctrl)
+	masters := mock.NewMockMasterRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+
+	useCase := NewReportUseCase(NewScheduleUseCase(scheduleRepo, nil, bookings, nil, nil), nil, masters, services, bookings)
+	ctx := context.Background()
+
+	master := &entity.Master{ID: uuid.New(), Name: "Anna"}
+	haircut := &entity.Service{ID: uuid.New(), MasterID: master.ID, Name: "Haircut"}
+	colour := &entity.Service{ID: uuid.New(), MasterID: master.ID, Name: "Colour"}
+	monday, tuesday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	nine, five := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 17, 0, 0, 0, time.UTC)
+	booking := func(service *entity.Service, hour, minutes int, status entity.BookingStatus) *entity.Booking {
+		start := monday.Add(time.Duration(hour) * time.Hour)
+		return &entity.Booking{ID: uuid.New(), MasterID: master.ID, ServiceID: service.ID, StartTime: start, EndTime: start.Add(time.Duration(minutes) * time.Minute), Status: status}
+	}
+
+	masters.EXPECT().GetByID(ctx, master.ID).Return(master, nil)
+	services.EXPECT().GetByMasterID(ctx, master.ID).Return([]*entity.Service{haircut, colour}, nil)
+	bookings.EXPECT().GetByMasterID(ctx, master.ID, monday, tuesday.AddDate(0, 0, 1)).Return([]*entity.Booking{
+		booking(haircut, 9, 60, entity.BookingStatusCompleted),
+		booking(colour, 11, 120, entity.BookingStatusConfirmed),
+		booking(haircut, 14, 60, entity.BookingStatusCancelled),
+	}, nil)
+	scheduleRepo.EXPECT().GetSlotsByDate(ctx, master.ID, monday).Return([]*entity.ScheduleSlot{{StartTime: &nine, EndTime: &five}}, nil)
+	scheduleRepo.EXPECT().GetSlotsByDate(ctx, master.ID, tuesday).Return([]*entity.ScheduleSlot{{IsDayOff: true}}, nil)
+
+	report, err := useCase.Utilization(ctx, master.ID, monday, tuesday)
+
+	assert.NoError(t, err)
+	if assert.Len(t, report.Masters, 1) {
+		m := report.Masters[0]
+		assert.Equal(t, 480, m.WorkingMinutes)
+		assert.Equal(t, 180, m.BookedMinutes, "cancelled bookings are left out")
+		assert.Equal(t, 0.375, m.Rate)
+		assert.Equal(t, "Colour", m.Services[0].ServiceName, "the most booked service comes first")
+		assert.Len(t, m.Days, 2)
+		assert.Len(t, m.Days[0].Services, 2)
+		assert.Equal(t, 0, m.Days[1].WorkingMinutes)
+		assert.Equal(t, 0.0, m.Days[1].Rate)
+	}
+}
+
+func TestReportUseCase_UtilizationOverlappingSchedules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scheduleRepo := mock.NewMockScheduleRepository(ctrl)
+	masters := mock.NewMockMasterRepository(ctrl)
+	services := mock.NewMockServiceRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+
+	useCase := NewReportUseCase(NewScheduleUseCase(scheduleRepo, nil, bookings, nil, nil), nil, masters, services, bookings)
+	ctx := context.Background()
+
+	master := &entity.Master{ID: uuid.New(), Name: "Anna"}
+	haircut := &entity.Service{ID: uuid.New(), MasterID: master.ID, Name: "Haircut"}
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	base := &entity.Schedule{ID: uuid.New(), MasterID: master.ID, Name: "Main", Type: entity.ScheduleTypeWeekly, StartDate: monday.AddDate(-1, 0, 0)}
+	short := &entity.Schedule{ID: uuid.New(), MasterID: master.ID, Name: "Short week", Type: entity.ScheduleTypeWeekly, StartDate: monday, Priority: 1}
+	ten, four := time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 16, 0, 0, 0, time.UTC)
+	start := monday.Add(10 * time.Hour)
+
+	masters.EXPECT().GetByID(ctx, master.ID).Return(master, nil)
+	services.EXPECT().GetByMasterID(ctx, master.ID).Return([]*entity.Service{haircut}, nil)
+	bookings.EXPECT().GetByMasterID(ctx, master.ID, monday, monday.AddDate(0, 0, 1)).Return([]*entity.Booking{
+		{ID: uuid.New(), MasterID: master.ID, ServiceID: haircut.ID, StartTime: start, EndTime: start.Add(90 * time.Minute), Status: entity.BookingStatusConfirmed},
+	}, nil)
+	scheduleRepo.EXPECT().GetSlotsByDate(ctx, master.ID, monday).Return(nil, nil)
+	scheduleRepo.EXPECT().GetForDate(ctx, master.ID, monday).Return([]*entity.Schedule{short, base}, nil)
+	// the days of the base schedule are not loaded, so its hours do not add up
+	scheduleRepo.EXPECT().GetDaysByWeekday(ctx, short.ID, 1).Return([]*entity.ScheduleDay{{ScheduleID: short.ID, Weekday: ptr(1), StartTime: &ten, EndTime: &four}}, nil)
+
+	report, err := useCase.Utilization(ctx, master.ID, monday, monday)
+
+	assert.NoError(t, err)
+	if assert.Len(t, report.Masters, 1) {
+		m := report.Masters[0]
+		assert.Equal(t, 360, m.WorkingMinutes, "only the schedule of the highest priority counts")
+		assert.Equal(t, 90, m.BookedMinutes)
+		assert.Equal(t, 0.25, m.Rate)
+	}
+}
+
+func TestReportUseCase_Revenue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orgs := mock.NewMockOrganizationRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+
+	useCase := NewReportUseCase(nil, orgs, nil, nil, bookings)
+	org := &entity.Organization{ID: uuid.New(), Timezone: "Europe/Moscow"}
+	ctx := tenant.WithOrganizationID(context.Background(), org.ID)
+	moscow, _ := time.LoadLocation(org.Timezone)
+	from, to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	masterID := uuid.New()
+
+	t.Run("dates are in the organization's timezone", func(t *testing.T) {
+		orgs.EXPECT().GetByID(ctx, org.ID).Return(org, nil)
+		bookings.EXPECT().Revenue(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, q entity.RevenueQuery) ([]*entity.RevenueRow, error) {
+			assert.True(t, q.From.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, moscow)))
+			assert.True(t, q.To.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, moscow)))
+			assert.Equal(t, "Europe/Moscow", q.Location)
+			assert.Equal(t, entity.ReportPeriodMonth, q.Period)
+			assert.Equal(t, []entity.BookingStatus{entity.BookingStatusCompleted}, q.Statuses, "only completed bookings by default")
+			return []*entity.RevenueRow{
+				{Period: from, MasterID: &masterID, MasterName: "Anna", Bookings: 2, Revenue: 0.1},
+				{Period: from, MasterID: ptr(uuid.New()), MasterName: "Olga", Bookings: 1, Revenue: 0.2},
+			}, nil
+		})
+
+		report, err := useCase.Revenue(ctx, uuid.Nil, from, to, RevenueOptions{Period: entity.ReportPeriodMonth, ByMaster: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.Bookings)
+		assert.Equal(t, 0.3, report.Revenue)
+		if assert.Len(t, report.Rows, 2) {
+			assert.Equal(t, "2025-03-01", report.Rows[0].Period)
+			assert.Equal(t, &masterID, report.Rows[0].MasterID)
+		}
+	})
+
+	t.Run("masters get their own report", func(t *testing.T) {
+		masterCtx := auth.WithPrincipal(ctx, &auth.Principal{SubjectID: masterID, Role: entity.RoleMaster})
+		orgs.EXPECT().GetByID(masterCtx, org.ID).Return(org, nil)
+		bookings.EXPECT().Revenue(masterCtx, gomock.Any()).DoAndReturn(func(_ context.Context, q entity.RevenueQuery) ([]*entity.RevenueRow, error) {
+			assert.Equal(t, masterID, q.MasterID)
+			assert.Empty(t, q.Statuses, "all statuses when split by status")
+			return nil, nil
+		})
+
+		_, err := useCase.Revenue(masterCtx, uuid.Nil, from, to, RevenueOptions{ByStatus: true})
+		assert.NoError(t, err)
+
+		_, err = useCase.Revenue(masterCtx, uuid.New(), from, to, RevenueOptions{})
+		assert.ErrorIs(t, err, errors.ErrForbidden)
+	})
+}
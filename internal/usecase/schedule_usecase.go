@@ -2,40 +2,179 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/curserio/chrono-api/internal/auth"
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	"github.com/curserio/chrono-api/internal/dto"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
 	"github.com/curserio/chrono-api/internal/repository"
 	"github.com/curserio/chrono-api/pkg/timeutil"
 	"github.com/google/uuid"
 )
 
+// maxSlotRangeDays limits the dates overridden by one request.
+const maxSlotRangeDays = 366
+
+// impactHorizonDays limits how far ahead the bookings affected by a change of
+// a schedule are looked for.
+const impactHorizonDays = 366
+
+// ConflictAction is what happens to the bookings a change of a schedule
+// leaves outside the working hours of their master.
+type ConflictAction string
+
+const (
+	ConflictKeep   ConflictAction = "keep"
+	ConflictNotify ConflictAction = "notify"
+	ConflictCancel ConflictAction = "cancel"
+)
+
+// ChangeOptions control a change of a schedule. A dry run only reports the
+// bookings the change would leave outside the working hours.
+type ChangeOptions struct {
+	DryRun     bool
+	OnConflict ConflictAction
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
 type ScheduleUseCase struct {
-	repo repository.ScheduleRepository
+	repo     repository.ScheduleRepository
+	busyRepo repository.BusyBlockRepository
+	bookings repository.BookingRepository
+	tx       repository.TxManager
+	outbox   repository.OutboxRepository
+}
+
+func NewScheduleUseCase(repo repository.ScheduleRepository, busyRepo repository.BusyBlockRepository, bookings repository.BookingRepository, tx repository.TxManager, outbox repository.OutboxRepository) *ScheduleUseCase {
+	return &ScheduleUseCase{
+		repo:     repo,
+		busyRepo: busyRepo,
+		bookings: bookings,
+		tx:       tx,
+		outbox:   outbox,
+	}
+}
+
+// ScheduleUpdate lists the changes of a schedule. Nil fields are left
+// unchanged; non-nil Days replace all days of the schedule.
+type ScheduleUpdate struct {
+	MasterID  *uuid.UUID
+	Name      *string
+	Type      *entity.ScheduleType
+	StartDate *time.Time
+	EndDate   *time.Time
+	// ClearEndDate makes the schedule open-ended; EndDate is ignored
+	ClearEndDate bool
+	Priority     *int
+	Days         []*entity.ScheduleDay
+}
+
+// SlotRange gives the dates from From to To, inclusive, the same hours, or
+// makes them days off.
+type SlotRange struct {
+	From      time.Time
+	To        time.Time
+	StartTime *time.Time
+	EndTime   *time.Time
+	IsDayOff  bool
 }
 
-func NewScheduleUseCase(repo repository.ScheduleRepository) *ScheduleUseCase {
-	return &ScheduleUseCase{repo: repo}
+// ScheduleCopy describes the schedule created from another one. A zero
+// MasterID copies a template into a template. A new StartDate shifts the end
+// date and the date overrides along with it.
+type ScheduleCopy struct {
+	MasterID     uuid.UUID
+	Name         *string
+	StartDate    *time.Time
+	EndDate      *time.Time
+	Priority     *int
+	IncludeSlots bool
 }
 
 func (uc *ScheduleUseCase) CreateSchedule(ctx context.Context, schedule *entity.Schedule, days []*entity.ScheduleDay) (*entity.Schedule, error) {
-	if err := uc.repo.Create(ctx, schedule); err != nil {
-		return nil, fmt.Errorf("create schedule: %w", err)
+	if err := auth.AuthorizeMaster(ctx, schedule.MasterID); err != nil {
+		return nil, err
+	}
+	if err := validateSchedule(schedule, days); err != nil {
+		return nil, err
 	}
 
-	// Добавляем дни недели
-	for _, d := range days {
-		d.ScheduleID = schedule.ID
-		if err := uc.repo.AddDay(ctx, d); err != nil {
-			return nil, fmt.Errorf("add schedule day: %w", err)
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkOverlap(ctx, schedule); err != nil {
+			return err
+		}
+		if err := uc.repo.Create(ctx, schedule); err != nil {
+			return fmt.Errorf("create schedule: %w", err)
+		}
+
+		// Добавляем дни недели
+		for _, d := range days {
+			d.ScheduleID = schedule.ID
+			if err := uc.repo.AddDay(ctx, d); err != nil {
+				return fmt.Errorf("add schedule day: %w", err)
+			}
 		}
+
+		return publish(ctx, uc.outbox, entity.EventScheduleCreated, schedule.ID, schedule)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return schedule, nil
 }
 
+// CreateTemplate creates a schedule not bound to a master, to be instantiated
+// for masters later.
+func (uc *ScheduleUseCase) CreateTemplate(ctx context.Context, template *entity.Schedule, days []*entity.ScheduleDay) (*entity.Schedule, error) {
+	template.MasterID = uuid.Nil
+	template.IsTemplate = true
+	return uc.CreateSchedule(ctx, template, days)
+}
+
+func (uc *ScheduleUseCase) ListTemplates(ctx context.Context, offset, limit int) ([]*entity.Schedule, error) {
+	return uc.repo.ListTemplates(ctx, offset, limit)
+}
+
+// InstantiateTemplate creates a schedule of cp.MasterID with the days of the
+// template.
+func (uc *ScheduleUseCase) InstantiateTemplate(ctx context.Context, templateID uuid.UUID, cp ScheduleCopy) (*entity.ScheduleDetails, error) {
+	template, err := uc.repo.GetDetails(ctx, templateID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsTemplate {
+		return nil, apiErrors.ErrNotFound
+	}
+
+	cp.IncludeSlots = false
+	return uc.copySchedule(ctx, template, cp)
+}
+
+// CloneSchedule copies the schedule with its days, and optionally its date
+// overrides, to another master or period. Without a master the copy belongs
+// to the master of the source.
+func (uc *ScheduleUseCase) CloneSchedule(ctx context.Context, id uuid.UUID, cp ScheduleCopy) (*entity.ScheduleDetails, error) {
+	source, err := uc.repo.GetDetails(ctx, id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeMaster(ctx, source.MasterID); err != nil {
+		return nil, err
+	}
+
+	if cp.MasterID == uuid.Nil {
+		cp.MasterID = source.MasterID
+	}
+	return uc.copySchedule(ctx, source, cp)
+}
+
 func (uc *ScheduleUseCase) GetScheduleByID(ctx context.Context, id uuid.UUID) (*entity.Schedule, error) {
 	schedule, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
@@ -44,6 +183,22 @@ func (uc *ScheduleUseCase) GetScheduleByID(ctx context.Context, id uuid.UUID) (*
 	return schedule, nil
 }
 
+// GetScheduleDetails returns the schedule with its days and date overrides.
+// Given a window, only the overrides within it are returned and the schedule
+// is expanded into the hours of every date of the window.
+func (uc *ScheduleUseCase) GetScheduleDetails(ctx context.Context, id uuid.UUID, from, to *time.Time) (*dto.ScheduleDetailsResponse, error) {
+	details, err := uc.repo.GetDetails(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ScheduleDetailsResponse{ScheduleDetails: details}
+	if from != nil && to != nil {
+		resp.Dates = expandSchedule(details, timeutil.NormalizeDate(*from), timeutil.NormalizeDate(*to))
+	}
+	return resp, nil
+}
+
 func (uc *ScheduleUseCase) GetSchedulesByMaster(ctx context.Context, masterID uuid.UUID) ([]*entity.Schedule, error) {
 	schedules, err := uc.repo.GetByMasterID(ctx, masterID)
 	if err != nil {
@@ -57,25 +212,118 @@ func (uc *ScheduleUseCase) ListSchedules(ctx context.Context, offset, limit int)
 	return uc.repo.List(ctx, offset, limit)
 }
 
-func (uc *ScheduleUseCase) UpdateSchedule(ctx context.Context, s *entity.Schedule) error {
-	if err := uc.repo.Update(ctx, s); err != nil {
-		return fmt.Errorf("update schedule: %w", err)
+// UpdateSchedule applies the changes and returns the schedule with its days
+// and the bookings the change leaves outside the working hours, if opts ask
+// for them.
+func (uc *ScheduleUseCase) UpdateSchedule(ctx context.Context, id uuid.UUID, upd ScheduleUpdate, opts ChangeOptions) (*entity.Schedule, []*entity.ScheduleDay, []*entity.Booking, error) {
+	s, err := uc.authorize(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return nil
+	scope := opts.scope(time.Time{}, time.Time{}, s.MasterID)
+	// a schedule moved in time or between masters, or given another priority,
+	// may overlap others
+	moved := upd.MasterID != nil || upd.StartDate != nil || upd.EndDate != nil || upd.ClearEndDate || upd.Priority != nil
+
+	if upd.MasterID != nil {
+		if s.IsTemplate || *upd.MasterID == uuid.Nil {
+			return nil, nil, nil, apiErrors.ErrScheduleTemplateMaster
+		}
+		// moving a schedule needs access to both masters
+		if err := auth.AuthorizeMaster(ctx, *upd.MasterID); err != nil {
+			return nil, nil, nil, err
+		}
+		s.MasterID = *upd.MasterID
+		if scope != nil {
+			scope.masterIDs = append(scope.masterIDs, s.MasterID)
+		}
+	}
+	if upd.Name != nil {
+		s.Name = *upd.Name
+	}
+	if upd.Type != nil {
+		s.Type = *upd.Type
+	}
+	if upd.StartDate != nil {
+		s.StartDate = *upd.StartDate
+	}
+	if upd.ClearEndDate {
+		s.EndDate = nil
+	} else if upd.EndDate != nil {
+		s.EndDate = upd.EndDate
+	}
+	if upd.Priority != nil {
+		s.Priority = *upd.Priority
+	}
+
+	var days []*entity.ScheduleDay
+	conflicts, err := uc.applyChange(ctx, opts, scope, func(ctx context.Context) error {
+		if upd.Days != nil {
+			days = upd.Days
+			if err := uc.repo.DeleteDaysByScheduleID(ctx, s.ID); err != nil {
+				return fmt.Errorf("delete schedule days: %w", err)
+			}
+			for _, d := range days {
+				d.ScheduleID = s.ID
+				if err := uc.repo.AddDay(ctx, d); err != nil {
+					return fmt.Errorf("add schedule day: %w", err)
+				}
+			}
+		} else {
+			// the days must still fit a changed type
+			if days, err = uc.repo.GetDaysByScheduleID(ctx, s.ID); err != nil {
+				return fmt.Errorf("get schedule days: %w", err)
+			}
+		}
+		if err := validateSchedule(s, days); err != nil {
+			return err
+		}
+		if moved {
+			if err := uc.checkOverlap(ctx, s); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.repo.Update(ctx, s); err != nil {
+			return fmt.Errorf("update schedule: %w", err)
+		}
+		return publish(ctx, uc.outbox, entity.EventScheduleUpdated, s.ID, s)
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return s, days, conflicts, nil
 }
 
-func (uc *ScheduleUseCase) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
-	if err := uc.repo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("delete schedule: %w", err)
+func (uc *ScheduleUseCase) DeleteSchedule(ctx context.Context, id uuid.UUID, opts ChangeOptions) ([]*entity.Booking, error) {
+	schedule, err := uc.authorize(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return uc.applyChange(ctx, opts, opts.scope(time.Time{}, time.Time{}, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("delete schedule: %w", err)
+		}
+		return publish(ctx, uc.outbox, entity.EventScheduleDeleted, id, schedule)
+	})
 }
 
-func (uc *ScheduleUseCase) AddDay(ctx context.Context, day *entity.ScheduleDay) error {
-	if err := uc.repo.AddDay(ctx, day); err != nil {
-		return fmt.Errorf("add day: %w", err)
+func (uc *ScheduleUseCase) AddDay(ctx context.Context, day *entity.ScheduleDay, opts ChangeOptions) ([]*entity.Booking, error) {
+	schedule, err := uc.authorize(ctx, day.ScheduleID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if err := validateDay(schedule.Type, day); err != nil {
+		return nil, err
+	}
+
+	return uc.changeSchedule(ctx, schedule, opts, opts.scope(time.Time{}, time.Time{}, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.AddDay(ctx, day); err != nil {
+			return fmt.Errorf("add day: %w", err)
+		}
+		return uc.validateDays(ctx, schedule)
+	})
 }
 
 func (uc *ScheduleUseCase) GetDayByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleDay, error) {
@@ -94,33 +342,198 @@ func (uc *ScheduleUseCase) GetDaysBySchedule(ctx context.Context, scheduleID uui
 	return days, nil
 }
 
-func (uc *ScheduleUseCase) GetDaysByWeekday(ctx context.Context, masterID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
-	days, err := uc.repo.GetDaysByWeekday(ctx, masterID, weekday)
+func (uc *ScheduleUseCase) GetDaysByWeekday(ctx context.Context, scheduleID uuid.UUID, weekday int) ([]*entity.ScheduleDay, error) {
+	days, err := uc.repo.GetDaysByWeekday(ctx, scheduleID, weekday)
 	if err != nil {
 		return nil, fmt.Errorf("get days by weekday: %w", err)
 	}
 	return days, nil
 }
 
-func (uc *ScheduleUseCase) UpdateDay(ctx context.Context, day *entity.ScheduleDay) error {
-	if err := uc.repo.UpdateDay(ctx, day); err != nil {
-		return fmt.Errorf("update day: %w", err)
+func (uc *ScheduleUseCase) UpdateDay(ctx context.Context, day *entity.ScheduleDay, opts ChangeOptions) ([]*entity.Booking, error) {
+	current, err := uc.authorizeDay(ctx, day.ID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	schedule, err := uc.authorize(ctx, day.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDay(schedule.Type, day); err != nil {
+		return nil, err
+	}
+
+	scope := opts.scope(time.Time{}, time.Time{}, current.MasterID, schedule.MasterID)
+	return uc.changeSchedule(ctx, schedule, opts, scope, func(ctx context.Context) error {
+		if err := uc.repo.UpdateDay(ctx, day); err != nil {
+			return fmt.Errorf("update day: %w", err)
+		}
+		if current.ID != schedule.ID {
+			// moving the day may leave a gap in the cycle of its former schedule
+			if err := uc.validateDays(ctx, current); err != nil {
+				return err
+			}
+		}
+		return uc.validateDays(ctx, schedule)
+	})
 }
 
-func (uc *ScheduleUseCase) DeleteDay(ctx context.Context, id uuid.UUID) error {
-	if err := uc.repo.DeleteDay(ctx, id); err != nil {
-		return fmt.Errorf("delete day: %w", err)
+func (uc *ScheduleUseCase) DeleteDay(ctx context.Context, id uuid.UUID, opts ChangeOptions) ([]*entity.Booking, error) {
+	schedule, err := uc.authorizeDay(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return uc.changeSchedule(ctx, schedule, opts, opts.scope(time.Time{}, time.Time{}, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.DeleteDay(ctx, id); err != nil {
+			return fmt.Errorf("delete day: %w", err)
+		}
+		return uc.validateDays(ctx, schedule)
+	})
 }
 
-func (uc *ScheduleUseCase) AddSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
-	if err := uc.repo.AddSlot(ctx, slot); err != nil {
-		return fmt.Errorf("add slot: %w", err)
+func (uc *ScheduleUseCase) AddSlot(ctx context.Context, slot *entity.ScheduleSlot, opts ChangeOptions) ([]*entity.Booking, error) {
+	schedule, err := uc.authorize(ctx, slot.ScheduleID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if err := validateHours(slot.StartTime, slot.EndTime, slot.IsDayOff); err != nil {
+		return nil, err
+	}
+
+	return uc.changeSchedule(ctx, schedule, opts, opts.scope(slot.Date, slot.Date, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.AddSlot(ctx, slot); err != nil {
+			return fmt.Errorf("add slot: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetSlotRange replaces the overrides of the dates of the range with one per
+// date, e.g. for a vacation. It returns the new overrides and the bookings of
+// the master the change leaves outside the working hours, which are always
+// looked for.
+func (uc *ScheduleUseCase) SetSlotRange(ctx context.Context, scheduleID uuid.UUID, r SlotRange, opts ChangeOptions) ([]*entity.ScheduleSlot, []*entity.Booking, error) {
+	schedule, err := uc.authorize(ctx, scheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, to := timeutil.NormalizeDate(r.From), timeutil.NormalizeDate(r.To)
+	if err := validateDateRange(from, to); err != nil {
+		return nil, nil, err
+	}
+	if err := validateHours(r.StartTime, r.EndTime, r.IsDayOff); err != nil {
+		return nil, nil, err
+	}
+
+	slots := make([]*entity.ScheduleSlot, 0, daysBetween(from, to)+1)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		slots = append(slots, &entity.ScheduleSlot{
+			ScheduleID: scheduleID,
+			Date:       date,
+			StartTime:  r.StartTime,
+			EndTime:    r.EndTime,
+			IsDayOff:   r.IsDayOff,
+		})
+	}
+
+	conflicts, err := uc.changeSchedule(ctx, schedule, opts, newChangeScope(from, to, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.DeleteSlotsInRange(ctx, scheduleID, from, to); err != nil {
+			return fmt.Errorf("delete slots: %w", err)
+		}
+		for _, slot := range slots {
+			if err := uc.repo.AddSlot(ctx, slot); err != nil {
+				return fmt.Errorf("add slot: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return slots, conflicts, nil
+}
+
+// ClearSlotRange deletes the overrides of the dates from from to to,
+// inclusive, so that the days of the schedule apply again.
+func (uc *ScheduleUseCase) ClearSlotRange(ctx context.Context, scheduleID uuid.UUID, from, to time.Time, opts ChangeOptions) ([]*entity.Booking, error) {
+	schedule, err := uc.authorize(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to = timeutil.NormalizeDate(from), timeutil.NormalizeDate(to)
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	return uc.changeSchedule(ctx, schedule, opts, opts.scope(from, to, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.DeleteSlotsInRange(ctx, scheduleID, from, to); err != nil {
+			return fmt.Errorf("delete slots: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetDaysOff makes the dates from from to to, inclusive, days off of the
+// master, e.g. for approved time off. The overrides of the master on the dates
+// are replaced by a day-off override in the schedule applying to each date;
+// dates no schedule covers are days off already. It returns the bookings the
+// change leaves outside the working hours, which are always looked for.
+func (uc *ScheduleUseCase) SetDaysOff(ctx context.Context, masterID uuid.UUID, from, to time.Time, opts ChangeOptions) ([]*entity.Booking, error) {
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+
+	from, to = timeutil.NormalizeDate(from), timeutil.NormalizeDate(to)
+	if err := validateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	return uc.applyChange(ctx, opts, newChangeScope(from, to, masterID), func(ctx context.Context) error {
+		changed := make(map[uuid.UUID]bool)
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			slots, err := uc.repo.GetSlotsByDate(ctx, masterID, date)
+			if err != nil {
+				return fmt.Errorf("get slots by date: %w", err)
+			}
+			for _, slot := range slots {
+				if err := uc.repo.DeleteSlot(ctx, slot.ID); err != nil {
+					return fmt.Errorf("delete slot: %w", err)
+				}
+				changed[slot.ScheduleID] = true
+			}
+
+			schedules, err := uc.repo.GetForDate(ctx, masterID, date)
+			if err != nil {
+				return fmt.Errorf("get schedules: %w", err)
+			}
+			if len(schedules) == 0 {
+				continue
+			}
+			// the first schedule is the one applying to the date
+			slot := &entity.ScheduleSlot{ScheduleID: schedules[0].ID, Date: date, IsDayOff: true}
+			if err := uc.repo.AddSlot(ctx, slot); err != nil {
+				return fmt.Errorf("add slot: %w", err)
+			}
+			changed[slot.ScheduleID] = true
+		}
+
+		schedules, err := uc.repo.GetByMasterID(ctx, masterID)
+		if err != nil {
+			return fmt.Errorf("get schedules: %w", err)
+		}
+		for _, s := range schedules {
+			if !changed[s.ID] {
+				continue
+			}
+			if err := publish(ctx, uc.outbox, entity.EventScheduleUpdated, s.ID, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (uc *ScheduleUseCase) GetSlotByID(ctx context.Context, id uuid.UUID) (*entity.ScheduleSlot, error) {
@@ -150,18 +563,44 @@ func (uc *ScheduleUseCase) GetSlotsByDate(ctx context.Context, masterID uuid.UUI
 	return slots, nil
 }
 
-func (uc *ScheduleUseCase) UpdateSlot(ctx context.Context, slot *entity.ScheduleSlot) error {
-	if err := uc.repo.UpdateSlot(ctx, slot); err != nil {
-		return fmt.Errorf("update slot: %w", err)
+func (uc *ScheduleUseCase) UpdateSlot(ctx context.Context, slot *entity.ScheduleSlot, opts ChangeOptions) ([]*entity.Booking, error) {
+	current, currentSchedule, err := uc.authorizeSlot(ctx, slot.ID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	schedule, err := uc.authorize(ctx, slot.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHours(slot.StartTime, slot.EndTime, slot.IsDayOff); err != nil {
+		return nil, err
+	}
+
+	from, to := current.Date, slot.Date
+	if to.Before(from) {
+		from, to = to, from
+	}
+	scope := opts.scope(from, to, currentSchedule.MasterID, schedule.MasterID)
+	return uc.changeSchedule(ctx, schedule, opts, scope, func(ctx context.Context) error {
+		if err := uc.repo.UpdateSlot(ctx, slot); err != nil {
+			return fmt.Errorf("update slot: %w", err)
+		}
+		return nil
+	})
 }
 
-func (uc *ScheduleUseCase) DeleteSlot(ctx context.Context, id uuid.UUID) error {
-	if err := uc.repo.DeleteSlot(ctx, id); err != nil {
-		return fmt.Errorf("delete slot: %w", err)
+func (uc *ScheduleUseCase) DeleteSlot(ctx context.Context, id uuid.UUID, opts ChangeOptions) ([]*entity.Booking, error) {
+	slot, schedule, err := uc.authorizeSlot(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return uc.changeSchedule(ctx, schedule, opts, opts.scope(slot.Date, slot.Date, schedule.MasterID), func(ctx context.Context) error {
+		if err := uc.repo.DeleteSlot(ctx, id); err != nil {
+			return fmt.Errorf("delete slot: %w", err)
+		}
+		return nil
+	})
 }
 
 // GetScheduleForDate returns the effective schedule for a given master and date.
@@ -177,10 +616,35 @@ func (uc *ScheduleUseCase) DeleteSlot(ctx context.Context, id uuid.UUID) error {
 //
 // The response is always a slice of ScheduleForDateResponse objects —
 // even if there are no active slots for that date.
+//
+// Busy blocks of the date are attached to every entry.
 func (uc *ScheduleUseCase) GetScheduleForDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]dto.ScheduleForDateResponse, error) {
 	// Normalize date to midnight UTC to ensure consistent lookups.
 	date = timeutil.NormalizeDate(date)
 
+	out, err := uc.scheduleForDate(ctx, masterID, date)
+	if err != nil || len(out) == 0 {
+		return out, err
+	}
+
+	blocks, err := uc.busyRepo.ListByMaster(ctx, masterID, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("list busy blocks: %w", err)
+	}
+	if len(blocks) > 0 {
+		busy := make([]dto.Period, 0, len(blocks))
+		for _, b := range blocks {
+			busy = append(busy, dto.Period{StartTime: b.StartTime, EndTime: b.EndTime})
+		}
+		for i := range out {
+			out[i].Busy = busy
+		}
+	}
+	return out, nil
+}
+
+func (uc *ScheduleUseCase) scheduleForDate(ctx context.Context, masterID uuid.UUID, date time.Time) ([]dto.ScheduleForDateResponse, error) {
+
 	// Check for override slots for the exact date.
 	overrideSlots, err := uc.repo.GetSlotsByDate(ctx, masterID, date)
 	if err != nil {
@@ -212,7 +676,8 @@ func (uc *ScheduleUseCase) GetScheduleForDate(ctx context.Context, masterID uuid
 		return out, nil
 	}
 
-	// Find the active schedule for the given date (may return multiple, pick the latest).
+	// Find the active schedule for the given date; of several, the first has
+	// the highest priority and only its days apply.
 	schedules, err := uc.repo.GetForDate(ctx, masterID, date)
 	if err != nil {
 		return nil, fmt.Errorf("get schedules: %w", err)
@@ -238,7 +703,7 @@ func (uc *ScheduleUseCase) GetScheduleForDate(ctx context.Context, masterID uuid
 		}
 		dayIndex := (daysSinceStart % cycleLength) + 1
 
-		days, err = uc.repo.GetDaysByDayIndex(ctx, masterID, dayIndex)
+		days, err = uc.repo.GetDaysByDayIndex(ctx, schedule.ID, dayIndex)
 		if err != nil {
 			return nil, fmt.Errorf("get schedule days by day index: %w", err)
 		}
@@ -248,11 +713,14 @@ func (uc *ScheduleUseCase) GetScheduleForDate(ctx context.Context, masterID uuid
 		if weekday == 0 {
 			weekday = 7 // make Sunday = 7 to match DB convention
 		}
-		days, err = uc.repo.GetDaysByWeekday(ctx, masterID, weekday)
+		days, err = uc.repo.GetDaysByWeekday(ctx, schedule.ID, weekday)
 		if err != nil {
 			return nil, fmt.Errorf("get schedule days by weekday: %w", err)
 		}
 
+	case entity.ScheduleTypeCustom:
+		// the hours of custom schedules are set by date overrides only
+
 	default:
 		return nil, fmt.Errorf("unsupported schedule type: %s", schedule.Type)
 	}
@@ -317,6 +785,489 @@ func (uc *ScheduleUseCase) GetScheduleForRange(ctx context.Context, masterID uui
 	return results, nil
 }
 
+func (uc *ScheduleUseCase) copySchedule(ctx context.Context, source *entity.ScheduleDetails, cp ScheduleCopy) (*entity.ScheduleDetails, error) {
+	if err := auth.AuthorizeMaster(ctx, cp.MasterID); err != nil {
+		return nil, err
+	}
+
+	schedule := &entity.Schedule{
+		MasterID:   cp.MasterID,
+		Name:       source.Name,
+		Type:       source.Type,
+		StartDate:  source.StartDate,
+		EndDate:    source.EndDate,
+		Priority:   source.Priority,
+		IsTemplate: cp.MasterID == uuid.Nil,
+	}
+	if cp.Name != nil {
+		schedule.Name = *cp.Name
+	}
+	if cp.Priority != nil {
+		schedule.Priority = *cp.Priority
+	}
+	var shift int
+	if cp.StartDate != nil {
+		shift = daysBetween(source.StartDate, *cp.StartDate)
+		schedule.StartDate = *cp.StartDate
+		if source.EndDate != nil {
+			end := source.EndDate.AddDate(0, 0, shift)
+			schedule.EndDate = &end
+		}
+	}
+	if cp.EndDate != nil {
+		schedule.EndDate = cp.EndDate
+	}
+
+	days := make([]*entity.ScheduleDay, 0, len(source.Days))
+	for _, d := range source.Days {
+		days = append(days, &entity.ScheduleDay{
+			Weekday:   d.Weekday,
+			DayIndex:  d.DayIndex,
+			StartTime: d.StartTime,
+			EndTime:   d.EndTime,
+			IsDayOff:  d.IsDayOff,
+		})
+	}
+	slots := make([]*entity.ScheduleSlot, 0)
+	if cp.IncludeSlots {
+		for _, sl := range source.Slots {
+			slots = append(slots, &entity.ScheduleSlot{
+				Date:      sl.Date.AddDate(0, 0, shift),
+				StartTime: sl.StartTime,
+				EndTime:   sl.EndTime,
+				IsDayOff:  sl.IsDayOff,
+			})
+		}
+	}
+	if err := validateSchedule(schedule, days); err != nil {
+		return nil, err
+	}
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.checkOverlap(ctx, schedule); err != nil {
+			return err
+		}
+		if err := uc.repo.Create(ctx, schedule); err != nil {
+			return fmt.Errorf("create schedule: %w", err)
+		}
+		for _, d := range days {
+			d.ScheduleID = schedule.ID
+			if err := uc.repo.AddDay(ctx, d); err != nil {
+				return fmt.Errorf("add schedule day: %w", err)
+			}
+		}
+		for _, sl := range slots {
+			sl.ScheduleID = schedule.ID
+			if err := uc.repo.AddSlot(ctx, sl); err != nil {
+				return fmt.Errorf("add schedule slot: %w", err)
+			}
+		}
+		return publish(ctx, uc.outbox, entity.EventScheduleCreated, schedule.ID, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ScheduleDetails{Schedule: schedule, Days: days, Slots: slots}, nil
+}
+
+// bookingConflicts returns the active bookings of the master between from and
+// to, inclusive, that are not within its working hours. Run in the
+// transaction of a change, it sees the hours as changed.
+func (uc *ScheduleUseCase) bookingConflicts(ctx context.Context, masterID uuid.UUID, from, to time.Time) ([]*entity.Booking, error) {
+	conflicts := make([]*entity.Booking, 0)
+	if masterID == uuid.Nil {
+		// templates have no bookings
+		return conflicts, nil
+	}
+
+	bookings, err := uc.bookings.GetByMasterID(ctx, masterID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("list bookings: %w", err)
+	}
+
+	hours := make(map[time.Time][]dto.ScheduleForDateResponse)
+	for _, b := range bookings {
+		if b.Status == entity.BookingStatusCancelled || b.Status == entity.BookingStatusCompleted {
+			continue
+		}
+		date := timeutil.NormalizeDate(b.StartTime.UTC())
+		if _, ok := hours[date]; !ok {
+			if hours[date], err = uc.scheduleForDate(ctx, masterID, date); err != nil {
+				return nil, err
+			}
+		}
+		if !withinHours(b, date, hours[date]) {
+			conflicts = append(conflicts, b)
+		}
+	}
+	return conflicts, nil
+}
+
+// changeSchedule runs a change of the schedule's days or slots and announces
+// it as schedule.updated in the same transaction.
+func (uc *ScheduleUseCase) changeSchedule(ctx context.Context, schedule *entity.Schedule, opts ChangeOptions, scope *changeScope, change func(ctx context.Context) error) ([]*entity.Booking, error) {
+	return uc.applyChange(ctx, opts, scope, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		return publish(ctx, uc.outbox, entity.EventScheduleUpdated, schedule.ID, schedule)
+	})
+}
+
+// changeScope is the part of the calendars of masters a change may leave
+// bookings outside of.
+type changeScope struct {
+	masterIDs []uuid.UUID
+	from, to  time.Time
+}
+
+// newChangeScope returns the scope of the dates from from to to, inclusive,
+// limited to the coming impactHorizonDays. Zero dates leave the range open.
+func newChangeScope(from, to time.Time, masterIDs ...uuid.UUID) *changeScope {
+	today := timeutil.NormalizeDate(time.Now().UTC())
+	horizon := today.AddDate(0, 0, impactHorizonDays-1)
+	if from.Before(today) {
+		from = today
+	}
+	if to.IsZero() || to.After(horizon) {
+		to = horizon
+	}
+	return &changeScope{masterIDs: masterIDs, from: from, to: to}
+}
+
+// scope is newChangeScope if the options ask for the affected bookings, and
+// nil otherwise.
+func (o ChangeOptions) scope(from, to time.Time, masterIDs ...uuid.UUID) *changeScope {
+	if !o.DryRun && (o.OnConflict == "" || o.OnConflict == ConflictKeep) {
+		return nil
+	}
+	return newChangeScope(from, to, masterIDs...)
+}
+
+// applyChange runs the change in a transaction. Given a scope, it returns the
+// bookings within the scope that fit the working hours before the change but
+// not after it, and notifies or cancels them as opts say; a dry run rolls the
+// change back and only returns them.
+func (uc *ScheduleUseCase) applyChange(ctx context.Context, opts ChangeOptions, scope *changeScope, change func(ctx context.Context) error) ([]*entity.Booking, error) {
+	var conflicts []*entity.Booking
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if scope == nil {
+			return change(ctx)
+		}
+
+		before, err := uc.scopeConflicts(ctx, scope)
+		if err != nil {
+			return err
+		}
+		if err := change(ctx); err != nil {
+			return err
+		}
+		after, err := uc.scopeConflicts(ctx, scope)
+		if err != nil {
+			return err
+		}
+
+		conflicts = make([]*entity.Booking, 0, len(after))
+		for _, b := range after {
+			if !containsBooking(before, b.ID) {
+				conflicts = append(conflicts, b)
+			}
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return uc.resolveConflicts(ctx, conflicts, opts.OnConflict)
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+func (uc *ScheduleUseCase) scopeConflicts(ctx context.Context, scope *changeScope) ([]*entity.Booking, error) {
+	var conflicts []*entity.Booking
+	if scope.to.Before(scope.from) {
+		return conflicts, nil
+	}
+	for i, masterID := range scope.masterIDs {
+		if slices.Contains(scope.masterIDs[:i], masterID) {
+			continue
+		}
+		found, err := uc.bookingConflicts(ctx, masterID, scope.from, scope.to)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, found...)
+	}
+	return conflicts, nil
+}
+
+// resolveConflicts notifies the clients of the bookings or cancels them.
+func (uc *ScheduleUseCase) resolveConflicts(ctx context.Context, conflicts []*entity.Booking, action ConflictAction) error {
+	for _, b := range conflicts {
+		switch action {
+		case ConflictNotify:
+			if err := publish(ctx, uc.outbox, entity.EventBookingOutsideHours, b.ID, b); err != nil {
+				return err
+			}
+		case ConflictCancel:
+			if err := uc.bookings.UpdateStatus(ctx, b.ID, entity.BookingStatusCancelled); err != nil {
+				return fmt.Errorf("cancel booking: %w", err)
+			}
+			b.Status = entity.BookingStatusCancelled
+			if err := publish(ctx, uc.outbox, entity.EventBookingCancelled, b.ID, b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func containsBooking(bookings []*entity.Booking, id uuid.UUID) bool {
+	return slices.ContainsFunc(bookings, func(b *entity.Booking) bool { return b.ID == id })
+}
+
+// authorize loads the schedule and checks that the caller may manage it.
+func (uc *ScheduleUseCase) authorize(ctx context.Context, scheduleID uuid.UUID) (*entity.Schedule, error) {
+	schedule, err := uc.repo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeMaster(ctx, schedule.MasterID); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (uc *ScheduleUseCase) authorizeDay(ctx context.Context, dayID uuid.UUID) (*entity.Schedule, error) {
+	day, err := uc.repo.GetDayByID(ctx, dayID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.authorize(ctx, day.ScheduleID)
+}
+
+func (uc *ScheduleUseCase) authorizeSlot(ctx context.Context, slotID uuid.UUID) (*entity.ScheduleSlot, *entity.Schedule, error) {
+	slot, err := uc.repo.GetSlotByID(ctx, slotID)
+	if err != nil {
+		return nil, nil, err
+	}
+	schedule, err := uc.authorize(ctx, slot.ScheduleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slot, schedule, nil
+}
+
+// validateDays checks the stored days of the schedule; run in the
+// transaction of a change of a day, it sees the days as changed.
+func (uc *ScheduleUseCase) validateDays(ctx context.Context, schedule *entity.Schedule) error {
+	days, err := uc.repo.GetDaysByScheduleID(ctx, schedule.ID)
+	if err != nil {
+		return fmt.Errorf("get schedule days: %w", err)
+	}
+	return validateSchedule(schedule, days)
+}
+
+// checkOverlap rejects a schedule sharing dates with another one of the
+// master with the same priority, as neither would take precedence.
+func (uc *ScheduleUseCase) checkOverlap(ctx context.Context, schedule *entity.Schedule) error {
+	if schedule.IsTemplate {
+		return nil
+	}
+	others, err := uc.repo.GetOverlapping(ctx, schedule)
+	if err != nil {
+		return fmt.Errorf("get overlapping schedules: %w", err)
+	}
+	if len(others) > 0 {
+		return apiErrors.ErrScheduleOverlap
+	}
+	return nil
+}
+
+// expandSchedule resolves the hours of the schedule for every date of the
+// window: the overrides of a date, else the days matching it. Dates outside
+// the schedule's period without overrides are left out.
+func expandSchedule(d *entity.ScheduleDetails, from, to time.Time) []dto.ScheduleForDateResponse {
+	overrides := make(map[string][]*entity.ScheduleSlot)
+	for _, s := range d.Slots {
+		key := s.Date.Format(time.DateOnly)
+		overrides[key] = append(overrides[key], s)
+	}
+	start := timeutil.NormalizeDate(d.StartDate)
+
+	var out []dto.ScheduleForDateResponse
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format(time.DateOnly)
+		entry := func(startTime, endTime *time.Time, isDayOff bool, source string) {
+			res := dto.ScheduleForDateResponse{MasterID: d.MasterID, Date: key, IsDayOff: isDayOff, Source: source}
+			if !isDayOff && startTime != nil && endTime != nil {
+				st, et := formatTimeOfDay(*startTime), formatTimeOfDay(*endTime)
+				res.StartTime, res.EndTime = &st, &et
+			}
+			out = append(out, res)
+		}
+
+		if slots := overrides[key]; len(slots) > 0 {
+			for _, s := range slots {
+				entry(s.StartTime, s.EndTime, s.IsDayOff, "override")
+			}
+			continue
+		}
+		if date.Before(start) || (d.EndDate != nil && date.After(timeutil.NormalizeDate(*d.EndDate))) {
+			continue
+		}
+
+		var matched bool
+		for _, day := range d.Days {
+			if scheduleDayMatches(d.Schedule, len(d.Days), day, start, date) {
+				entry(day.StartTime, day.EndTime, day.IsDayOff, d.Name)
+				matched = true
+			}
+		}
+		if !matched {
+			entry(nil, nil, true, d.Name)
+		}
+	}
+	return out
+}
+
+// scheduleDayMatches reports whether the day of the schedule applies to the
+// date: by weekday for weekly schedules, by position in the cycle of
+// cycleLength days for cyclic ones.
+func scheduleDayMatches(s *entity.Schedule, cycleLength int, day *entity.ScheduleDay, start, date time.Time) bool {
+	switch s.Type {
+	case entity.ScheduleTypeWeekly:
+		weekday := int(date.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return day.Weekday != nil && *day.Weekday == weekday
+	case entity.ScheduleTypeCyclic:
+		daysSinceStart := int(date.Sub(start).Hours() / 24)
+		return day.DayIndex != nil && *day.DayIndex == daysSinceStart%cycleLength+1
+	default:
+		return false
+	}
+}
+
+// validateSchedule checks the dates of the schedule and its days against the
+// rules of its type: weekly schedules have at most one day per weekday,
+// cyclic ones a day for every index from 1 to the length of the cycle, and
+// custom ones no days at all.
+func validateSchedule(s *entity.Schedule, days []*entity.ScheduleDay) error {
+	if s.EndDate != nil && s.EndDate.Before(s.StartDate) {
+		return &apiErrors.ValidationError{Field: "end_date", Err: apiErrors.ErrEndDateBeforeStartDate}
+	}
+	switch s.Type {
+	case entity.ScheduleTypeWeekly, entity.ScheduleTypeCyclic:
+	case entity.ScheduleTypeCustom:
+		if len(days) > 0 {
+			return &apiErrors.ValidationError{Field: "days", Err: apiErrors.ErrCustomScheduleDays}
+		}
+	default:
+		return apiErrors.ErrScheduleTypeInvalid
+	}
+
+	seen := make(map[int]bool, len(days))
+	for i, d := range days {
+		field := fmt.Sprintf("days[%d]", i)
+		if err := validateDay(s.Type, d); err != nil {
+			return &apiErrors.ValidationError{Field: field, Err: err}
+		}
+		key := d.Weekday
+		if s.Type == entity.ScheduleTypeCyclic {
+			key = d.DayIndex
+		}
+		if seen[*key] {
+			return &apiErrors.ValidationError{Field: field, Err: apiErrors.ErrScheduleDayDuplicate}
+		}
+		seen[*key] = true
+	}
+	if s.Type == entity.ScheduleTypeCyclic {
+		// the indexes are distinct, so all of 1..n present means no gaps
+		for i := 1; i <= len(days); i++ {
+			if !seen[i] {
+				return &apiErrors.ValidationError{Field: "days", Err: apiErrors.ErrScheduleCycleInvalid}
+			}
+		}
+	}
+	return nil
+}
+
+// validateDay checks a single day of a schedule of the type: its weekday or
+// day index, and its hours.
+func validateDay(scheduleType entity.ScheduleType, d *entity.ScheduleDay) error {
+	switch scheduleType {
+	case entity.ScheduleTypeWeekly:
+		if d.Weekday == nil || *d.Weekday < 1 || *d.Weekday > 7 || d.DayIndex != nil {
+			return apiErrors.ErrWeeklyDayInvalid
+		}
+	case entity.ScheduleTypeCyclic:
+		if d.DayIndex == nil || *d.DayIndex < 1 || d.Weekday != nil {
+			return apiErrors.ErrCyclicDayInvalid
+		}
+	case entity.ScheduleTypeCustom:
+		return apiErrors.ErrCustomScheduleDays
+	default:
+		return apiErrors.ErrScheduleTypeInvalid
+	}
+	return validateHours(d.StartTime, d.EndTime, d.IsDayOff)
+}
+
+// validateHours checks the hours of a day or date override: none on days
+// off, a start before the end otherwise.
+func validateHours(start, end *time.Time, isDayOff bool) error {
+	switch {
+	case isDayOff:
+		if start != nil || end != nil {
+			return apiErrors.ErrDayOffHours
+		}
+	case start == nil || end == nil:
+		return apiErrors.ErrWorkingHoursRequired
+	case !start.Before(*end):
+		return apiErrors.ErrEndTimeBeforeStartTime
+	}
+	return nil
+}
+
+// daysBetween returns the number of calendar days from one date to another.
+func daysBetween(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+	return int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// withinHours reports whether the booking lies within one of the working
+// periods of its date.
+func withinHours(b *entity.Booking, date time.Time, hours []dto.ScheduleForDateResponse) bool {
+	for _, h := range hours {
+		if h.IsDayOff || h.StartTime == nil || h.EndTime == nil {
+			continue
+		}
+		start, err1 := time.Parse("15:04", *h.StartTime)
+		end, err2 := time.Parse("15:04", *h.EndTime)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		periodStart := date.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+		periodEnd := date.Add(time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute)
+		if !b.StartTime.Before(periodStart) && !b.EndTime.After(periodEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDateRange checks that the inclusive range spans 1 to
+// maxSlotRangeDays dates.
+func validateDateRange(from, to time.Time) error {
+	if to.Before(from) || daysBetween(from, to) >= maxSlotRangeDays {
+		return apiErrors.ErrDateRangeInvalid
+	}
+	return nil
+}
+
 // formatTimeOfDay returns "HH:mm" representation of a time-of-day field.
 func formatTimeOfDay(t time.Time) string {
 	return t.Format("15:04")
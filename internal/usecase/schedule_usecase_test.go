@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/timeutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduleUseCase_UpdateSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockScheduleRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewScheduleUseCase(repo, nil, nil, tx, outbox)
+	ctx := context.Background()
+
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	current := func() *entity.Schedule {
+		return &entity.Schedule{ID: uuid.New(), MasterID: uuid.New(), Name: "Main", Type: entity.ScheduleTypeWeekly, StartDate: start}
+	}
+	nine, six := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	t.Run("replaces the days and keeps omitted fields", func(t *testing.T) {
+		s := current()
+		name := "Summer"
+		days := []*entity.ScheduleDay{{Weekday: ptr(1), StartTime: &nine, EndTime: &six}, {Weekday: ptr(7), IsDayOff: true}}
+
+		repo.EXPECT().GetByID(ctx, s.ID).Return(s, nil)
+		repo.EXPECT().DeleteDaysByScheduleID(ctx, s.ID).Return(nil)
+		repo.EXPECT().AddDay(ctx, gomock.Any()).Return(nil).Times(2)
+		repo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.Schedule) error {
+			assert.Equal(t, "Summer", u.Name)
+			assert.Equal(t, start, u.StartDate)
+			assert.Equal(t, entity.ScheduleTypeWeekly, u.Type)
+			return nil
+		})
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		updated, gotDays, _, err := useCase.UpdateSchedule(ctx, s.ID, ScheduleUpdate{Name: &name, Days: days}, ChangeOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Summer", updated.Name)
+		assert.Len(t, gotDays, 2)
+		assert.Equal(t, s.ID, gotDays[0].ScheduleID)
+	})
+
+	t.Run("clearing the end date makes the schedule open-ended", func(t *testing.T) {
+		s := current()
+		end := start.AddDate(0, 3, 0)
+		s.EndDate = &end
+
+		repo.EXPECT().GetByID(ctx, s.ID).Return(s, nil)
+		repo.EXPECT().GetDaysByScheduleID(ctx, s.ID).Return([]*entity.ScheduleDay{{Weekday: ptr(1), StartTime: &nine, EndTime: &six}}, nil)
+		repo.EXPECT().GetOverlapping(ctx, s).Return(nil, nil)
+		repo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *entity.Schedule) error {
+			assert.Nil(t, u.EndDate)
+			return nil
+		})
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		_, _, _, err := useCase.UpdateSchedule(ctx, s.ID, ScheduleUpdate{ClearEndDate: true}, ChangeOptions{})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("the stored days must fit a new type", func(t *testing.T) {
+		s := current()
+		cyclic := entity.ScheduleTypeCyclic
+
+		repo.EXPECT().GetByID(ctx, s.ID).Return(s, nil)
+		repo.EXPECT().GetDaysByScheduleID(ctx, s.ID).
+			Return([]*entity.ScheduleDay{{ScheduleID: s.ID, Weekday: ptr(1), IsDayOff: true}}, nil)
+
+		_, _, _, err := useCase.UpdateSchedule(ctx, s.ID, ScheduleUpdate{Type: &cyclic}, ChangeOptions{})
+
+		assert.ErrorIs(t, err, errors.ErrCyclicDayInvalid)
+	})
+
+	t.Run("end date before start date", func(t *testing.T) {
+		s := current()
+		end := start.AddDate(0, 0, -1)
+
+		repo.EXPECT().GetByID(ctx, s.ID).Return(s, nil)
+		repo.EXPECT().GetDaysByScheduleID(ctx, s.ID).Return(nil, nil)
+
+		_, _, _, err := useCase.UpdateSchedule(ctx, s.ID, ScheduleUpdate{EndDate: &end}, ChangeOptions{})
+
+		assert.ErrorIs(t, err, errors.ErrEndDateBeforeStartDate)
+	})
+
+	t.Run("a new period may not overlap a schedule of the same priority", func(t *testing.T) {
+		s := current()
+		end := start.AddDate(0, 3, 0)
+
+		repo.EXPECT().GetByID(ctx, s.ID).Return(s, nil)
+		repo.EXPECT().GetDaysByScheduleID(ctx, s.ID).Return(nil, nil)
+		repo.EXPECT().GetOverlapping(ctx, s).Return([]*entity.Schedule{{ID: uuid.New(), MasterID: s.MasterID}}, nil)
+
+		_, _, _, err := useCase.UpdateSchedule(ctx, s.ID, ScheduleUpdate{EndDate: &end}, ChangeOptions{})
+
+		assert.ErrorIs(t, err, errors.ErrScheduleOverlap)
+	})
+}
+
+func TestScheduleUseCase_Ownership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockScheduleRepository(ctrl)
+
+	useCase := NewScheduleUseCase(repo, nil, nil, nil, nil)
+	schedule := &entity.Schedule{ID: uuid.New(), MasterID: uuid.New(), Type: entity.ScheduleTypeWeekly}
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{SubjectID: uuid.New(), Role: entity.RoleMaster})
+
+	repo.EXPECT().GetByID(ctx, schedule.ID).Return(schedule, nil).Times(2)
+
+	_, err := useCase.DeleteSchedule(ctx, schedule.ID, ChangeOptions{})
+	assert.ErrorIs(t, err, errors.ErrForbidden, "masters do not delete the schedules of other masters")
+
+	name := "Mine"
+	_, _, _, err = useCase.UpdateSchedule(ctx, schedule.ID, ScheduleUpdate{Name: &name}, ChangeOptions{})
+	assert.ErrorIs(t, err, errors.ErrForbidden, "masters do not update the schedules of other masters")
+}
+
+func TestScheduleUseCase_ScheduleForDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockScheduleRepository(ctrl)
+
+	useCase := NewScheduleUseCase(repo, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	masterID := uuid.New()
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	base := &entity.Schedule{ID: uuid.New(), MasterID: masterID, Name: "Main", Type: entity.ScheduleTypeWeekly, StartDate: monday.AddDate(-1, 0, 0)}
+	summer := &entity.Schedule{ID: uuid.New(), MasterID: masterID, Name: "Summer", Type: entity.ScheduleTypeWeekly, StartDate: monday, Priority: 1}
+	ten, four := time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 16, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().GetSlotsByDate(ctx, masterID, monday).Return(nil, nil)
+	repo.EXPECT().GetForDate(ctx, masterID, monday).Return([]*entity.Schedule{summer, base}, nil)
+	// the days of the base schedule are not loaded
+	repo.EXPECT().GetDaysByWeekday(ctx, summer.ID, 1).Return([]*entity.ScheduleDay{{ScheduleID: summer.ID, Weekday: ptr(1), StartTime: &ten, EndTime: &four}}, nil)
+
+	hours, err := useCase.scheduleForDate(ctx, masterID, monday)
+
+	assert.NoError(t, err)
+	if assert.Len(t, hours, 1, "only the schedule of the highest priority applies") {
+		assert.Equal(t, "Summer", hours[0].Source)
+		assert.Equal(t, "10:00", *hours[0].StartTime)
+		assert.Equal(t, "16:00", *hours[0].EndTime)
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	nine, six := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	schedule := func(t entity.ScheduleType) *entity.Schedule {
+		return &entity.Schedule{Type: t, StartDate: start}
+	}
+
+	tests := []struct {
+		name     string
+		schedule *entity.Schedule
+		days     []*entity.ScheduleDay
+		field    string
+		err      error
+	}{
+		{"weekly", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{Weekday: ptr(1), StartTime: &nine, EndTime: &six}, {Weekday: ptr(7), IsDayOff: true}}, "", nil},
+		{"weekly day without weekday", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{DayIndex: ptr(1), IsDayOff: true}}, "days[0]", errors.ErrWeeklyDayInvalid},
+		{"weekday twice", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{Weekday: ptr(1), IsDayOff: true}, {Weekday: ptr(1), IsDayOff: true}}, "days[1]", errors.ErrScheduleDayDuplicate},
+		{"cyclic", schedule(entity.ScheduleTypeCyclic), []*entity.ScheduleDay{{DayIndex: ptr(2), IsDayOff: true}, {DayIndex: ptr(1), StartTime: &nine, EndTime: &six}}, "", nil},
+		{"cycle with a gap", schedule(entity.ScheduleTypeCyclic), []*entity.ScheduleDay{{DayIndex: ptr(1), IsDayOff: true}, {DayIndex: ptr(3), IsDayOff: true}}, "days", errors.ErrScheduleCycleInvalid},
+		{"custom with days", schedule(entity.ScheduleTypeCustom), []*entity.ScheduleDay{{Weekday: ptr(1), IsDayOff: true}}, "days", errors.ErrCustomScheduleDays},
+		{"working day without hours", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{Weekday: ptr(1)}}, "days[0]", errors.ErrWorkingHoursRequired},
+		{"day off with hours", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{Weekday: ptr(1), StartTime: &nine, EndTime: &six, IsDayOff: true}}, "days[0]", errors.ErrDayOffHours},
+		{"hours reversed", schedule(entity.ScheduleTypeWeekly), []*entity.ScheduleDay{{Weekday: ptr(1), StartTime: &six, EndTime: &nine}}, "days[0]", errors.ErrEndTimeBeforeStartTime},
+		{"unknown type", schedule("monthly"), nil, "", errors.ErrScheduleTypeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSchedule(tt.schedule, tt.days)
+
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+			var validationErr *errors.ValidationError
+			if tt.field != "" && assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, tt.field, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestExpandSchedule(t *testing.T) {
+	nine, six := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)
+	date := func(day int) time.Time { return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC) }
+	end := date(9)
+
+	t.Run("weekly", func(t *testing.T) {
+		// March 3rd 2025 is a Monday
+		details := &entity.ScheduleDetails{
+			Schedule: &entity.Schedule{Name: "Main", Type: entity.ScheduleTypeWeekly, StartDate: date(3), EndDate: &end},
+			Days: []*entity.ScheduleDay{
+				{Weekday: ptr(1), StartTime: &nine, EndTime: &six},
+				{Weekday: ptr(2), StartTime: &nine, EndTime: &six},
+			},
+			Slots: []*entity.ScheduleSlot{{Date: date(4), IsDayOff: true}},
+		}
+
+		out := expandSchedule(details, date(2), date(10))
+
+		assert.Len(t, out, 7, "the dates outside the period are left out")
+		assert.Equal(t, "2025-03-03", out[0].Date)
+		assert.Equal(t, "09:00", *out[0].StartTime)
+		assert.Equal(t, "override", out[1].Source)
+		assert.True(t, out[1].IsDayOff)
+		assert.True(t, out[2].IsDayOff, "no day for Wednesday")
+		assert.Equal(t, "Main", out[2].Source)
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+		details := &entity.ScheduleDetails{
+			Schedule: &entity.Schedule{Name: "2/2", Type: entity.ScheduleTypeCyclic, StartDate: date(1)},
+			Days: []*entity.ScheduleDay{
+				{DayIndex: ptr(1), StartTime: &nine, EndTime: &six},
+				{DayIndex: ptr(2), StartTime: &nine, EndTime: &six},
+				{DayIndex: ptr(3), IsDayOff: true},
+				{DayIndex: ptr(4), IsDayOff: true},
+			},
+		}
+
+		out := expandSchedule(details, date(4), date(6))
+
+		assert.Len(t, out, 3)
+		assert.True(t, out[0].IsDayOff)
+		assert.False(t, out[1].IsDayOff)
+		assert.False(t, out[2].IsDayOff)
+	})
+}
+
+func TestScheduleUseCase_CloneSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockScheduleRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewScheduleUseCase(repo, nil, nil, tx, outbox)
+	ctx := context.Background()
+
+	start, end := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	source := &entity.ScheduleDetails{
+		Schedule: &entity.Schedule{ID: uuid.New(), MasterID: uuid.New(), Name: "Spring", Type: entity.ScheduleTypeWeekly, StartDate: start, EndDate: &end},
+		Days:     []*entity.ScheduleDay{{ID: uuid.New(), Weekday: ptr(1), IsDayOff: true}},
+		Slots:    []*entity.ScheduleSlot{{ID: uuid.New(), Date: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), IsDayOff: true}},
+	}
+
+	t.Run("moves the period and the overrides to another master", func(t *testing.T) {
+		masterID, newStart := uuid.New(), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+		repo.EXPECT().GetDetails(ctx, source.ID, nil, nil).Return(source, nil)
+		repo.EXPECT().GetOverlapping(ctx, gomock.Any()).Return(nil, nil)
+		repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.Schedule) error {
+			s.ID = uuid.New()
+			return nil
+		})
+		repo.EXPECT().AddDay(ctx, gomock.Any()).Return(nil)
+		repo.EXPECT().AddSlot(ctx, gomock.Any()).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		clone, err := useCase.CloneSchedule(ctx, source.ID, ScheduleCopy{MasterID: masterID, StartDate: &newStart, IncludeSlots: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, masterID, clone.MasterID)
+		assert.Equal(t, "Spring", clone.Name)
+		assert.Equal(t, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), *clone.EndDate)
+		assert.Equal(t, clone.ID, clone.Days[0].ScheduleID)
+		assert.NotEqual(t, source.Days[0].ID, clone.Days[0].ID)
+		assert.Equal(t, time.Date(2025, 4, 8, 0, 0, 0, 0, time.UTC), clone.Slots[0].Date)
+	})
+
+	t.Run("only templates are instantiated", func(t *testing.T) {
+		repo.EXPECT().GetDetails(ctx, source.ID, nil, nil).Return(source, nil)
+
+		_, err := useCase.InstantiateTemplate(ctx, source.ID, ScheduleCopy{MasterID: uuid.New(), StartDate: &start})
+
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("schedules can not become templates", func(t *testing.T) {
+		template := &entity.Schedule{ID: uuid.New(), Type: entity.ScheduleTypeWeekly, IsTemplate: true}
+		masterID := uuid.New()
+
+		repo.EXPECT().GetByID(ctx, template.ID).Return(template, nil)
+
+		_, _, _, err := useCase.UpdateSchedule(ctx, template.ID, ScheduleUpdate{MasterID: &masterID}, ChangeOptions{})
+
+		assert.ErrorIs(t, err, errors.ErrScheduleTemplateMaster)
+	})
+}
+
+func TestScheduleUseCase_SetSlotRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockScheduleRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewScheduleUseCase(repo, nil, bookings, tx, outbox)
+	ctx := context.Background()
+
+	schedule := &entity.Schedule{ID: uuid.New(), MasterID: uuid.New(), Type: entity.ScheduleTypeWeekly}
+	from := timeutil.NormalizeDate(time.Now().UTC()).AddDate(0, 0, 7)
+	to := from.AddDate(0, 0, 2)
+	nine, six := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)
+	working := []*entity.ScheduleSlot{{StartTime: &nine, EndTime: &six}}
+	dayOff := []*entity.ScheduleSlot{{IsDayOff: true}}
+
+	at := from.AddDate(0, 0, 1).Add(10 * time.Hour)
+	booked := &entity.Booking{ID: uuid.New(), StartTime: at, EndTime: at.Add(time.Hour), Status: entity.BookingStatusConfirmed}
+	cancelled := &entity.Booking{ID: uuid.New(), StartTime: at, EndTime: at.Add(time.Hour), Status: entity.BookingStatusCancelled}
+
+	// expectChange expects the range to be replaced while the hours of the
+	// booked date go from before to after.
+	expectChange := func(before, after []*entity.ScheduleSlot) {
+		repo.EXPECT().GetByID(ctx, schedule.ID).Return(schedule, nil)
+		bookings.EXPECT().GetByMasterID(ctx, schedule.MasterID, from, to.AddDate(0, 0, 1)).
+			Return([]*entity.Booking{booked, cancelled}, nil).Times(2)
+		repo.EXPECT().GetSlotsByDate(ctx, schedule.MasterID, from.AddDate(0, 0, 1)).Return(before, nil)
+		repo.EXPECT().DeleteSlotsInRange(ctx, schedule.ID, from, to).Return(nil)
+		repo.EXPECT().AddSlot(ctx, gomock.Any()).Return(nil).Times(3)
+		repo.EXPECT().GetSlotsByDate(ctx, schedule.MasterID, from.AddDate(0, 0, 1)).Return(after, nil)
+	}
+
+	t.Run("a vacation reports the bookings it overlaps", func(t *testing.T) {
+		expectChange(working, dayOff)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		slots, conflicts, err := useCase.SetSlotRange(ctx, schedule.ID, SlotRange{From: from, To: to, IsDayOff: true}, ChangeOptions{})
+
+		assert.NoError(t, err)
+		assert.Len(t, slots, 3)
+		assert.Equal(t, to, slots[2].Date)
+		assert.Equal(t, []*entity.Booking{booked}, conflicts)
+	})
+
+	t.Run("bookings outside the hours before are not reported again", func(t *testing.T) {
+		expectChange(dayOff, dayOff)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		_, conflicts, err := useCase.SetSlotRange(ctx, schedule.ID, SlotRange{From: from, To: to, IsDayOff: true}, ChangeOptions{})
+
+		assert.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("a dry run does not cancel", func(t *testing.T) {
+		expectChange(working, dayOff)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		_, conflicts, err := useCase.SetSlotRange(ctx, schedule.ID, SlotRange{From: from, To: to, IsDayOff: true}, ChangeOptions{DryRun: true, OnConflict: ConflictCancel})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.Booking{booked}, conflicts)
+	})
+
+	t.Run("conflicting bookings are cancelled on request", func(t *testing.T) {
+		expectChange(working, dayOff)
+		bookings.EXPECT().UpdateStatus(ctx, booked.ID, entity.BookingStatusCancelled).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.Event) error {
+			assert.Equal(t, entity.EventScheduleUpdated, e.Type)
+			return nil
+		})
+		outbox.EXPECT().Add(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.Event) error {
+			assert.Equal(t, entity.EventBookingCancelled, e.Type)
+			assert.Equal(t, booked.ID, e.AggregateID)
+			return nil
+		})
+
+		_, conflicts, err := useCase.SetSlotRange(ctx, schedule.ID, SlotRange{From: from, To: to, IsDayOff: true}, ChangeOptions{OnConflict: ConflictCancel})
+
+		assert.NoError(t, err)
+		assert.Len(t, conflicts, 1)
+		assert.Equal(t, entity.BookingStatusCancelled, conflicts[0].Status)
+	})
+
+	t.Run("ranges are limited", func(t *testing.T) {
+		repo.EXPECT().GetByID(ctx, schedule.ID).Return(schedule, nil)
+
+		_, _, err := useCase.SetSlotRange(ctx, schedule.ID, SlotRange{From: from, To: from.AddDate(2, 0, 0), IsDayOff: true}, ChangeOptions{})
+
+		assert.ErrorIs(t, err, errors.ErrDateRangeInvalid)
+	})
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 
+	"github.com/curserio/chrono-api/internal/auth"
 	"github.com/curserio/chrono-api/internal/domain/entity"
 	"github.com/curserio/chrono-api/internal/repository"
 	"github.com/google/uuid"
@@ -17,6 +18,9 @@ func NewServiceUseCase(repo repository.ServiceRepository) *ServiceUseCase {
 }
 
 func (uc *ServiceUseCase) CreateService(ctx context.Context, s *entity.Service) (*entity.Service, error) {
+	if err := auth.AuthorizeMaster(ctx, s.MasterID); err != nil {
+		return nil, err
+	}
 	if err := uc.serviceRepo.Create(ctx, s); err != nil {
 		return nil, err
 	}
@@ -32,9 +36,28 @@ func (uc *ServiceUseCase) ListServicesByMaster(ctx context.Context, masterID uui
 }
 
 func (uc *ServiceUseCase) UpdateService(ctx context.Context, s *entity.Service) error {
+	// both the current owner and the new one must be accessible
+	if err := uc.authorize(ctx, s.ID); err != nil {
+		return err
+	}
+	if err := auth.AuthorizeMaster(ctx, s.MasterID); err != nil {
+		return err
+	}
 	return uc.serviceRepo.Update(ctx, s)
 }
 
 func (uc *ServiceUseCase) DeleteService(ctx context.Context, id uuid.UUID) error {
+	if err := uc.authorize(ctx, id); err != nil {
+		return err
+	}
 	return uc.serviceRepo.Delete(ctx, id)
 }
+
+// authorize checks that the caller may manage the stored service.
+func (uc *ServiceUseCase) authorize(ctx context.Context, id uuid.UUID) error {
+	existing, err := uc.serviceRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return auth.AuthorizeMaster(ctx, existing.MasterID)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/timeutil"
+	"github.com/google/uuid"
+)
+
+// TimeOffUseCase handles the days off masters ask for. Admins review the
+// requests; approving one makes the days days off in the master's schedule
+// and notifies the clients of the bookings falling on them.
+type TimeOffUseCase struct {
+	repo      repository.TimeOffRepository
+	masters   repository.MasterRepository
+	schedules *ScheduleUseCase
+	tx        repository.TxManager
+	outbox    repository.OutboxRepository
+}
+
+func NewTimeOffUseCase(repo repository.TimeOffRepository, masters repository.MasterRepository, schedules *ScheduleUseCase, tx repository.TxManager, outbox repository.OutboxRepository) *TimeOffUseCase {
+	return &TimeOffUseCase{
+		repo:      repo,
+		masters:   masters,
+		schedules: schedules,
+		tx:        tx,
+		outbox:    outbox,
+	}
+}
+
+// RequestTimeOff creates a pending request of the master, who must belong to
+// the organization of the context.
+func (uc *TimeOffUseCase) RequestTimeOff(ctx context.Context, req *entity.TimeOffRequest) error {
+	if err := auth.AuthorizeMaster(ctx, req.MasterID); err != nil {
+		return err
+	}
+
+	req.StartDate, req.EndDate = timeutil.NormalizeDate(req.StartDate), timeutil.NormalizeDate(req.EndDate)
+	if req.EndDate.Before(req.StartDate) {
+		return &apiErrors.ValidationError{Field: "end_date", Err: apiErrors.ErrEndDateBeforeStartDate}
+	}
+	if err := validateDateRange(req.StartDate, req.EndDate); err != nil {
+		return err
+	}
+	if _, err := uc.masters.GetByID(ctx, req.MasterID); err != nil {
+		return err
+	}
+	req.Status = entity.TimeOffStatusPending
+
+	return uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Create(ctx, req); err != nil {
+			return fmt.Errorf("create time-off request: %w", err)
+		}
+		return publish(ctx, uc.outbox, entity.EventTimeOffRequested, req.ID, req)
+	})
+}
+
+func (uc *TimeOffUseCase) GetTimeOff(ctx context.Context, id uuid.UUID) (*entity.TimeOffRequest, error) {
+	req, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.AuthorizeMaster(ctx, req.MasterID); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListTimeOff returns the requests of the master, or of all masters for a
+// zero masterID, with the status, or any for an empty one. Masters listing
+// the requests of all masters get their own.
+func (uc *TimeOffUseCase) ListTimeOff(ctx context.Context, masterID uuid.UUID, status entity.TimeOffStatus, offset, limit int) ([]*entity.TimeOffRequest, error) {
+	if p, ok := auth.PrincipalFromContext(ctx); ok && p.Role == entity.RoleMaster && masterID == uuid.Nil {
+		masterID = p.SubjectID
+	}
+	if err := auth.AuthorizeMaster(ctx, masterID); err != nil {
+		return nil, err
+	}
+	return uc.repo.List(ctx, masterID, status, offset, limit)
+}
+
+// CancelTimeOff withdraws a request that has not been reviewed yet.
+func (uc *TimeOffUseCase) CancelTimeOff(ctx context.Context, id uuid.UUID) error {
+	if _, err := uc.GetTimeOff(ctx, id); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+// ApproveTimeOff approves a pending request and makes its days days off. The
+// clients of the bookings the days off leave outside the working hours are
+// notified; the bookings are returned.
+func (uc *TimeOffUseCase) ApproveTimeOff(ctx context.Context, id uuid.UUID, comment string) (*entity.TimeOffRequest, []*entity.Booking, error) {
+	var conflicts []*entity.Booking
+	req, err := uc.review(ctx, id, entity.TimeOffStatusApproved, comment, func(ctx context.Context, req *entity.TimeOffRequest) error {
+		var err error
+		conflicts, err = uc.schedules.SetDaysOff(ctx, req.MasterID, req.StartDate, req.EndDate, ChangeOptions{OnConflict: ConflictNotify})
+		if err != nil {
+			return fmt.Errorf("set days off: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return req, conflicts, nil
+}
+
+// RejectTimeOff rejects a pending request; the schedule is left unchanged.
+func (uc *TimeOffUseCase) RejectTimeOff(ctx context.Context, id uuid.UUID, comment string) (*entity.TimeOffRequest, error) {
+	return uc.review(ctx, id, entity.TimeOffStatusRejected, comment, nil)
+}
+
+// review stores the decision on a pending request along with the changes
+// apply makes, if any, in one transaction.
+func (uc *TimeOffUseCase) review(ctx context.Context, id uuid.UUID, status entity.TimeOffStatus, comment string, apply func(ctx context.Context, req *entity.TimeOffRequest) error) (*entity.TimeOffRequest, error) {
+	// only admins review requests, the master's own included
+	if err := auth.RequireRole(ctx, entity.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	req, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != entity.TimeOffStatusPending {
+		return nil, apiErrors.ErrTimeOffReviewed
+	}
+
+	now := time.Now()
+	req.Status = status
+	req.ReviewedAt = &now
+	req.ReviewComment = comment
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		req.ReviewedBy = &p.SubjectID
+	}
+
+	event := entity.EventTimeOffRejected
+	if status == entity.TimeOffStatusApproved {
+		event = entity.EventTimeOffApproved
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// reviewing first fails a concurrent review before any change
+		if err := uc.repo.Review(ctx, req); err != nil {
+			return err
+		}
+		if apply != nil {
+			if err := apply(ctx, req); err != nil {
+				return err
+			}
+		}
+		return publish(ctx, uc.outbox, event, req.ID, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/internal/auth"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/timeutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimeOffUseCase_RequestTimeOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockTimeOffRepository(ctrl)
+	masters := mock.NewMockMasterRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewTimeOffUseCase(repo, masters, nil, tx, outbox)
+	ctx := context.Background()
+	date := timeutil.NormalizeDate(time.Now().UTC()).AddDate(0, 0, 7)
+
+	t.Run("pending request", func(t *testing.T) {
+		req := &entity.TimeOffRequest{MasterID: uuid.New(), StartDate: date, EndDate: date.AddDate(0, 0, 2), Reason: "vacation"}
+		masters.EXPECT().GetByID(ctx, req.MasterID).Return(&entity.Master{ID: req.MasterID}, nil)
+		repo.EXPECT().Create(ctx, req).Return(nil)
+		outbox.EXPECT().Add(ctx, gomock.Any()).Return(nil)
+
+		err := useCase.RequestTimeOff(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TimeOffStatusPending, req.Status)
+	})
+
+	t.Run("master of another organization", func(t *testing.T) {
+		req := &entity.TimeOffRequest{MasterID: uuid.New(), StartDate: date, EndDate: date}
+		masters.EXPECT().GetByID(ctx, req.MasterID).Return(nil, errors.ErrNotFound)
+
+		err := useCase.RequestTimeOff(ctx, req)
+
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+}
+
+func TestTimeOffUseCase_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockTimeOffRepository(ctrl)
+	scheduleRepo := mock.NewMockScheduleRepository(ctrl)
+	bookings := mock.NewMockBookingRepository(ctrl)
+	outbox := mock.NewMockOutboxRepository(ctrl)
+	tx := mock.NewMockTxManager(ctrl)
+	tx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).AnyTimes()
+
+	useCase := NewTimeOffUseCase(repo, nil, NewScheduleUseCase(scheduleRepo, nil, bookings, tx, outbox), tx, outbox)
+	ctx := context.Background()
+
+	schedule := &entity.Schedule{ID: uuid.New(), MasterID: uuid.New(), Type: entity.ScheduleTypeWeekly}
+	date := timeutil.NormalizeDate(time.Now().UTC()).AddDate(0, 0, 7)
+	nine, six := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 18, 0, 0, 0, time.UTC)
+	at := date.Add(10 * time.Hour)
+	booked := &entity.Booking{ID: uuid.New(), MasterID: schedule.MasterID, StartTime: at, EndTime: at.Add(time.Hour), Status: entity.BookingStatusConfirmed}
+	pending := func() *entity.TimeOffRequest {
+		return &entity.TimeOffRequest{ID: uuid.New(), MasterID: schedule.MasterID, StartDate: date, EndDate: date, Status: entity.TimeOffStatusPending}
+	}
+
+	t.Run("approval makes the days days off and notifies the clients", func(t *testing.T) {
+		req := pending()
+		override := &entity.ScheduleSlot{ID: uuid.New(), ScheduleID: schedule.ID, Date: date, StartTime: &nine, EndTime: &six}
+
+		repo.EXPECT().GetByID(ctx, req.ID).Return(req, nil)
+		repo.EXPECT().Review(ctx, req).Return(nil)
+		bookings.EXPECT().GetByMasterID(ctx, schedule.MasterID, date, date.AddDate(0, 0, 1)).Return([]*entity.Booking{booked}, nil).Times(2)
+		// the hours before, the overrides replaced and the hours after
+		scheduleRepo.EXPECT().GetSlotsByDate(ctx, schedule.MasterID, date).Return([]*entity.ScheduleSlot{override}, nil)
+		scheduleRepo.EXPECT().GetSlotsByDate(ctx, schedule.MasterID, date).Return([]*entity.ScheduleSlot{override}, nil)
+		scheduleRepo.EXPECT().DeleteSlot(ctx, override.ID).Return(nil)
+		scheduleRepo.EXPECT().GetForDate(ctx, schedule.MasterID, date).Return([]*entity.Schedule{schedule}, nil)
+		scheduleRepo.EXPECT().AddSlot(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *entity.ScheduleSlot) error {
+			assert.Equal(t, schedule.ID, s.ScheduleID)
+			assert.True(t, s.IsDayOff)
+			return nil
+		})
+		scheduleRepo.EXPECT().GetByMasterID(ctx, schedule.MasterID).Return([]*entity.Schedule{schedule}, nil)
+		scheduleRepo.EXPECT().GetSlotsByDate(ctx, schedule.MasterID, date).Return([]*entity.ScheduleSlot{{IsDayOff: true}}, nil)
+		var events []entity.EventType
+		outbox.EXPECT().Add(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entity.Event) error {
+			events = append(events, e.Type)
+			return nil
+		}).Times(3)
+
+		approved, conflicts, err := useCase.ApproveTimeOff(ctx, req.ID, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TimeOffStatusApproved, approved.Status)
+		assert.NotNil(t, approved.ReviewedAt)
+		assert.Equal(t, []*entity.Booking{booked}, conflicts)
+		assert.Equal(t, []entity.EventType{entity.EventScheduleUpdated, entity.EventBookingOutsideHours, entity.EventTimeOffApproved}, events)
+	})
+
+	t.Run("requests are reviewed once", func(t *testing.T) {
+		req := pending()
+		req.Status = entity.TimeOffStatusRejected
+
+		repo.EXPECT().GetByID(ctx, req.ID).Return(req, nil)
+
+		_, _, err := useCase.ApproveTimeOff(ctx, req.ID, "")
+
+		assert.ErrorIs(t, err, errors.ErrTimeOffReviewed)
+	})
+
+	t.Run("masters do not review their own requests", func(t *testing.T) {
+		masterCtx := auth.WithPrincipal(ctx, &auth.Principal{SubjectID: schedule.MasterID, Role: entity.RoleMaster})
+
+		_, err := useCase.RejectTimeOff(masterCtx, uuid.New(), "")
+
+		assert.ErrorIs(t, err, errors.ErrForbidden)
+	})
+}
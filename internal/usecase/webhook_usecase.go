@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	apiErrors "github.com/curserio/chrono-api/internal/errors"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/internal/webhook"
+	"github.com/google/uuid"
+)
+
+type WebhookUseCase struct {
+	subs       repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+}
+
+func NewWebhookUseCase(subs repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository) *WebhookUseCase {
+	return &WebhookUseCase{
+		subs:       subs,
+		deliveries: deliveries,
+	}
+}
+
+// CreateSubscription creates the subscription with a new signing secret,
+// which is returned only here.
+func (uc *WebhookUseCase) CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) (*entity.WebhookSubscription, string, error) {
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return nil, "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+
+	sub.Secret = secret
+	sub.Active = true
+	if err := uc.subs.Create(ctx, sub); err != nil {
+		return nil, "", fmt.Errorf("create webhook subscription: %w", err)
+	}
+	return sub, secret, nil
+}
+
+func (uc *WebhookUseCase) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	return uc.subs.GetByID(ctx, id)
+}
+
+func (uc *WebhookUseCase) ListSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return uc.subs.List(ctx)
+}
+
+func (uc *WebhookUseCase) UpdateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	return uc.subs.Update(ctx, sub)
+}
+
+func (uc *WebhookUseCase) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return uc.subs.Delete(ctx, id)
+}
+
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, offset, limit int) ([]*entity.WebhookDelivery, error) {
+	return uc.deliveries.ListBySubscription(ctx, subscriptionID, offset, limit)
+}
+
+// GetDelivery returns the delivery with the log of its attempts.
+func (uc *WebhookUseCase) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	d, err := uc.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.AttemptLog, err = uc.deliveries.ListAttempts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook attempts: %w", err)
+	}
+	return d, nil
+}
+
+// RetryDelivery requeues a dead-lettered delivery.
+func (uc *WebhookUseCase) RetryDelivery(ctx context.Context, id uuid.UUID) error {
+	d, err := uc.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if d.Status != entity.WebhookDeliveryDead {
+		return apiErrors.ErrWebhookDeliveryNotDead
+	}
+	return uc.deliveries.Retry(ctx, id)
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/repository"
+)
+
+// Sink is the outbox sink of webhooks. It only queues a delivery for every
+// matching subscription; the Worker sends them, so a slow or failing
+// receiver does not hold back the outbox or other subscriptions.
+type Sink struct {
+	subs       repository.WebhookRepository
+	deliveries repository.WebhookDeliveryRepository
+}
+
+func NewSink(subs repository.WebhookRepository, deliveries repository.WebhookDeliveryRepository) *Sink {
+	return &Sink{
+		subs:       subs,
+		deliveries: deliveries,
+	}
+}
+
+func (s *Sink) Name() string {
+	return "webhook"
+}
+
+func (s *Sink) Handle(ctx context.Context, e *entity.Event) error {
+	subs, err := s.subs.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+
+	var body []byte
+	for _, sub := range subs {
+		if !sub.Accepts(e.Type) {
+			continue
+		}
+
+		if body == nil {
+			if body, err = json.Marshal(e); err != nil {
+				return fmt.Errorf("marshal event: %w", err)
+			}
+		}
+
+		if err := s.deliveries.Create(ctx, &entity.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Body:           body,
+		}); err != nil {
+			return fmt.Errorf("queue webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
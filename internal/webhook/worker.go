@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/netguard"
+	"github.com/curserio/chrono-api/internal/repository"
+	"github.com/curserio/chrono-api/pkg/logger"
+)
+
+const (
+	maxBackoff = 6 * time.Hour
+	// leaseDuration must exceed the time needed to send a batch
+	leaseDuration = 5 * time.Minute
+)
+
+// Worker sends queued webhook deliveries. A delivery succeeds on any 2xx
+// response; failures are retried with exponential backoff until MaxAttempts
+// is reached, after which the delivery is dead-lettered.
+type Worker struct {
+	repo          repository.WebhookDeliveryRepository
+	client        *http.Client
+	log           logger.Logger
+	interval      time.Duration
+	batchSize     int
+	maxAttempts   int
+	backoff       time.Duration
+	allowInsecure bool
+}
+
+func NewWorker(repo repository.WebhookDeliveryRepository, log logger.Logger, cfg config.WebhookConfig) *Worker {
+	return &Worker{
+		repo:          repo,
+		client:        netguard.NewClient(cfg.Timeout, cfg.AllowInsecureTargets),
+		log:           log,
+		interval:      cfg.PollInterval,
+		batchSize:     cfg.BatchSize,
+		maxAttempts:   cfg.MaxAttempts,
+		backoff:       cfg.RetryBackoff,
+		allowInsecure: cfg.AllowInsecureTargets,
+	}
+}
+
+// Run sends deliveries until the context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.DeliverBatch(ctx)
+			if err != nil {
+				w.log.Error("failed to deliver webhooks", "error", err)
+			}
+			if err != nil || n < w.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverBatch sends one batch of due deliveries and returns its size.
+func (w *Worker) DeliverBatch(ctx context.Context) (int, error) {
+	dispatches, err := w.repo.Claim(ctx, w.batchSize, leaseDuration)
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	for _, d := range dispatches {
+		if err := w.deliver(ctx, d); err != nil {
+			return len(dispatches), err
+		}
+	}
+	return len(dispatches), nil
+}
+
+func (w *Worker) deliver(ctx context.Context, d *entity.WebhookDispatch) error {
+	started := time.Now()
+	statusCode, sendErr := w.send(ctx, d)
+
+	attempt := &entity.WebhookAttempt{
+		DeliveryID:  d.Delivery.ID,
+		AttemptedAt: started,
+		DurationMs:  int(time.Since(started).Milliseconds()),
+	}
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		attempt.Error = &msg
+	}
+	if err := w.repo.RecordAttempt(ctx, attempt); err != nil {
+		return fmt.Errorf("record webhook attempt: %w", err)
+	}
+
+	if sendErr == nil {
+		if err := w.repo.MarkDelivered(ctx, d.Delivery.ID); err != nil {
+			return fmt.Errorf("mark webhook delivered: %w", err)
+		}
+		return nil
+	}
+
+	attempts := d.Delivery.Attempts + 1
+	dead := attempts >= w.maxAttempts
+	if dead {
+		w.log.Warn("webhook delivery dead-lettered",
+			"delivery_id", d.Delivery.ID, "subscription_id", d.Delivery.SubscriptionID, "attempts", attempts, "error", sendErr)
+	}
+
+	next := time.Now().Add(w.retryDelay(d.Delivery.Attempts))
+	if err := w.repo.MarkFailed(ctx, d.Delivery.ID, next, sendErr.Error(), dead); err != nil {
+		return fmt.Errorf("mark webhook failed: %w", err)
+	}
+	return nil
+}
+
+// send posts the signed body and returns the response status, if any.
+func (w *Worker) send(ctx context.Context, d *entity.WebhookDispatch) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Delivery.Body))
+	if err != nil {
+		return 0, err
+	}
+	// subscriptions created before https was required may still use http
+	if err := netguard.CheckURL(req.URL, w.allowInsecure); err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, Sign(d.Secret, time.Now(), d.Delivery.Body))
+	req.Header.Set(HeaderEvent, string(d.Delivery.EventType))
+	req.Header.Set(HeaderDelivery, d.Delivery.ID.String())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (w *Worker) retryDelay(attempts int) time.Duration {
+	delay := w.backoff
+	for i := 0; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/curserio/chrono-api/config"
+	"github.com/curserio/chrono-api/internal/domain/entity"
+	"github.com/curserio/chrono-api/internal/netguard"
+	"github.com/curserio/chrono-api/internal/repository/mock"
+	"github.com/curserio/chrono-api/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const testSecret = "whsec_test"
+
+// newTestWorker sends to the plain http loopback receivers of the tests.
+func newTestWorker(repo *mock.MockWebhookDeliveryRepository) *Worker {
+	return NewWorker(repo, logger.DefaultLogger, config.WebhookConfig{
+		Timeout:              time.Second,
+		BatchSize:            10,
+		MaxAttempts:          3,
+		RetryBackoff:         time.Second,
+		AllowInsecureTargets: true,
+	})
+}
+
+func newDispatch(url string, attempts int) *entity.WebhookDispatch {
+	return &entity.WebhookDispatch{
+		Delivery: &entity.WebhookDelivery{
+			ID:        uuid.New(),
+			EventType: entity.EventBookingCreated,
+			Body:      []byte(`{"type":"booking.created"}`),
+			Attempts:  attempts,
+		},
+		URL:    url,
+		Secret: testSecret,
+	}
+}
+
+func TestWorker_DeliverBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("signed delivery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock.NewMockWebhookDeliveryRepository(ctrl)
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, string(entity.EventBookingCreated), r.Header.Get(HeaderEvent))
+			assert.NoError(t, Verify(testSecret, r.Header.Get(HeaderSignature), body, time.Minute, time.Now()))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		d := newDispatch(receiver.URL, 0)
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.WebhookDispatch{d}, nil)
+		repo.EXPECT().RecordAttempt(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, a *entity.WebhookAttempt) error {
+			assert.Equal(t, http.StatusNoContent, *a.StatusCode)
+			assert.Nil(t, a.Error)
+			return nil
+		})
+		repo.EXPECT().MarkDelivered(ctx, d.Delivery.ID).Return(nil)
+
+		n, err := newTestWorker(repo).DeliverBatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("retry and dead letter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mock.NewMockWebhookDeliveryRepository(ctrl)
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer receiver.Close()
+
+		retried := newDispatch(receiver.URL, 0)
+		last := newDispatch(receiver.URL, 2)
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.WebhookDispatch{retried, last}, nil)
+		repo.EXPECT().RecordAttempt(ctx, gomock.Any()).Return(nil).Times(2)
+		repo.EXPECT().MarkFailed(ctx, retried.Delivery.ID, gomock.Any(), "unexpected status 503", false).Return(nil)
+		repo.EXPECT().MarkFailed(ctx, last.Delivery.ID, gomock.Any(), "unexpected status 503", true).Return(nil)
+
+		_, err := newTestWorker(repo).DeliverBatch(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestWorker_InternalTargets(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockWebhookDeliveryRepository(ctrl)
+	w := NewWorker(repo, logger.DefaultLogger, config.WebhookConfig{Timeout: time.Second, BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Second})
+
+	called := false
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	defer receiver.Close()
+
+	for _, url := range []string{"http://example.com/hook", receiver.URL, "https://169.254.169.254/latest/meta-data"} {
+		d := newDispatch(url, 0)
+		repo.EXPECT().Claim(ctx, 10, leaseDuration).Return([]*entity.WebhookDispatch{d}, nil)
+		repo.EXPECT().RecordAttempt(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, a *entity.WebhookAttempt) error {
+			assert.Nil(t, a.StatusCode)
+			assert.Contains(t, *a.Error, netguard.ErrForbidden.Error(), url)
+			return nil
+		})
+		repo.EXPECT().MarkFailed(ctx, d.Delivery.ID, gomock.Any(), gomock.Any(), false).Return(nil)
+
+		_, err := w.DeliverBatch(ctx)
+		assert.NoError(t, err)
+	}
+	assert.False(t, called, "the loopback receiver is not called")
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{}`)
+	now := time.Now()
+	header := Sign(testSecret, now, body)
+
+	assert.NoError(t, Verify(testSecret, header, body, time.Minute, now))
+	assert.ErrorIs(t, Verify("other", header, body, time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, header, []byte(`{"x":1}`), time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, header, body, time.Minute, now.Add(time.Hour)), ErrInvalidSignature)
+}
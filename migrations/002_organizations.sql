@@ -0,0 +1,84 @@
+-- Table of organizations (salons), the tenant boundary for all other data
+CREATE TABLE organizations
+(
+    id         UUID PRIMARY KEY      DEFAULT uuidv7(), -- unique identifier for the organization
+    name       VARCHAR(255) NOT NULL,                  -- display name of the salon
+    timezone   TEXT         NOT NULL DEFAULT 'UTC',    -- time zone of the organization
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),    -- record creation timestamp
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT now()     -- last update timestamp
+);
+
+COMMENT ON TABLE organizations IS 'Organizations (salons) owning masters, services, clients and bookings';
+COMMENT ON COLUMN organizations.id IS 'Unique identifier for the organization';
+COMMENT ON COLUMN organizations.name IS 'Display name of the organization';
+COMMENT ON COLUMN organizations.timezone IS 'Time zone of the organization, used for reports, e.g., Europe/Moscow';
+COMMENT ON COLUMN organizations.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN organizations.updated_at IS 'Last update timestamp';
+
+-- Existing rows are moved into a default organization before the columns become mandatory
+INSERT INTO organizations (name)
+SELECT 'Default'
+WHERE EXISTS (SELECT 1 FROM masters)
+   OR EXISTS (SELECT 1 FROM clients);
+
+ALTER TABLE masters ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE services ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE clients ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE bookings ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+ALTER TABLE schedules ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+
+UPDATE masters SET organization_id = (SELECT id FROM organizations ORDER BY created_at LIMIT 1);
+UPDATE services SET organization_id = (SELECT id FROM organizations ORDER BY created_at LIMIT 1);
+UPDATE clients SET organization_id = (SELECT id FROM organizations ORDER BY created_at LIMIT 1);
+UPDATE bookings SET organization_id = (SELECT id FROM organizations ORDER BY created_at LIMIT 1);
+UPDATE schedules SET organization_id = (SELECT id FROM organizations ORDER BY created_at LIMIT 1);
+
+ALTER TABLE masters ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE services ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE clients ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE bookings ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE schedules ALTER COLUMN organization_id SET NOT NULL;
+
+COMMENT ON COLUMN masters.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN services.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN clients.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN bookings.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN schedules.organization_id IS 'Reference to the owning organization';
+
+-- Contacts are unique per organization: the same person may visit several salons
+ALTER TABLE masters DROP CONSTRAINT masters_email_key;
+ALTER TABLE masters DROP CONSTRAINT masters_telegram_id_key;
+ALTER TABLE masters ADD CONSTRAINT masters_organization_email_key UNIQUE (organization_id, email);
+ALTER TABLE masters ADD CONSTRAINT masters_organization_telegram_id_key UNIQUE (organization_id, telegram_id);
+
+ALTER TABLE clients DROP CONSTRAINT clients_email_key;
+ALTER TABLE clients DROP CONSTRAINT clients_telegram_id_key;
+ALTER TABLE clients ADD CONSTRAINT clients_organization_email_key UNIQUE (organization_id, email);
+ALTER TABLE clients ADD CONSTRAINT clients_organization_telegram_id_key UNIQUE (organization_id, telegram_id);
+
+-- Composite keys make cross-tenant references impossible at the database level
+ALTER TABLE masters ADD CONSTRAINT masters_id_organization_key UNIQUE (id, organization_id);
+ALTER TABLE services ADD CONSTRAINT services_id_organization_key UNIQUE (id, organization_id);
+ALTER TABLE clients ADD CONSTRAINT clients_id_organization_key UNIQUE (id, organization_id);
+
+ALTER TABLE services
+    ADD CONSTRAINT services_master_organization_fkey
+        FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id);
+ALTER TABLE schedules
+    ADD CONSTRAINT schedules_master_organization_fkey
+        FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id) ON DELETE CASCADE;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_master_organization_fkey
+        FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id);
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_client_organization_fkey
+        FOREIGN KEY (client_id, organization_id) REFERENCES clients (id, organization_id);
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_service_organization_fkey
+        FOREIGN KEY (service_id, organization_id) REFERENCES services (id, organization_id);
+
+CREATE INDEX idx_masters_organization_id ON masters (organization_id);
+CREATE INDEX idx_services_organization_id ON services (organization_id);
+CREATE INDEX idx_clients_organization_id ON clients (organization_id);
+CREATE INDEX idx_bookings_organization_id ON bookings (organization_id);
+CREATE INDEX idx_schedules_organization_id ON schedules (organization_id);
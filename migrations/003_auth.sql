@@ -0,0 +1,52 @@
+-- Table of organization administrators (salon managers)
+CREATE TABLE admins
+(
+    id              UUID PRIMARY KEY      DEFAULT uuidv7(),                                  -- unique identifier for the admin
+    organization_id UUID         NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    name            VARCHAR(255) NOT NULL,                                                   -- full name of the admin
+    email           VARCHAR(255) NOT NULL,                                                   -- login email
+    password_hash   TEXT         NOT NULL,                                                   -- bcrypt hash of the password
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- record creation timestamp
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- last update timestamp
+    UNIQUE (organization_id, email)
+);
+
+COMMENT ON TABLE admins IS 'Organization administrators (salon managers)';
+COMMENT ON COLUMN admins.id IS 'Unique identifier for the admin';
+COMMENT ON COLUMN admins.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN admins.name IS 'Full name of the admin';
+COMMENT ON COLUMN admins.email IS 'Login email, unique within the organization';
+COMMENT ON COLUMN admins.password_hash IS 'Bcrypt hash of the password';
+COMMENT ON COLUMN admins.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN admins.updated_at IS 'Last update timestamp';
+
+-- Enum type for principal role
+CREATE TYPE principal_role AS ENUM ('admin', 'master', 'client');
+COMMENT ON TYPE principal_role IS 'Role of an authenticated principal';
+
+-- Table of issued refresh tokens
+CREATE TABLE refresh_tokens
+(
+    id              UUID PRIMARY KEY        DEFAULT uuidv7(),                                  -- unique token identifier
+    organization_id UUID           NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- organization of the principal
+    subject_id      UUID           NOT NULL,                                                   -- admin, master or client id
+    role            principal_role NOT NULL,                                                   -- role of the principal
+    family_id       UUID           NOT NULL,                                                   -- chain of rotated tokens
+    token_hash      TEXT           NOT NULL UNIQUE,                                            -- SHA-256 of the opaque token
+    expires_at      TIMESTAMPTZ    NOT NULL,                                                   -- expiry timestamp
+    revoked_at      TIMESTAMPTZ,                                                               -- set when rotated or revoked
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT now()                                      -- record creation timestamp
+);
+
+COMMENT ON TABLE refresh_tokens IS 'Single-use refresh tokens, rotated on every refresh';
+COMMENT ON COLUMN refresh_tokens.id IS 'Unique token identifier';
+COMMENT ON COLUMN refresh_tokens.organization_id IS 'Organization of the principal';
+COMMENT ON COLUMN refresh_tokens.subject_id IS 'Identifier of the admin, master or client';
+COMMENT ON COLUMN refresh_tokens.role IS 'Role of the principal';
+COMMENT ON COLUMN refresh_tokens.family_id IS 'Identifier shared by all tokens rotated from the same login';
+COMMENT ON COLUMN refresh_tokens.token_hash IS 'SHA-256 hash of the opaque token';
+COMMENT ON COLUMN refresh_tokens.expires_at IS 'Expiry timestamp';
+COMMENT ON COLUMN refresh_tokens.revoked_at IS 'Timestamp when the token was rotated or revoked';
+COMMENT ON COLUMN refresh_tokens.created_at IS 'Record creation timestamp';
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
@@ -0,0 +1,26 @@
+-- Table of API keys used by server-to-server integrations (CRM, website backend)
+CREATE TABLE api_keys
+(
+    id              UUID PRIMARY KEY      DEFAULT uuidv7(),                                  -- unique identifier for the key
+    organization_id UUID         NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    name            VARCHAR(255) NOT NULL,                                                   -- human-readable name, e.g. CRM
+    prefix          VARCHAR(16)  NOT NULL,                                                   -- public part of the key shown in listings
+    key_hash        TEXT         NOT NULL UNIQUE,                                            -- SHA-256 of the full key
+    scopes          TEXT[]       NOT NULL DEFAULT '{}',                                      -- granted scopes, e.g. bookings:read
+    last_used_at    TIMESTAMPTZ,                                                             -- last successful authentication
+    revoked_at      TIMESTAMPTZ,                                                             -- set when the key is revoked
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now()                                      -- record creation timestamp
+);
+
+COMMENT ON TABLE api_keys IS 'Hashed API keys of server-to-server integrations';
+COMMENT ON COLUMN api_keys.id IS 'Unique identifier for the key';
+COMMENT ON COLUMN api_keys.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN api_keys.name IS 'Human-readable name of the integration';
+COMMENT ON COLUMN api_keys.prefix IS 'Public prefix of the key, used to tell keys apart';
+COMMENT ON COLUMN api_keys.key_hash IS 'SHA-256 hash of the full key';
+COMMENT ON COLUMN api_keys.scopes IS 'Granted scopes in the form resource:read or resource:write';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Timestamp of the last successful authentication';
+COMMENT ON COLUMN api_keys.revoked_at IS 'Timestamp when the key was revoked';
+COMMENT ON COLUMN api_keys.created_at IS 'Record creation timestamp';
+
+CREATE INDEX idx_api_keys_organization_id ON api_keys (organization_id);
@@ -0,0 +1,29 @@
+-- Transactional outbox: domain events are written in the same transaction as
+-- the state change and delivered to sinks by a background dispatcher
+CREATE TABLE outbox
+(
+    id              UUID PRIMARY KEY      DEFAULT uuidv7(),                                  -- unique event identifier
+    organization_id UUID         NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- organization the event belongs to
+    event_type      VARCHAR(100) NOT NULL,                                                   -- e.g. booking.created
+    aggregate_id    UUID         NOT NULL,                                                   -- id of the booking or schedule
+    payload         JSONB        NOT NULL,                                                   -- event data
+    occurred_at     TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- when the change happened
+    attempts        INT          NOT NULL DEFAULT 0,                                         -- number of failed deliveries
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- earliest time of the next delivery
+    last_error      TEXT,                                                                    -- error of the last failed delivery
+    delivered_at    TIMESTAMPTZ                                                              -- set once all sinks accepted the event
+);
+
+COMMENT ON TABLE outbox IS 'Domain events awaiting delivery to sinks (transactional outbox)';
+COMMENT ON COLUMN outbox.id IS 'Unique event identifier, also used by consumers for deduplication';
+COMMENT ON COLUMN outbox.organization_id IS 'Reference to the organization the event belongs to';
+COMMENT ON COLUMN outbox.event_type IS 'Event type, e.g., booking.created, schedule.updated';
+COMMENT ON COLUMN outbox.aggregate_id IS 'Identifier of the booking or schedule the event is about';
+COMMENT ON COLUMN outbox.payload IS 'Event data in JSON';
+COMMENT ON COLUMN outbox.occurred_at IS 'Timestamp of the state change';
+COMMENT ON COLUMN outbox.attempts IS 'Number of failed delivery attempts';
+COMMENT ON COLUMN outbox.next_attempt_at IS 'Earliest time of the next delivery attempt; also used as a lease while delivering';
+COMMENT ON COLUMN outbox.last_error IS 'Error of the last failed delivery attempt';
+COMMENT ON COLUMN outbox.delivered_at IS 'Timestamp when all sinks accepted the event';
+
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at) WHERE delivered_at IS NULL;
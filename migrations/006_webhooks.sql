@@ -0,0 +1,84 @@
+-- Table of webhook subscriptions of an organization
+CREATE TABLE webhook_subscriptions
+(
+    id              UUID PRIMARY KEY       DEFAULT uuidv7(),                                  -- unique identifier for the subscription
+    organization_id UUID          NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    url             VARCHAR(2048) NOT NULL,                                                   -- receiver endpoint
+    secret          TEXT          NOT NULL,                                                   -- HMAC-SHA256 signing secret
+    event_types     TEXT[]        NOT NULL DEFAULT '{}',                                      -- subscribed event types, empty for all
+    active          BOOLEAN       NOT NULL DEFAULT TRUE,                                      -- paused subscriptions receive nothing
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT now(),                                     -- record creation timestamp
+    updated_at      TIMESTAMPTZ   NOT NULL DEFAULT now()                                      -- last update timestamp
+);
+
+COMMENT ON TABLE webhook_subscriptions IS 'HTTP callbacks receiving domain events of an organization';
+COMMENT ON COLUMN webhook_subscriptions.id IS 'Unique identifier for the subscription';
+COMMENT ON COLUMN webhook_subscriptions.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN webhook_subscriptions.url IS 'Receiver endpoint, called with POST';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'Secret used to sign payloads with HMAC-SHA256';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'Subscribed event types, e.g., booking.created; empty means all events';
+COMMENT ON COLUMN webhook_subscriptions.active IS 'Whether events are delivered to the subscription';
+COMMENT ON COLUMN webhook_subscriptions.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN webhook_subscriptions.updated_at IS 'Last update timestamp';
+
+CREATE INDEX idx_webhook_subscriptions_organization_id ON webhook_subscriptions (organization_id);
+
+-- Enum type for webhook delivery status
+CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'delivered', 'dead');
+COMMENT ON TYPE webhook_delivery_status IS 'Status of a webhook delivery: pending (will be retried), delivered, dead (gave up)';
+
+-- Table of webhook deliveries, one per subscription and event
+CREATE TABLE webhook_deliveries
+(
+    id              UUID PRIMARY KEY                 DEFAULT uuidv7(),                                          -- unique delivery identifier
+    organization_id UUID                    NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,         -- owning organization
+    subscription_id UUID                    NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE, -- receiving subscription
+    event_id        UUID                    NOT NULL,                                                           -- outbox event identifier
+    event_type      VARCHAR(100)            NOT NULL,                                                           -- e.g. booking.created
+    body            JSONB                   NOT NULL,                                                           -- request body sent to the receiver
+    status          webhook_delivery_status NOT NULL DEFAULT 'pending',                                         -- delivery status
+    attempts        INT                     NOT NULL DEFAULT 0,                                                 -- number of attempts made
+    next_attempt_at TIMESTAMPTZ             NOT NULL DEFAULT now(),                                             -- earliest time of the next attempt
+    last_error      TEXT,                                                                                       -- error of the last failed attempt
+    delivered_at    TIMESTAMPTZ,                                                                                -- set on success
+    created_at      TIMESTAMPTZ             NOT NULL DEFAULT now(),                                             -- record creation timestamp
+    UNIQUE (subscription_id, event_id)
+);
+
+COMMENT ON TABLE webhook_deliveries IS 'Deliveries of domain events to webhook subscriptions';
+COMMENT ON COLUMN webhook_deliveries.id IS 'Unique delivery identifier, sent in the X-Chrono-Delivery header';
+COMMENT ON COLUMN webhook_deliveries.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN webhook_deliveries.subscription_id IS 'Reference to the receiving subscription';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'Identifier of the outbox event; stable across redeliveries';
+COMMENT ON COLUMN webhook_deliveries.event_type IS 'Event type, e.g., booking.created';
+COMMENT ON COLUMN webhook_deliveries.body IS 'Request body sent to the receiver';
+COMMENT ON COLUMN webhook_deliveries.status IS 'Delivery status: pending, delivered or dead';
+COMMENT ON COLUMN webhook_deliveries.attempts IS 'Number of delivery attempts made';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Earliest time of the next attempt; also used as a lease while sending';
+COMMENT ON COLUMN webhook_deliveries.last_error IS 'Error of the last failed attempt';
+COMMENT ON COLUMN webhook_deliveries.delivered_at IS 'Timestamp of the successful attempt';
+COMMENT ON COLUMN webhook_deliveries.created_at IS 'Record creation timestamp';
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, created_at);
+
+-- Table of individual webhook delivery attempts
+CREATE TABLE webhook_delivery_attempts
+(
+    id           UUID PRIMARY KEY     DEFAULT uuidv7(),                                       -- unique attempt identifier
+    delivery_id  UUID        NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE, -- delivery the attempt belongs to
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT now(),                                          -- when the request was sent
+    status_code  INT,                                                                         -- HTTP status of the response, if any
+    error        TEXT,                                                                        -- transport or status error
+    duration_ms  INT         NOT NULL                                                         -- request duration in milliseconds
+);
+
+COMMENT ON TABLE webhook_delivery_attempts IS 'Log of webhook delivery attempts';
+COMMENT ON COLUMN webhook_delivery_attempts.id IS 'Unique attempt identifier';
+COMMENT ON COLUMN webhook_delivery_attempts.delivery_id IS 'Reference to the delivery';
+COMMENT ON COLUMN webhook_delivery_attempts.attempted_at IS 'Timestamp when the request was sent';
+COMMENT ON COLUMN webhook_delivery_attempts.status_code IS 'HTTP status code of the response, NULL if no response was received';
+COMMENT ON COLUMN webhook_delivery_attempts.error IS 'Error of a failed attempt';
+COMMENT ON COLUMN webhook_delivery_attempts.duration_ms IS 'Request duration in milliseconds';
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts (delivery_id, attempted_at);
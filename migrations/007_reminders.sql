@@ -0,0 +1,38 @@
+-- Enum type for reminder status
+CREATE TYPE reminder_status AS ENUM ('pending', 'sending', 'sent', 'cancelled', 'failed');
+COMMENT ON TYPE reminder_status IS 'Status of a reminder: pending, sending (claimed by a worker), sent, cancelled, failed';
+
+-- Table of booking reminders sent to clients before the appointment
+CREATE TABLE reminders
+(
+    id              UUID PRIMARY KEY         DEFAULT uuidv7(),                                  -- unique reminder identifier
+    organization_id UUID            NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    booking_id      UUID            NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,      -- booking to remind about
+    offset_minutes  INT             NOT NULL CHECK (offset_minutes > 0),                      -- how long before the start to remind
+    send_at         TIMESTAMPTZ     NOT NULL,                                                 -- when the reminder is due
+    status          reminder_status NOT NULL DEFAULT 'pending',                               -- reminder status
+    attempts        INT             NOT NULL DEFAULT 0,                                       -- number of send attempts
+    claimed_at      TIMESTAMPTZ,                                                              -- when a worker started sending
+    sent_at         TIMESTAMPTZ,                                                              -- when the reminder was sent
+    last_error      TEXT,                                                                     -- error of the last failed attempt
+    created_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),                                   -- record creation timestamp
+    updated_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),                                   -- last update timestamp
+    UNIQUE (booking_id, offset_minutes)
+);
+
+COMMENT ON TABLE reminders IS 'Reminders about upcoming bookings, one per booking and offset';
+COMMENT ON COLUMN reminders.id IS 'Unique reminder identifier';
+COMMENT ON COLUMN reminders.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN reminders.booking_id IS 'Reference to the booking';
+COMMENT ON COLUMN reminders.offset_minutes IS 'Offset before the booking start in minutes, e.g., 1440 for 24 hours';
+COMMENT ON COLUMN reminders.send_at IS 'Timestamp when the reminder is due';
+COMMENT ON COLUMN reminders.status IS 'Reminder status: pending, sending, sent, cancelled or failed';
+COMMENT ON COLUMN reminders.attempts IS 'Number of send attempts';
+COMMENT ON COLUMN reminders.claimed_at IS 'Timestamp when a worker claimed the reminder for sending';
+COMMENT ON COLUMN reminders.sent_at IS 'Timestamp when the reminder was sent';
+COMMENT ON COLUMN reminders.last_error IS 'Error of the last failed attempt';
+COMMENT ON COLUMN reminders.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN reminders.updated_at IS 'Last update timestamp';
+
+CREATE INDEX idx_reminders_due ON reminders (send_at) WHERE status = 'pending';
+CREATE INDEX idx_reminders_sending ON reminders (claimed_at) WHERE status = 'sending';
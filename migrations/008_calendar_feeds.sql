@@ -0,0 +1,19 @@
+-- Table of secret ICS feed URLs of masters and clients
+CREATE TABLE calendar_feeds
+(
+    id              UUID PRIMARY KEY        DEFAULT uuidv7(),                                  -- unique feed identifier
+    organization_id UUID           NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    owner_role      principal_role NOT NULL CHECK (owner_role IN ('master', 'client')),      -- whose bookings the feed shows
+    owner_id        UUID           NOT NULL,                                                 -- master or client identifier
+    token_hash      TEXT           NOT NULL UNIQUE,                                          -- SHA-256 of the feed token
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT now(),                                   -- record creation timestamp
+    UNIQUE (owner_role, owner_id)
+);
+
+COMMENT ON TABLE calendar_feeds IS 'Secret ICS subscription feeds, one per master or client';
+COMMENT ON COLUMN calendar_feeds.id IS 'Unique feed identifier';
+COMMENT ON COLUMN calendar_feeds.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN calendar_feeds.owner_role IS 'Role of the feed owner: master or client';
+COMMENT ON COLUMN calendar_feeds.owner_id IS 'Identifier of the master or client';
+COMMENT ON COLUMN calendar_feeds.token_hash IS 'SHA-256 hash of the token in the feed URL';
+COMMENT ON COLUMN calendar_feeds.created_at IS 'Record creation timestamp, reset when the token is rotated';
@@ -0,0 +1,63 @@
+-- Table of external ICS calendars synced into busy blocks
+CREATE TABLE calendar_sources
+(
+    id              UUID PRIMARY KEY      DEFAULT uuidv7(),                                  -- unique source identifier
+    organization_id UUID         NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    master_id       UUID         NOT NULL,                                                   -- master whose time is blocked
+    name            VARCHAR(255) NOT NULL,                                                   -- human-readable name
+    url             TEXT         NOT NULL,                                                   -- ICS URL to fetch
+    next_sync_at    TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- when the source is synced next
+    last_synced_at  TIMESTAMPTZ,                                                             -- last successful sync
+    last_error      TEXT,                                                                    -- error of the last failed sync
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- record creation timestamp
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),                                     -- last update timestamp
+    FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE calendar_sources IS 'External ICS calendars of masters, periodically imported as busy blocks';
+COMMENT ON COLUMN calendar_sources.id IS 'Unique source identifier';
+COMMENT ON COLUMN calendar_sources.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN calendar_sources.master_id IS 'Reference to the master whose time the events block';
+COMMENT ON COLUMN calendar_sources.name IS 'Human-readable name, e.g. Personal calendar';
+COMMENT ON COLUMN calendar_sources.url IS 'URL of the ICS file';
+COMMENT ON COLUMN calendar_sources.next_sync_at IS 'Timestamp when the source is due for the next sync';
+COMMENT ON COLUMN calendar_sources.last_synced_at IS 'Timestamp of the last successful sync';
+COMMENT ON COLUMN calendar_sources.last_error IS 'Error of the last failed sync';
+COMMENT ON COLUMN calendar_sources.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN calendar_sources.updated_at IS 'Last update timestamp';
+
+CREATE INDEX idx_calendar_sources_master_id ON calendar_sources (master_id);
+CREATE INDEX idx_calendar_sources_next_sync_at ON calendar_sources (next_sync_at);
+
+-- Table of times when a master is busy outside of bookings
+CREATE TABLE busy_blocks
+(
+    id              UUID PRIMARY KEY     DEFAULT uuidv7(),                                  -- unique block identifier
+    organization_id UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    master_id       UUID        NOT NULL,                                                   -- busy master
+    source_id       UUID REFERENCES calendar_sources (id) ON DELETE CASCADE,              -- source of a synced block
+    uid             TEXT        NOT NULL,                                                   -- calendar event UID
+    summary         TEXT        NOT NULL DEFAULT '',                                        -- event title, private to the master
+    start_time      TIMESTAMPTZ NOT NULL,                                                   -- start of the busy time
+    end_time        TIMESTAMPTZ NOT NULL,                                                   -- end of the busy time
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),                                     -- record creation timestamp
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),                                     -- last update timestamp
+    CHECK (end_time > start_time),
+    UNIQUE (master_id, uid),
+    FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE busy_blocks IS 'Busy times of masters imported from external calendars, treated as occupied';
+COMMENT ON COLUMN busy_blocks.id IS 'Unique block identifier';
+COMMENT ON COLUMN busy_blocks.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN busy_blocks.master_id IS 'Reference to the busy master';
+COMMENT ON COLUMN busy_blocks.source_id IS 'Reference to the calendar source; NULL for uploaded files';
+COMMENT ON COLUMN busy_blocks.uid IS 'Event UID, with the recurrence id for instances of recurring events';
+COMMENT ON COLUMN busy_blocks.summary IS 'Event title, visible to the master and admins only';
+COMMENT ON COLUMN busy_blocks.start_time IS 'Start of the busy time';
+COMMENT ON COLUMN busy_blocks.end_time IS 'End of the busy time';
+COMMENT ON COLUMN busy_blocks.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN busy_blocks.updated_at IS 'Last update timestamp';
+
+CREATE INDEX idx_busy_blocks_master_time ON busy_blocks (master_id, start_time, end_time);
+CREATE INDEX idx_busy_blocks_source_id ON busy_blocks (source_id);
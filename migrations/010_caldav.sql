@@ -0,0 +1,25 @@
+-- Table of CalDAV app passwords of masters
+CREATE TABLE caldav_accounts
+(
+    id              UUID PRIMARY KEY     DEFAULT uuidv7(),                                  -- unique account identifier
+    organization_id UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    master_id       UUID        NOT NULL UNIQUE,                                            -- master signing in
+    token_hash      TEXT        NOT NULL UNIQUE,                                            -- SHA-256 of the app password
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),                                     -- record creation timestamp
+    FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE caldav_accounts IS 'App passwords of masters for CalDAV clients, one per master';
+COMMENT ON COLUMN caldav_accounts.id IS 'Unique account identifier';
+COMMENT ON COLUMN caldav_accounts.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN caldav_accounts.master_id IS 'Reference to the master; the master ID is the CalDAV user name';
+COMMENT ON COLUMN caldav_accounts.token_hash IS 'SHA-256 hash of the app password';
+COMMENT ON COLUMN caldav_accounts.created_at IS 'Record creation timestamp, reset when the password is rotated';
+
+-- Blocks created by CalDAV clients are stored under the name chosen by the client
+ALTER TABLE busy_blocks
+    ADD COLUMN href TEXT;
+
+COMMENT ON COLUMN busy_blocks.href IS 'CalDAV resource name chosen by the client; NULL for imported blocks';
+
+CREATE UNIQUE INDEX idx_busy_blocks_master_href ON busy_blocks (master_id, href) WHERE href IS NOT NULL;
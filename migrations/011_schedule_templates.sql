@@ -0,0 +1,11 @@
+-- Templates are schedules not bound to a master, copied into masters' schedules
+ALTER TABLE schedules
+    ADD COLUMN is_template BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE schedules SET is_template = TRUE WHERE master_id IS NULL;
+
+ALTER TABLE schedules
+    ADD CONSTRAINT schedules_template_master CHECK (is_template = (master_id IS NULL));
+
+COMMENT ON COLUMN schedules.is_template IS 'True for templates, which have no master and only serve to create schedules of masters';
+COMMENT ON COLUMN schedules.master_id IS 'Reference to the master; NULL for templates';
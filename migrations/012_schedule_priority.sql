@@ -0,0 +1,5 @@
+-- Among the schedules of a master covering a date, the one of the highest priority applies
+ALTER TABLE schedules
+    ADD COLUMN priority INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN schedules.priority IS 'Precedence over other schedules of the master covering the same dates; the highest applies, and schedules of the same priority may not overlap';
@@ -0,0 +1,39 @@
+-- Enum type for time-off request status
+CREATE TYPE time_off_status AS ENUM ('pending', 'approved', 'rejected');
+COMMENT ON TYPE time_off_status IS 'Status of a time-off request: pending (awaiting review), approved, rejected';
+
+-- Table of days off requested by masters and reviewed by managers
+CREATE TABLE time_off_requests
+(
+    id              UUID PRIMARY KEY         DEFAULT uuidv7(),                                  -- unique request identifier
+    organization_id UUID            NOT NULL REFERENCES organizations (id) ON DELETE CASCADE, -- owning organization
+    master_id       UUID            NOT NULL,                                                 -- master asking for time off
+    start_date      DATE            NOT NULL,                                                 -- first day off
+    end_date        DATE            NOT NULL,                                                 -- last day off, inclusive
+    reason          TEXT            NOT NULL DEFAULT '',                                      -- reason given by the master
+    status          time_off_status NOT NULL DEFAULT 'pending',                               -- review status
+    reviewed_by     UUID,                                                                     -- admin or API key that reviewed the request
+    reviewed_at     TIMESTAMPTZ,                                                              -- time of the review
+    review_comment  TEXT            NOT NULL DEFAULT '',                                      -- comment of the reviewer
+    created_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),                                   -- record creation timestamp
+    updated_at      TIMESTAMPTZ     NOT NULL DEFAULT now(),                                   -- last update timestamp
+    CHECK (end_date >= start_date),
+    FOREIGN KEY (master_id, organization_id) REFERENCES masters (id, organization_id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE time_off_requests IS 'Days off requested by masters; approved requests are applied to the schedule as day-off overrides';
+COMMENT ON COLUMN time_off_requests.id IS 'Unique request identifier';
+COMMENT ON COLUMN time_off_requests.organization_id IS 'Reference to the owning organization';
+COMMENT ON COLUMN time_off_requests.master_id IS 'Reference to the master asking for time off';
+COMMENT ON COLUMN time_off_requests.start_date IS 'First day off';
+COMMENT ON COLUMN time_off_requests.end_date IS 'Last day off, inclusive';
+COMMENT ON COLUMN time_off_requests.reason IS 'Reason given by the master, e.g. vacation';
+COMMENT ON COLUMN time_off_requests.status IS 'Review status: pending, approved or rejected';
+COMMENT ON COLUMN time_off_requests.reviewed_by IS 'Subject of the admin or API key that approved or rejected the request';
+COMMENT ON COLUMN time_off_requests.reviewed_at IS 'Timestamp of the approval or rejection';
+COMMENT ON COLUMN time_off_requests.review_comment IS 'Comment of the reviewer, e.g. the reason of a rejection';
+COMMENT ON COLUMN time_off_requests.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN time_off_requests.updated_at IS 'Last update timestamp';
+
+CREATE INDEX idx_time_off_requests_master_id ON time_off_requests (master_id, start_date);
+CREATE INDEX idx_time_off_requests_status ON time_off_requests (organization_id, status);
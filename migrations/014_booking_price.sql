@@ -0,0 +1,21 @@
+-- Price of the service snapshotted on the booking, so later price edits do
+-- not rewrite the revenue of past bookings
+ALTER TABLE bookings
+    ADD COLUMN price DECIMAL(10, 2);
+
+-- existing bookings get the current price of their service
+UPDATE bookings b
+SET price = s.price
+FROM services s
+WHERE s.id = b.service_id;
+
+UPDATE bookings
+SET price = 0
+WHERE price IS NULL;
+
+ALTER TABLE bookings
+    ALTER COLUMN price SET DEFAULT 0,
+    ALTER COLUMN price SET NOT NULL;
+
+COMMENT ON COLUMN bookings.price IS 'Price of the service at booking time; later changes of the service price do not affect it';
+
@@ -0,0 +1,5 @@
+-- Optional expiry of API keys, e.g. for keys handed to contractors
+ALTER TABLE api_keys
+    ADD COLUMN expires_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN api_keys.expires_at IS 'Timestamp after which the key is rejected; NULL for keys that do not expire';
@@ -0,0 +1,12 @@
+-- Delivery of outbox events is tracked per sink, so a failing sink neither
+-- delays nor duplicates the delivery to the others, and events failing for
+-- too long are dead-lettered
+ALTER TABLE outbox
+    ADD COLUMN delivered_sinks TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN dead_at         TIMESTAMPTZ;
+
+COMMENT ON COLUMN outbox.delivered_sinks IS 'Names of the sinks that accepted the event; they are skipped on retries';
+COMMENT ON COLUMN outbox.dead_at IS 'Timestamp when delivery was given up after the maximum number of attempts';
+
+DROP INDEX idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at) WHERE delivered_at IS NULL AND dead_at IS NULL;
@@ -0,0 +1,292 @@
+// Package caldav implements the XML bodies of the WebDAV and CalDAV requests
+// a calendar client needs to discover a calendar and sync its events
+// (RFC 4918, RFC 4791): PROPFIND, REPORT calendar-query and calendar-multiget,
+// and multistatus responses.
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// XML namespaces of the supported properties
+const (
+	NSDAV       = "DAV:"
+	NSCalDAV    = "urn:ietf:params:xml:ns:caldav"
+	NSCalServer = "http://calendarserver.org/ns/"
+)
+
+var ErrInvalidRequest = errors.New("invalid dav request")
+
+// Property names
+var (
+	ResourceType                  = xml.Name{Space: NSDAV, Local: "resourcetype"}
+	DisplayName                   = xml.Name{Space: NSDAV, Local: "displayname"}
+	GetETag                       = xml.Name{Space: NSDAV, Local: "getetag"}
+	GetContentType                = xml.Name{Space: NSDAV, Local: "getcontenttype"}
+	CurrentUserPrincipal          = xml.Name{Space: NSDAV, Local: "current-user-principal"}
+	PrincipalURL                  = xml.Name{Space: NSDAV, Local: "principal-URL"}
+	CalendarHomeSet               = xml.Name{Space: NSCalDAV, Local: "calendar-home-set"}
+	CalendarData                  = xml.Name{Space: NSCalDAV, Local: "calendar-data"}
+	SupportedCalendarComponentSet = xml.Name{Space: NSCalDAV, Local: "supported-calendar-component-set"}
+	GetCTag                       = xml.Name{Space: NSCalServer, Local: "getctag"}
+)
+
+// ParsePropFind returns the properties requested by a PROPFIND body. An
+// empty body or allprop requests all properties and returns nil.
+func ParsePropFind(r io.Reader) ([]xml.Name, error) {
+	var body struct {
+		XMLName xml.Name  `xml:"DAV: propfind"`
+		AllProp *struct{} `xml:"DAV: allprop"`
+		Prop    *propList `xml:"DAV: prop"`
+	}
+	if err := xml.NewDecoder(r).Decode(&body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	if body.AllProp != nil || body.Prop == nil {
+		return nil, nil
+	}
+	return body.Prop.names, nil
+}
+
+// Report is a REPORT request: a calendar-query, possibly limited to a time
+// range, or a calendar-multiget of the given hrefs.
+type Report struct {
+	Props []xml.Name
+
+	Multiget bool
+	Hrefs    []string
+
+	// Start and End of the time-range filter, zero if unbounded
+	Start, End time.Time
+}
+
+func ParseReport(r io.Reader) (*Report, error) {
+	var root struct {
+		XMLName xml.Name
+		Prop    *propList `xml:"DAV: prop"`
+		Hrefs   []string  `xml:"DAV: href"`
+		Filter  *struct {
+			Comps []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+		} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+	}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	report := &Report{}
+	if root.Prop != nil {
+		report.Props = root.Prop.names
+	}
+
+	switch root.XMLName {
+	case xml.Name{Space: NSCalDAV, Local: "calendar-multiget"}:
+		report.Multiget = true
+		for _, h := range root.Hrefs {
+			report.Hrefs = append(report.Hrefs, strings.TrimSpace(h))
+		}
+	case xml.Name{Space: NSCalDAV, Local: "calendar-query"}:
+		if root.Filter != nil {
+			if tr := findTimeRange(root.Filter.Comps); tr != nil {
+				var err error
+				if report.Start, err = parseUTC(tr.Start); err != nil {
+					return nil, err
+				}
+				if report.End, err = parseUTC(tr.End); err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported report %s", ErrInvalidRequest, root.XMLName.Local)
+	}
+	return report, nil
+}
+
+// propList collects the names of the requested properties.
+type propList struct {
+	names []xml.Name
+}
+
+func (p *propList) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			p.names = append(p.names, t.Name)
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type compFilter struct {
+	Name      string       `xml:"name,attr"`
+	TimeRange *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Comps     []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// findTimeRange returns the time range of the VEVENT filter, if any.
+func findTimeRange(comps []compFilter) *timeRange {
+	for _, c := range comps {
+		if c.Name == "VEVENT" && c.TimeRange != nil {
+			return c.TimeRange
+		}
+		if tr := findTimeRange(c.Comps); tr != nil {
+			return tr
+		}
+	}
+	return nil
+}
+
+func parseUTC(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("20060102T150405Z", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: time-range %q", ErrInvalidRequest, s)
+	}
+	return t, nil
+}
+
+// Response describes one resource of a multistatus response. Found maps the
+// properties to their raw XML values; requested properties missing from it
+// are reported as not found. A non-zero Status reports the resource itself,
+// e.g. http.StatusNotFound for an unknown href of a multiget.
+type Response struct {
+	Href   string
+	Status int
+	Found  map[xml.Name]string
+	Order  []xml.Name
+}
+
+// NewResponse returns a response with no properties.
+func NewResponse(href string) *Response {
+	return &Response{Href: href, Found: make(map[xml.Name]string)}
+}
+
+// Set adds a property with its raw XML value.
+func (r *Response) Set(name xml.Name, rawValue string) {
+	if _, ok := r.Found[name]; !ok {
+		r.Order = append(r.Order, name)
+	}
+	r.Found[name] = rawValue
+}
+
+// SetText adds a property with a text value.
+func (r *Response) SetText(name xml.Name, value string) {
+	r.Set(name, Escape(value))
+}
+
+// HrefValue is the raw value of a property containing a single href.
+func HrefValue(href string) string {
+	return "<d:href>" + Escape(href) + "</d:href>"
+}
+
+func Escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+var prefixes = map[string]string{
+	NSDAV:       "d",
+	NSCalDAV:    "c",
+	NSCalServer: "cs",
+}
+
+// WriteMultistatus writes the responses, each limited to the requested
+// properties; nil props reports all found properties.
+func WriteMultistatus(w io.Writer, responses []*Response, props []xml.Name) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+
+	for _, r := range responses {
+		b.WriteString("<d:response>")
+		b.WriteString(HrefValue(r.Href))
+
+		if r.Status != 0 {
+			writeStatus(&b, r.Status)
+			b.WriteString("</d:response>")
+			continue
+		}
+
+		found, missing := r.Order, []xml.Name(nil)
+		if props != nil {
+			found = nil
+			for _, p := range props {
+				if _, ok := r.Found[p]; ok {
+					found = append(found, p)
+				} else {
+					missing = append(missing, p)
+				}
+			}
+		}
+
+		if len(found) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, p := range found {
+				writeProp(&b, p, r.Found[p])
+			}
+			b.WriteString("</d:prop>")
+			writeStatus(&b, http.StatusOK)
+			b.WriteString("</d:propstat>")
+		}
+		if len(missing) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, p := range missing {
+				writeProp(&b, p, "")
+			}
+			b.WriteString("</d:prop>")
+			writeStatus(&b, http.StatusNotFound)
+			b.WriteString("</d:propstat>")
+		}
+
+		b.WriteString("</d:response>")
+	}
+
+	b.WriteString("</d:multistatus>")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeProp(b *strings.Builder, name xml.Name, value string) {
+	tag, decl := name.Local, ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		decl = ` xmlns:x="` + Escape(name.Space) + `"`
+	}
+
+	if value == "" {
+		fmt.Fprintf(b, "<%s%s/>", tag, decl)
+		return
+	}
+	fmt.Fprintf(b, "<%s%s>%s</%s>", tag, decl, value, tag)
+}
+
+func writeStatus(b *strings.Builder, code int) {
+	fmt.Fprintf(b, "<d:status>HTTP/1.1 %d %s</d:status>", code, http.StatusText(code))
+}
@@ -0,0 +1,83 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePropFind(t *testing.T) {
+	props, err := ParsePropFind(strings.NewReader(`<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/" xmlns:a="http://apple.com/ns/ical/">
+  <d:prop><d:getetag/><cs:getctag/><a:calendar-color/></d:prop>
+</d:propfind>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []xml.Name{GetETag, GetCTag, {Space: "http://apple.com/ns/ical/", Local: "calendar-color"}}, props)
+
+	props, err = ParsePropFind(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Nil(t, props)
+
+	_, err = ParsePropFind(strings.NewReader("<nope"))
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestParseReport(t *testing.T) {
+	t.Run("calendar-query", func(t *testing.T) {
+		report, err := ParseReport(strings.NewReader(`<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT">
+    <c:time-range start="20250301T000000Z" end="20250401T000000Z"/>
+  </c:comp-filter></c:comp-filter></c:filter>
+</c:calendar-query>`))
+		assert.NoError(t, err)
+		assert.False(t, report.Multiget)
+		assert.Equal(t, []xml.Name{GetETag, CalendarData}, report.Props)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), report.Start)
+		assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), report.End)
+	})
+
+	t.Run("calendar-multiget", func(t *testing.T) {
+		report, err := ParseReport(strings.NewReader(`<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <d:href>/caldav/m/calendar/a.ics</d:href>
+  <d:href> /caldav/m/calendar/b.ics </d:href>
+</c:calendar-multiget>`))
+		assert.NoError(t, err)
+		assert.True(t, report.Multiget)
+		assert.Equal(t, []string{"/caldav/m/calendar/a.ics", "/caldav/m/calendar/b.ics"}, report.Hrefs)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := ParseReport(strings.NewReader(`<d:sync-collection xmlns:d="DAV:"/>`))
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
+
+func TestWriteMultistatus(t *testing.T) {
+	found := NewResponse("/caldav/m/calendar/a.ics")
+	found.SetText(GetETag, `"1"`)
+	missing := &Response{Href: "/caldav/m/calendar/b.ics", Status: http.StatusNotFound}
+
+	var b strings.Builder
+	color := xml.Name{Space: "http://apple.com/ns/ical/", Local: "calendar-color"}
+	assert.NoError(t, WriteMultistatus(&b, []*Response{found, missing}, []xml.Name{GetETag, color}))
+
+	out := b.String()
+	assert.Contains(t, out, `<d:getetag>&#34;1&#34;</d:getetag></d:prop><d:status>HTTP/1.1 200 OK</d:status>`)
+	assert.Contains(t, out, `<x:calendar-color xmlns:x="http://apple.com/ns/ical/"/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status>`)
+	assert.Contains(t, out, `<d:href>/caldav/m/calendar/b.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>`)
+
+	// the output is well-formed
+	var ms struct {
+		Responses []struct {
+			Href string `xml:"href"`
+		} `xml:"response"`
+	}
+	assert.NoError(t, xml.Unmarshal([]byte(out), &ms))
+	assert.Len(t, ms.Responses, 2)
+}
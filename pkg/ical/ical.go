@@ -0,0 +1,155 @@
+// Package ical writes iCalendar (RFC 5545) data for booking invites and feeds.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	// maxLineOctets is the line length limit before folding
+	maxLineOctets = 75
+)
+
+// Calendar is a VCALENDAR object.
+type Calendar struct {
+	ProdID string
+	Method string
+	// Name is shown by clients subscribed to a feed
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT. Times are written in UTC.
+type Event struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Status      string
+	// Organizer and Attendees are email addresses
+	Organizer string
+	Attendees []string
+	// Transparent events do not block time
+	Transparent bool
+
+	// The fields below are read by Parse only
+	AllDay       bool
+	RRule        string
+	ExDates      []time.Time
+	RecurrenceID *time.Time
+}
+
+// Encode writes the calendar to w.
+func (c *Calendar) Encode(w io.Writer) error {
+	e := &encoder{w: w}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		e.line("METHOD", c.Method)
+	}
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", escape(c.Name))
+	}
+	for i := range c.Events {
+		c.Events[i].encode(e)
+	}
+	e.line("END", "VCALENDAR")
+	return e.err
+}
+
+// Bytes returns the encoded calendar.
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	_ = c.Encode(&buf)
+	return buf.Bytes()
+}
+
+func (ev *Event) encode(e *encoder) {
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", ev.UID)
+	e.line("SEQUENCE", fmt.Sprint(ev.Sequence))
+	e.line("DTSTAMP", formatTime(ev.Stamp))
+	e.line("DTSTART", formatTime(ev.Start))
+	e.line("DTEND", formatTime(ev.End))
+	e.line("SUMMARY", escape(ev.Summary))
+	if ev.Description != "" {
+		e.line("DESCRIPTION", escape(ev.Description))
+	}
+	if ev.Location != "" {
+		e.line("LOCATION", escape(ev.Location))
+	}
+	if ev.Status != "" {
+		e.line("STATUS", ev.Status)
+	}
+	if ev.Transparent {
+		e.line("TRANSP", "TRANSPARENT")
+	}
+	if ev.Organizer != "" {
+		e.line("ORGANIZER", "mailto:"+ev.Organizer)
+	}
+	for _, a := range ev.Attendees {
+		e.line("ATTENDEE;ROLE=REQ-PARTICIPANT", "mailto:"+a)
+	}
+	e.line("END", "VEVENT")
+}
+
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+// line writes a content line, folding it at 75 octets without splitting
+// UTF-8 sequences.
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	s := name + ":" + value
+
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > maxLineOctets {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+
+	_, e.err = io.WriteString(e.w, b.String())
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
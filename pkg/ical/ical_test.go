@@ -0,0 +1,36 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarEncode(t *testing.T) {
+	start := time.Date(2025, 3, 10, 12, 0, 0, 0, time.FixedZone("MSK", 3*3600))
+
+	cal := &Calendar{
+		ProdID: "-//Chrono//EN",
+		Method: MethodRequest,
+		Events: []Event{{
+			UID:         "b1@chrono",
+			Stamp:       start,
+			Start:       start,
+			End:         start.Add(time.Hour),
+			Summary:     "Haircut, Anna; room 2",
+			Description: strings.Repeat("долгое описание ", 8),
+			Status:      StatusConfirmed,
+		}},
+	}
+	out := string(cal.Bytes())
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Contains(t, out, "DTSTART:20250310T090000Z\r\n")
+	assert.Contains(t, out, `SUMMARY:Haircut\, Anna\; room 2`)
+	for _, line := range strings.Split(out, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	assert.Contains(t, strings.ReplaceAll(out, "\r\n ", ""), "DESCRIPTION:"+strings.Repeat("долгое описание ", 8))
+}
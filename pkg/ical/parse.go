@@ -0,0 +1,259 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCalendar = errors.New("invalid calendar")
+
+// Parse reads the VEVENTs of a calendar. Times without a zone are read in
+// loc. Events recurring by RRULE are returned once; see Occurrences.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events  []Event
+		current *Event
+		props   map[string]property
+		depth   int // of components nested in the VEVENT, e.g. VALARM
+	)
+	for _, line := range lines {
+		p, err := parseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT") && current == nil:
+			current = &Event{}
+			props = make(map[string]property)
+		case current == nil:
+			continue
+		case p.name == "BEGIN":
+			depth++
+		case p.name == "END" && depth > 0:
+			depth--
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if err := current.fill(props, loc); err != nil {
+				return nil, err
+			}
+			events = append(events, *current)
+			current = nil
+		case depth == 0:
+			if p.name == "EXDATE" {
+				// may repeat
+				for _, v := range strings.Split(p.value, ",") {
+					t, _, err := parseTime(property{name: p.name, params: p.params, value: v}, loc)
+					if err != nil {
+						return nil, err
+					}
+					current.ExDates = append(current.ExDates, t)
+				}
+				continue
+			}
+			props[p.name] = p
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("%w: unterminated VEVENT", ErrInvalidCalendar)
+	}
+	return events, nil
+}
+
+func (ev *Event) fill(props map[string]property, loc *time.Location) error {
+	uid, ok := props["UID"]
+	if !ok || uid.value == "" {
+		return fmt.Errorf("%w: VEVENT without UID", ErrInvalidCalendar)
+	}
+	ev.UID = uid.value
+
+	start, ok := props["DTSTART"]
+	if !ok {
+		return fmt.Errorf("%w: VEVENT %s without DTSTART", ErrInvalidCalendar, ev.UID)
+	}
+	var err error
+	if ev.Start, ev.AllDay, err = parseTime(start, loc); err != nil {
+		return err
+	}
+
+	switch {
+	case props["DTEND"].value != "":
+		if ev.End, _, err = parseTime(props["DTEND"], loc); err != nil {
+			return err
+		}
+	case props["DURATION"].value != "":
+		d, err := parseDuration(props["DURATION"].value)
+		if err != nil {
+			return err
+		}
+		ev.End = ev.Start.Add(d)
+	case ev.AllDay:
+		ev.End = ev.Start.AddDate(0, 0, 1)
+	default:
+		ev.End = ev.Start
+	}
+
+	if id, ok := props["RECURRENCE-ID"]; ok {
+		t, _, err := parseTime(id, loc)
+		if err != nil {
+			return err
+		}
+		ev.RecurrenceID = &t
+	}
+	if seq, ok := props["SEQUENCE"]; ok {
+		ev.Sequence, _ = strconv.Atoi(seq.value)
+	}
+	ev.Summary = unescape(props["SUMMARY"].value)
+	ev.Description = unescape(props["DESCRIPTION"].value)
+	ev.Location = unescape(props["LOCATION"].value)
+	ev.Status = strings.ToUpper(props["STATUS"].value)
+	ev.Transparent = strings.EqualFold(props["TRANSP"].value, "TRANSPARENT")
+	ev.RRule = props["RRULE"].value
+	return nil
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// unfold joins folded content lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits NAME;PARAM=VALUE:value, honouring quoted parameters.
+func parseProperty(line string) (property, error) {
+	p := property{params: make(map[string]string)}
+
+	quoted, colon := false, -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, fmt.Errorf("%w: malformed line %q", ErrInvalidCalendar, line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	p.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	p.value = line[colon+1:]
+	return p, nil
+}
+
+// parseTime reads a DATE or DATE-TIME value in UTC, in its TZID or in loc.
+func parseTime(p property, loc *time.Location) (t time.Time, allDay bool, err error) {
+	if tzid := p.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	value := p.value
+	switch {
+	case p.params["VALUE"] == "DATE" || len(value) == len("20060102"):
+		t, err = time.ParseInLocation("20060102", value, loc)
+		allDay = true
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse(dateTimeLayout, value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return t, false, fmt.Errorf("%w: %s %q", ErrInvalidCalendar, p.name, value)
+	}
+	return t, allDay, nil
+}
+
+// parseDuration reads a DURATION value such as PT1H30M or P1D.
+func parseDuration(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("%w: DURATION %q", ErrInvalidCalendar, s)
+
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, invalid
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, invalid
+		}
+		num = ""
+		switch {
+		case r == 'W' && !inTime:
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, invalid
+		}
+	}
+	if num != "" {
+		return 0, invalid
+	}
+	return sign * d, nil
+}
+
+var unescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescape(s string) string {
+	return unescaper.Replace(s)
+}
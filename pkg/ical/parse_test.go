@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const personalCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:shift@example.com\r\n" +
+	"DTSTART;TZID=Europe/Moscow:20250303T090000\r\n" +
+	"DURATION:PT4H\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4\r\n" +
+	"EXDATE;TZID=Europe/Moscow:20250305T090000\r\n" +
+	"SUMMARY:Second job\\, downtown\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT15M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:vacation@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20250320\r\n" +
+	"DTEND;VALUE=DATE:20250322\r\n" +
+	"SUMMARY:Very long summary that is folded over two lines by the calendar a\r\n" +
+	" pp\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(personalCalendar), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	shift := events[0]
+	assert.Equal(t, "shift@example.com", shift.UID)
+	assert.Equal(t, "Second job, downtown", shift.Summary)
+	assert.Equal(t, time.Date(2025, 3, 3, 6, 0, 0, 0, time.UTC), shift.Start.UTC())
+	assert.Equal(t, 4*time.Hour, shift.End.Sub(shift.Start))
+
+	vacation := events[1]
+	assert.True(t, vacation.AllDay)
+	assert.Equal(t, 48*time.Hour, vacation.End.Sub(vacation.Start))
+	assert.True(t, strings.HasSuffix(vacation.Summary, "calendar app"))
+
+	t.Run("occurrences", func(t *testing.T) {
+		from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		instances, err := shift.Occurrences(from, from.AddDate(0, 1, 0))
+		require.NoError(t, err)
+
+		// COUNT includes the excluded Wednesday
+		var days []int
+		for _, i := range instances {
+			days = append(days, i.Start.Day())
+			assert.Equal(t, i.Start, *i.RecurrenceID)
+		}
+		assert.Equal(t, []int{3, 10, 12}, days)
+	})
+
+	t.Run("unsupported rule", func(t *testing.T) {
+		monthly := Event{Start: time.Now(), End: time.Now(), RRule: "FREQ=MONTHLY;BYMONTHDAY=1"}
+		_, err := monthly.Occurrences(time.Now(), time.Now().AddDate(1, 0, 0))
+		assert.ErrorIs(t, err, ErrUnsupportedRule)
+	})
+}
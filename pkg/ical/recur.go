@@ -0,0 +1,177 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedRule = errors.New("unsupported recurrence rule")
+
+// maxInstances bounds the expansion of a rule without COUNT or UNTIL
+const maxInstances = 5000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Occurrences returns the instances of the event overlapping [from, to),
+// each with RecurrenceID set to its original start. An event without a rule
+// is its only instance. DAILY and WEEKLY rules with INTERVAL, COUNT, UNTIL
+// and BYDAY are supported, as are EXDATEs.
+func (ev *Event) Occurrences(from, to time.Time) ([]Event, error) {
+	if ev.RRule == "" {
+		if ev.Start.Before(to) && ev.End.After(from) {
+			return []Event{*ev}, nil
+		}
+		return nil, nil
+	}
+
+	rule, err := parseRule(ev.RRule, ev.Start.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	duration := ev.End.Sub(ev.Start)
+	var out []Event
+	count := 0
+	for start := range rule.starts(ev.Start) {
+		if rule.count > 0 && count >= rule.count {
+			break
+		}
+		if !rule.until.IsZero() && start.After(rule.until) {
+			break
+		}
+		if !start.Before(to) {
+			break
+		}
+		count++
+
+		if excluded(start, ev.ExDates) || !start.Add(duration).After(from) {
+			continue
+		}
+		instance := *ev
+		instance.Start = start
+		instance.End = start.Add(duration)
+		instance.RRule = ""
+		instance.ExDates = nil
+		recurrenceID := start
+		instance.RecurrenceID = &recurrenceID
+		out = append(out, instance)
+	}
+	return out, nil
+}
+
+type rule struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+func parseRule(s string, loc *time.Location) (*rule, error) {
+	r := &rule{interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		k, v, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			r.freq = strings.ToUpper(v)
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL %q", ErrInvalidCalendar, v)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT %q", ErrInvalidCalendar, v)
+			}
+			r.count = n
+		case "UNTIL":
+			t, _, err := parseTime(property{name: "UNTIL", value: v}, loc)
+			if err != nil {
+				return nil, err
+			}
+			if len(v) == len("20060102") {
+				// a date includes the whole day
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			r.until = t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				wd, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					// e.g. 1MO, which only makes sense for monthly rules
+					return nil, fmt.Errorf("%w: BYDAY %q", ErrUnsupportedRule, d)
+				}
+				r.byDay = append(r.byDay, wd)
+			}
+		case "WKST":
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRule, k)
+		}
+	}
+	if r.freq != "DAILY" && r.freq != "WEEKLY" {
+		return nil, fmt.Errorf("%w: FREQ=%s", ErrUnsupportedRule, r.freq)
+	}
+	if r.freq == "DAILY" && len(r.byDay) > 0 {
+		return nil, fmt.Errorf("%w: BYDAY with FREQ=DAILY", ErrUnsupportedRule)
+	}
+	return r, nil
+}
+
+// starts yields up to maxInstances candidate starts in order. Days are
+// added in the event's location, so instances keep their wall-clock time
+// across DST changes.
+func (r *rule) starts(first time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if r.freq == "DAILY" {
+			for i := 0; i < maxInstances; i++ {
+				if !yield(first.AddDate(0, 0, i*r.interval)) {
+					return
+				}
+			}
+			return
+		}
+
+		byDay := r.byDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{first.Weekday()}
+		}
+		// weeks start on Monday
+		weekStart := first.AddDate(0, 0, -mondayOffset(first.Weekday()))
+		for n, week := 0, 0; n < maxInstances; week++ {
+			base := weekStart.AddDate(0, 0, week*7*r.interval)
+			for offset := 0; offset < 7; offset++ {
+				day := base.AddDate(0, 0, offset)
+				if !slices.Contains(byDay, day.Weekday()) || day.Before(first) {
+					continue
+				}
+				n++
+				if !yield(day) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func mondayOffset(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+func excluded(t time.Time, exDates []time.Time) bool {
+	for _, ex := range exDates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package jwt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Role string `json:"role"`
+}
+
+func TestSignAndParseHS256(t *testing.T) {
+	key := []byte("secret")
+
+	t.Run("round trip", func(t *testing.T) {
+		in := &testClaims{
+			RegisteredClaims: RegisteredClaims{Subject: "42", ExpiresAt: time.Now().Add(time.Minute).Unix()},
+			Role:             "admin",
+		}
+
+		token, err := SignHS256(in, key)
+		assert.NoError(t, err)
+
+		out := &testClaims{}
+		assert.NoError(t, ParseHS256(token, key, out))
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		token, err := SignHS256(&testClaims{Role: "admin"}, key)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, ParseHS256(token, []byte("other"), &testClaims{}), ErrInvalidToken)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token, err := SignHS256(&testClaims{Role: "client"}, key)
+		assert.NoError(t, err)
+
+		forged, err := SignHS256(&testClaims{Role: "admin"}, []byte("other"))
+		assert.NoError(t, err)
+
+		parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+		tampered := parts[0] + "." + forgedParts[1] + "." + parts[2]
+		assert.ErrorIs(t, ParseHS256(tampered, key, &testClaims{}), ErrInvalidToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token, err := SignHS256(&testClaims{
+			RegisteredClaims: RegisteredClaims{ExpiresAt: time.Now().Add(-time.Second).Unix()},
+		}, key)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, ParseHS256(token, key, &testClaims{}), ErrTokenExpired)
+	})
+}